@@ -0,0 +1,471 @@
+package main
+
+import (
+	"app/internal"
+	"app/internal/codec"
+	"app/internal/dto/v2"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// command is a function that runs a command with its arguments
+type command func(ctx context.Context, a *app, args []string) error
+
+// commands are the commands by name
+var commands = map[string]command{
+	"list":   list,
+	"search": searchVehicles,
+	"get":    get,
+	"create": create,
+	"patch":  patch,
+	"delete": remove,
+	"import": importVehicles,
+	"export": exportVehicles,
+	"stats":  stats,
+}
+
+// list is a function that prints the vehicles matching the filters
+func list(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	var (
+		f         service.VehicleFilter
+		year      = optionalInt{dst: &f.Year}
+		yearFrom  = optionalInt{dst: &f.YearFrom}
+		yearTo    = optionalInt{dst: &f.YearTo}
+		length    = rangeFlag{dst: &f.Length}
+		width     = rangeFlag{dst: &f.Width}
+		weightMin = optionalFloat{dst: &f.WeightMin}
+		weightMax = optionalFloat{dst: &f.WeightMax}
+	)
+	fs.StringVar(&f.Color, "color", "", "color, together with -year")
+	fs.Var(&year, "year", "fabrication year, together with -color")
+	fs.StringVar(&f.Brand, "brand", "", "brand")
+	fs.Var(&yearFrom, "year-from", "first fabrication year of the brand")
+	fs.Var(&yearTo, "year-to", "last fabrication year of the brand")
+	fs.StringVar(&f.FuelType, "fuel-type", "", "fuel type")
+	fs.StringVar(&f.Transmission, "transmission", "", "transmission")
+	fs.Var(&length, "length", "range of the length, as min-max")
+	fs.Var(&width, "width", "range of the width, as min-max")
+	fs.Var(&weightMin, "weight-min", "minimum weight")
+	fs.Var(&weightMax, "weight-max", "maximum weight")
+	if err = a.parse(fs, args, 0); err != nil {
+		return
+	}
+	if (f.YearFrom != nil || f.YearTo != nil) && f.Brand == "" {
+		return errors.New("list: -year-from and -year-to need -brand")
+	}
+
+	v, err := service.FindByFilter(ctx, a.sv, f)
+	if err != nil {
+		return
+	}
+	return a.printVehicles(sorted(v))
+}
+
+// searchVehicles is a function that prints the vehicles matching the words of the arguments,
+// the most relevant first
+func searchVehicles(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("search <words...>", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "maximum number of vehicles")
+	if err = a.parse(fs, args, -1); err != nil {
+		return
+	}
+	if fs.NArg() == 0 {
+		return errors.New("usage: fleetctl search <words...>")
+	}
+
+	matches, err := a.sv.Search(ctx, strings.Join(fs.Args(), " "), *limit)
+	if err != nil {
+		return
+	}
+	v := make([]internal.Vehicle, 0, len(matches))
+	for _, m := range matches {
+		v = append(v, m.Vehicle)
+	}
+	return a.printVehicles(v)
+}
+
+// get is a function that prints the vehicle of an id
+func get(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("get <id> | get -plate <registration>", flag.ContinueOnError)
+	registration := fs.String("plate", "", "registration of the vehicle, instead of the id")
+	if err = a.parse(fs, args, -1); err != nil {
+		return
+	}
+	if (*registration == "") != (fs.NArg() == 1) || fs.NArg() > 1 {
+		return fmt.Errorf("usage: fleetctl %s", fs.Name())
+	}
+	var vh internal.Vehicle
+	if *registration != "" {
+		vh, err = a.sv.FindByRegistration(ctx, *registration)
+	} else {
+		vh, err = find(ctx, a, fs.Arg(0))
+	}
+	if err != nil {
+		return
+	}
+	return a.printVehicles([]internal.Vehicle{vh})
+}
+
+// create is a function that creates the vehicle of a JSON file, in the body format of the REST API
+func create(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	file := fs.String("f", "-", "JSON file of the vehicle, - is the standard input")
+	if err = a.parse(fs, args, 0); err != nil {
+		return
+	}
+	var rq v2.VehicleRequest
+	if err = a.readJSON(*file, &rq); err != nil {
+		return
+	}
+	attrs := rq.ToAttributes()
+	vh, err := a.sv.Save(ctx, &attrs)
+	if err != nil {
+		return
+	}
+	if err = a.commit(ctx); err != nil {
+		return
+	}
+	return a.printVehicles([]internal.Vehicle{vh})
+}
+
+// patch is a function that updates the attributes of a vehicle present in a JSON file,
+// the absent ones are kept
+func patch(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("patch <id>", flag.ContinueOnError)
+	file := fs.String("f", "-", "JSON file of the attributes, - is the standard input")
+	if err = a.parse(fs, args, 1); err != nil {
+		return
+	}
+	var rq v2.VehiclePatchRequest
+	if err = a.readJSON(*file, &rq); err != nil {
+		return
+	}
+	vh, err := find(ctx, a, fs.Arg(0))
+	if err != nil {
+		return
+	}
+	rq.ApplyTo(&vh.VehicleAttributes)
+	if vh, err = a.sv.Patch(ctx, &vh); err != nil {
+		return
+	}
+	if err = a.commit(ctx); err != nil {
+		return
+	}
+	return a.printVehicles([]internal.Vehicle{vh})
+}
+
+// remove is a function that deletes the vehicle of an id
+func remove(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("delete <id>", flag.ContinueOnError)
+	if err = a.parse(fs, args, 1); err != nil {
+		return
+	}
+	if _, err = find(ctx, a, fs.Arg(0)); err != nil {
+		return
+	}
+	if err = a.sv.DeleteById(ctx, fs.Arg(0)); err != nil {
+		return
+	}
+	return a.commit(ctx)
+}
+
+// importVehicles is a function that creates the vehicles of a file, all or none
+func importVehicles(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("f", "-", "file of the vehicles, - is the standard input")
+	format := fs.String("format", "", "format of the file: json, jsonl or csv, by default the extension of the file")
+	if err = a.parse(fs, args, 0); err != nil {
+		return
+	}
+	if *format == "" {
+		*format = formatOf(*file)
+	}
+	r, closeFn, err := a.open(*file)
+	if err != nil {
+		return
+	}
+	defer closeFn()
+	vs, err := codec.Decode(r, *format)
+	if err != nil {
+		return
+	}
+	if len(vs) == 0 {
+		return fmt.Errorf("import: %w: no vehicles in %s", apperrors.ErrInvalidVehicleData, *file)
+	}
+
+	attrs := make([]internal.VehicleAttributes, 0, len(vs))
+	for _, vh := range vs {
+		attrs = append(attrs, vh.VehicleAttributes)
+	}
+	v, err := a.sv.SaveMultipleVehicles(ctx, &attrs)
+	if err != nil {
+		return
+	}
+	if err = a.commit(ctx); err != nil {
+		return
+	}
+	return a.printVehicles(sorted(v))
+}
+
+// exportVehicles is a function that writes every vehicle to a file
+func exportVehicles(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("f", "-", "file to write, - is the standard output")
+	format := fs.String("format", "", "format of the file: json, jsonl or csv, by default the extension of the file")
+	if err = a.parse(fs, args, 0); err != nil {
+		return
+	}
+	if *format == "" {
+		*format = formatOf(*file)
+	}
+	v, err := a.sv.FindAll(ctx)
+	if err != nil {
+		return
+	}
+
+	w := a.stdout
+	if *file != "-" {
+		f, e := os.Create(*file)
+		if e != nil {
+			return e
+		}
+		defer func() {
+			if e := f.Close(); err == nil {
+				err = e
+			}
+		}()
+		w = f
+	}
+	enc, err := codec.NewEncoder(w, *format)
+	if err != nil {
+		return
+	}
+	for _, vh := range sorted(v) {
+		if err = enc.Encode(vh); err != nil {
+			return
+		}
+	}
+	return enc.Close()
+}
+
+// brandStats is a struct that represents the statistics of a brand
+type brandStats struct {
+	Brand        string  `json:"brand"`
+	AverageSpeed float64 `json:"average_speed"`
+	AverageCap   float64 `json:"average_capacity"`
+}
+
+// stats is a function that prints the average speed and capacity of the brands informed,
+// or of every brand of the fleet, computed from the vehicles, when none is
+func stats(ctx context.Context, a *app, args []string) (err error) {
+	fs := flag.NewFlagSet("stats [brand...]", flag.ContinueOnError)
+	if err = a.parse(fs, args, -1); err != nil {
+		return
+	}
+
+	if fs.NArg() == 0 {
+		v, e := a.sv.FindAll(ctx)
+		if e != nil {
+			return e
+		}
+		return a.printStats(fleetStats(v))
+	}
+	var s []brandStats
+	for _, brand := range fs.Args() {
+		speed, e := a.sv.FindVelocidadeMediaMarca(ctx, brand)
+		if e != nil {
+			return fmt.Errorf("stats: %s: %w", brand, e)
+		}
+		capacity, e := a.sv.FindMediaPessoaPorMarca(ctx, brand)
+		if e != nil {
+			return fmt.Errorf("stats: %s: %w", brand, e)
+		}
+		s = append(s, brandStats{Brand: brand, AverageSpeed: speed, AverageCap: float64(capacity)})
+	}
+	return a.printStats(s)
+}
+
+// fleetStats is a function that returns the statistics of every brand of v, ordered by brand
+func fleetStats(v map[int]internal.Vehicle) (s []brandStats) {
+	type sums struct {
+		speed    float64
+		capacity int
+		count    int
+	}
+	byBrand := make(map[string]*sums)
+	for _, vh := range v {
+		sm, ok := byBrand[vh.Brand]
+		if !ok {
+			sm = &sums{}
+			byBrand[vh.Brand] = sm
+		}
+		sm.speed += vh.MaxSpeed
+		sm.capacity += vh.Capacity
+		sm.count++
+	}
+	for brand, sm := range byBrand {
+		s = append(s, brandStats{
+			Brand:        brand,
+			AverageSpeed: sm.speed / float64(sm.count),
+			AverageCap:   float64(sm.capacity) / float64(sm.count),
+		})
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Brand < s[j].Brand })
+	return
+}
+
+// parse is a method that parses the flags of a command, that takes n positional
+// arguments, any number when n is negative
+func (a *app) parse(fs *flag.FlagSet, args []string, n int) (err error) {
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: fleetctl %s\n", fs.Name())
+		fs.PrintDefaults()
+	}
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+	if n >= 0 && fs.NArg() != n {
+		return fmt.Errorf("usage: fleetctl %s, %d arguments given", fs.Name(), fs.NArg())
+	}
+	return
+}
+
+// find is a function that returns the vehicle of an id, the service returning a zero
+// vehicle when it does not exist
+func find(ctx context.Context, a *app, id string) (vh internal.Vehicle, err error) {
+	if _, err = strconv.Atoi(id); err != nil {
+		return vh, fmt.Errorf("invalid id %q", id)
+	}
+	vh, err = a.sv.FindById(ctx, id)
+	if err == nil && vh.Id == 0 {
+		err = apperrors.ErrVehicleNotFound
+	}
+	return
+}
+
+// open is a method that opens a file to read, - is the standard input
+func (a *app) open(name string) (r io.Reader, closeFn func() error, err error) {
+	if name == "-" {
+		return a.stdin, func() error { return nil }, nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	return f, f.Close, nil
+}
+
+// readJSON is a method that decodes the JSON file name into v, rejecting unknown fields
+func (a *app) readJSON(name string, v any) (err error) {
+	r, closeFn, err := a.open(name)
+	if err != nil {
+		return
+	}
+	defer closeFn()
+	if err = v2.Decode(r, v); err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	return
+}
+
+// formatOf is a function that returns the format of a file by its extension, json by default
+func formatOf(name string) string {
+	switch ext := strings.TrimPrefix(filepath.Ext(name), "."); ext {
+	case codec.FormatJSONLines, codec.FormatCSV:
+		return ext
+	case "ndjson":
+		return codec.FormatJSONLines
+	}
+	return codec.FormatJSON
+}
+
+// sorted is a function that returns the vehicles ordered by id
+func sorted(v map[int]internal.Vehicle) []internal.Vehicle {
+	s := make([]internal.Vehicle, 0, len(v))
+	for _, vh := range v {
+		s = append(s, vh)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Id < s[j].Id })
+	return s
+}
+
+// optionalInt is a struct that implements flag.Value for an integer that may be absent
+type optionalInt struct {
+	dst **int
+}
+
+func (o *optionalInt) String() string {
+	if o.dst == nil || *o.dst == nil {
+		return ""
+	}
+	return strconv.Itoa(**o.dst)
+}
+
+func (o *optionalInt) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.New("must be an integer")
+	}
+	*o.dst = &n
+	return nil
+}
+
+// optionalFloat is a struct that implements flag.Value for a number that may be absent
+type optionalFloat struct {
+	dst **float64
+}
+
+func (o *optionalFloat) String() string {
+	if o.dst == nil || *o.dst == nil {
+		return ""
+	}
+	return strconv.FormatFloat(**o.dst, 'f', -1, 64)
+}
+
+func (o *optionalFloat) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return errors.New("must be a number")
+	}
+	*o.dst = &f
+	return nil
+}
+
+// rangeFlag is a struct that implements flag.Value for a range written as min-max
+type rangeFlag struct {
+	dst **service.Range
+}
+
+func (r *rangeFlag) String() string {
+	if r.dst == nil || *r.dst == nil {
+		return ""
+	}
+	return fmt.Sprintf("%g-%g", (*r.dst).Min, (*r.dst).Max)
+}
+
+func (r *rangeFlag) Set(s string) error {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		return errors.New("must be min-max")
+	}
+	min, err1 := strconv.ParseFloat(lo, 64)
+	max, err2 := strconv.ParseFloat(hi, 64)
+	if err1 != nil || err2 != nil || min > max {
+		return errors.New("must be min-max, with min not greater than max")
+	}
+	*r.dst = &service.Range{Min: min, Max: max}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"app/internal"
+	"app/internal/catalog"
+	"app/internal/client"
+	"app/internal/loader"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/pkg/tenant"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// usage is the help of the command
+const usage = `fleetctl is the command-line client of the fleet.
+
+Usage:
+  fleetctl [global flags] <command> [flags] [args]
+
+Commands:
+  list      list the vehicles, the filters are combined
+  search    search the vehicles by brand, model, color and registration
+  get       get a vehicle by id
+  create    create a vehicle from a JSON file
+  patch     update the attributes of a vehicle present in a JSON file
+  delete    delete a vehicle by id
+  import    create the vehicles of a file (json, jsonl or csv)
+  export    write every vehicle to a file (json, jsonl or csv)
+  stats     average speed and capacity by brand
+
+Global flags:
+`
+
+// app is a struct that represents the state shared by the commands
+type app struct {
+	// sv is the service the commands run against, remote or offline
+	sv internal.VehicleService
+	// save persists the vehicles after a change in offline mode, nil when remote
+	save func(ctx context.Context) error
+	// output is the format of the output: table, json or csv
+	output string
+	// stdin, stdout and stderr are the streams of the command
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		// the usage was already printed
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "fleetctl:", err)
+		os.Exit(1)
+	}
+}
+
+// run is a function that parses the global flags and runs the command of args
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
+	fs := flag.NewFlagSet("fleetctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	server := fs.String("server", envOr("FLEETCTL_SERVER", "http://localhost:8080"), "URL of the server (env FLEETCTL_SERVER)")
+	file := fs.String("file", os.Getenv("FLEETCTL_FILE"), "data file to work offline on, instead of the server (env FLEETCTL_FILE)")
+	catalogFile := fs.String("catalog", os.Getenv("FLEETCTL_CATALOG"), "catalog of the brands of the offline mode (env FLEETCTL_CATALOG)")
+	output := fs.String("o", "table", "output format: table, json or csv")
+	tenantID := fs.String("tenant", "", "tenant of the requests")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout of the command")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	if err = fs.Parse(args); err != nil {
+		return
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+	switch *output {
+	case outputTable, outputJSON, outputCSV:
+	default:
+		return fmt.Errorf("unknown output %q", *output)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if *tenantID != "" {
+		ctx = tenant.WithContext(ctx, *tenantID)
+	}
+
+	a := &app{output: *output, stdin: stdin, stdout: stdout, stderr: stderr}
+	if *file != "" {
+		// offline, against the data file, its brands written as in the catalog
+		var brands []internal.Brand
+		if *catalogFile != "" {
+			if brands, err = loader.NewBrandJSONFile(*catalogFile).Load(); err != nil {
+				return
+			}
+		}
+		ct, e := catalog.NewBrandMap(brands, nil)
+		if e != nil {
+			return e
+		}
+		ld := loader.NewVehicleJSONFile(*file)
+		db, e := catalog.NewVehicleLoader(ld, ct).Load()
+		if e != nil {
+			return e
+		}
+		rp := repository.NewVehicleMap(db)
+		a.sv = service.NewVehicleDefault(rp, ct, nil, nil)
+		a.save = func(ctx context.Context) error {
+			v, err := rp.FindAll(ctx)
+			if err != nil {
+				return err
+			}
+			return ld.Save(v)
+		}
+	} else {
+		a.sv = client.NewVehicleHTTP(*server, &http.Client{Timeout: *timeout})
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+	err = cmd(ctx, a, fs.Args()[1:])
+	return
+}
+
+// commit is a method that persists the vehicles after a change, in offline mode
+func (a *app) commit(ctx context.Context) error {
+	if a.save == nil {
+		return nil
+	}
+	return a.save(ctx)
+}
+
+// envOr is a function that returns the environment variable key, or def when it is empty
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
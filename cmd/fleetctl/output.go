@@ -0,0 +1,87 @@
+package main
+
+import (
+	"app/internal"
+	"app/internal/codec"
+	"app/internal/dto/v2"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+)
+
+// formats of the output
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputCSV   = "csv"
+)
+
+// printVehicles is a method that prints the vehicles in the output format
+func (a *app) printVehicles(v []internal.Vehicle) (err error) {
+	switch a.output {
+	case outputJSON:
+		res := make([]v2.VehicleResponse, 0, len(v))
+		for _, vh := range v {
+			res = append(res, v2.VehicleToResponse(vh))
+		}
+		return a.printJSON(res)
+	case outputCSV:
+		enc, e := codec.NewEncoder(a.stdout, codec.FormatCSV)
+		if e != nil {
+			return e
+		}
+		for _, vh := range v {
+			if err = enc.Encode(vh); err != nil {
+				return
+			}
+		}
+		return enc.Close()
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tBRAND\tMODEL\tREGISTRATION\tCOLOR\tYEAR\tPASSENGERS\tMAX SPEED\tFUEL\tTRANSMISSION\tWEIGHT\tH x L x W")
+	for _, vh := range v {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s x %s x %s\n",
+			vh.Id, vh.Brand, vh.Model, vh.Registration, vh.Color, vh.FabricationYear, vh.Capacity,
+			number(vh.MaxSpeed), vh.FuelType, vh.Transmission, number(vh.Weight),
+			number(vh.Height), number(vh.Length), number(vh.Width))
+	}
+	return tw.Flush()
+}
+
+// printStats is a method that prints the statistics of the brands in the output format
+func (a *app) printStats(s []brandStats) (err error) {
+	switch a.output {
+	case outputJSON:
+		return a.printJSON(s)
+	case outputCSV:
+		cw := csv.NewWriter(a.stdout)
+		_ = cw.Write([]string{"brand", "average_speed", "average_capacity"})
+		for _, st := range s {
+			_ = cw.Write([]string{st.Brand, number(st.AverageSpeed), number(st.AverageCap)})
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BRAND\tAVERAGE SPEED\tAVERAGE CAPACITY")
+	for _, st := range s {
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f\n", st.Brand, st.AverageSpeed, st.AverageCap)
+	}
+	return tw.Flush()
+}
+
+// printJSON is a method that prints v as indented JSON
+func (a *app) printJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// number is a function that formats f with the digits needed to read it back
+func number(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
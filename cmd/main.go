@@ -2,23 +2,69 @@ package main
 
 import (
 	"app/internal/application"
-	"fmt"
+	"app/pkg/logger"
+	"app/pkg/plate"
+	"log/slog"
+	"os"
+	"time"
 )
 
 func main() {
 	// env
-	// ...
+	logLevel := os.Getenv("LOG_LEVEL")
+	tracingExporter := os.Getenv("TRACING_EXPORTER")
+	tracingEndpoint := os.Getenv("TRACING_ENDPOINT")
+	// - logger of the start up, the application logging with its own at the same level
+	l := logger.New(os.Stdout, logLevel)
+	// - plate formats by tenant, e.g. "acme=mercosul;*=mercosul,legacy"
+	platePolicy, err := plate.ParsePolicy(os.Getenv("PLATE_FORMATS"))
+	if err != nil {
+		l.Error("main: invalid PLATE_FORMATS", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
 	// app
 	// - config
 	cfg := &application.ConfigServerChi{
-		ServerAddress: ":8080",
-		LoaderFilePath: "docs/db/vehicles_100.json",
+		ServerAddress:       ":8080",
+		GRPCAddress:         ":9090",
+		LoaderFilePath:      "docs/db/vehicles_100.json",
+		CatalogFilePath:     "docs/db/brands.json",
+		MaintenanceFilePath: "docs/db/maintenance.json",
+		DriverFilePath:      "docs/db/drivers.json",
+		ReservationFilePath: "docs/db/reservations.json",
+		TripFilePath:        "docs/db/trips.json",
+		FuelFilePath:        "docs/db/fuel.json",
+		ZoneFilePath:        "docs/db/zones.json",
+		AttributeFilePath:   "docs/db/attributes.json",
+		ReadTimeout:         10 * time.Second,
+		ReadHeaderTimeout:   5 * time.Second,
+		WriteTimeout:        15 * time.Second,
+		IdleTimeout:         60 * time.Second,
+		ShutdownTimeout:     20 * time.Second,
+		DrainDelay:          5 * time.Second,
+		RouteTimeout:        5 * time.Second,
+		RouteTimeouts: map[string]time.Duration{
+			"GET /vehicles":               10 * time.Second,
+			"POST /vehicles/batch":        10 * time.Second,
+			"GET /vehicles/dimensions":    2 * time.Second,
+			"GET /v1/vehicles":            10 * time.Second,
+			"POST /v1/vehicles/batch":     10 * time.Second,
+			"GET /v1/vehicles/dimensions": 2 * time.Second,
+			"GET /v2/vehicles":            10 * time.Second,
+			"POST /v2/vehicles/batch":     10 * time.Second,
+		},
+		LogLevel:        logLevel,
+		V1DeprecatedAt:  time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		V1Sunset:        time.Date(2027, time.April, 19, 0, 0, 0, 0, time.UTC),
+		TracingExporter: tracingExporter,
+		TracingEndpoint: tracingEndpoint,
+		PlatePolicy:     platePolicy,
 	}
 	app := application.NewServerChi(cfg)
 	// - run
 	if err := app.Run(); err != nil {
-		fmt.Println(err)
-		return
+		l.Error("main: application stopped", slog.String("error", err.Error()))
+		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
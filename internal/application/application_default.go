@@ -1,42 +1,238 @@
 package application
 
 import (
+	"app/internal"
+	"app/internal/catalog"
+	"app/internal/graphql"
+	fleetgrpc "app/internal/grpc"
 	"app/internal/handler"
 	"app/internal/loader"
+	"app/internal/metrics"
+	"app/internal/openapi"
 	"app/internal/repository"
 	"app/internal/service"
+	"app/internal/tracing"
+	"app/pkg/logger"
+	"app/pkg/plate"
+	"app/pkg/tenant"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
 )
 
 // ConfigServerChi is a struct that represents the configuration for ServerChi
 type ConfigServerChi struct {
 	// ServerAddress is the address where the server will be listening
 	ServerAddress string
+	// GRPCAddress is the address where the gRPC server will be listening
+	GRPCAddress string
 	// LoaderFilePath is the path to the file that contains the vehicles
 	LoaderFilePath string
+	// CatalogFilePath is the path to the file that contains the catalog of the brands, the changes
+	// made at runtime written back to it, empty for an empty catalog kept in memory
+	CatalogFilePath string
+	// MaintenanceFilePath is the path to the file that contains the maintenance events and plans,
+	// empty for none
+	MaintenanceFilePath string
+	// DriverFilePath is the path to the file that contains the drivers and their assignments,
+	// empty for none
+	DriverFilePath string
+	// ReservationFilePath is the path to the file that contains the reservations of the pool vehicles,
+	// empty for none
+	ReservationFilePath string
+	// TripFilePath is the path to the file that contains the trips of the vehicles, empty for none
+	TripFilePath string
+	// FuelFilePath is the path to the file that contains the fuel transactions of the vehicles,
+	// empty for none
+	FuelFilePath string
+	// ZoneFilePath is the path to the file that contains the zones of the geofencing, empty for none
+	ZoneFilePath string
+	// AttributeFilePath is the path to the file that contains the custom attributes of the vehicles
+	// of the tenants, empty for none
+	AttributeFilePath string
+	// DocumentDir is the directory of the files of the documents of the vehicles and of their
+	// metadata, documents.json, kept across restarts
+	DocumentDir string
+	// ReadTimeout is the maximum duration for reading the entire request
+	ReadTimeout time.Duration
+	// ReadHeaderTimeout is the maximum duration for reading the request headers
+	ReadHeaderTimeout time.Duration
+	// WriteTimeout is the maximum duration before timing out writes of the response
+	WriteTimeout time.Duration
+	// IdleTimeout is the maximum duration to wait for the next request on keep-alive connections
+	IdleTimeout time.Duration
+	// ShutdownTimeout is the maximum duration to wait for in-flight requests on shutdown
+	ShutdownTimeout time.Duration
+	// DrainDelay is how long /readyz reports draining on shutdown before the server stops
+	// accepting requests, for the load balancers to stop routing to it
+	DrainDelay time.Duration
+	// RouteTimeout is the default deadline of the request context of the vehicle routes
+	RouteTimeout time.Duration
+	// RouteTimeouts overrides RouteTimeout per route, keyed by "METHOD /pattern"
+	// (e.g. "GET /vehicles/dimensions")
+	RouteTimeouts map[string]time.Duration
+	// LogLevel is the minimum level of the logs (debug, info, warn or error)
+	LogLevel string
+	// V1DeprecatedAt is the date the v1 routes were deprecated, sent in the Deprecation header
+	V1DeprecatedAt time.Time
+	// V1Sunset is the date the v1 routes will be removed, sent in the Sunset header
+	V1Sunset time.Time
+	// PlatePolicy are the formats of the registrations accepted for each tenant, nil for any
+	PlatePolicy plate.Policy
+	// TelemetryRetention is how long the telemetry samples, the speed alerts and the zone events are kept
+	TelemetryRetention time.Duration
+	// GraphQLMaxDepth is the deepest nesting of fields accepted by /graphql
+	GraphQLMaxDepth int
+	// GraphQLMaxComplexity is the highest complexity accepted by /graphql
+	GraphQLMaxComplexity int
+	// ServiceName is the name of the service reported in the traces
+	ServiceName string
+	// TracingExporter is the exporter of the spans: "" (disabled), "stdout" or "otlp"
+	TracingExporter string
+	// TracingEndpoint is the host:port of the OTLP/HTTP collector
+	TracingEndpoint string
+	// TracingInsecure disables TLS for the OTLP exporter
+	TracingInsecure bool
 }
 
 // NewServerChi is a function that returns a new instance of ServerChi
 func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 	// default values
 	defaultConfig := &ConfigServerChi{
-		ServerAddress: ":8080",
+		ServerAddress:        ":8080",
+		GRPCAddress:          ":9090",
+		ReadTimeout:          10 * time.Second,
+		ReadHeaderTimeout:    5 * time.Second,
+		WriteTimeout:         15 * time.Second,
+		IdleTimeout:          60 * time.Second,
+		ShutdownTimeout:      20 * time.Second,
+		DrainDelay:           5 * time.Second,
+		RouteTimeout:         10 * time.Second,
+		LogLevel:             "info",
+		GraphQLMaxDepth:      8,
+		GraphQLMaxComplexity: 2000,
+		TelemetryRetention:   24 * time.Hour,
+		DocumentDir:          "documents",
+		ServiceName:          "go-api-rest",
 	}
 	if cfg != nil {
 		if cfg.ServerAddress != "" {
 			defaultConfig.ServerAddress = cfg.ServerAddress
 		}
+		if cfg.GRPCAddress != "" {
+			defaultConfig.GRPCAddress = cfg.GRPCAddress
+		}
 		if cfg.LoaderFilePath != "" {
 			defaultConfig.LoaderFilePath = cfg.LoaderFilePath
 		}
+		defaultConfig.CatalogFilePath = cfg.CatalogFilePath
+		defaultConfig.MaintenanceFilePath = cfg.MaintenanceFilePath
+		defaultConfig.DriverFilePath = cfg.DriverFilePath
+		defaultConfig.ReservationFilePath = cfg.ReservationFilePath
+		defaultConfig.TripFilePath = cfg.TripFilePath
+		defaultConfig.FuelFilePath = cfg.FuelFilePath
+		defaultConfig.ZoneFilePath = cfg.ZoneFilePath
+		defaultConfig.AttributeFilePath = cfg.AttributeFilePath
+		if cfg.DocumentDir != "" {
+			defaultConfig.DocumentDir = cfg.DocumentDir
+		}
+		if cfg.ReadTimeout > 0 {
+			defaultConfig.ReadTimeout = cfg.ReadTimeout
+		}
+		if cfg.ReadHeaderTimeout > 0 {
+			defaultConfig.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+		}
+		if cfg.WriteTimeout > 0 {
+			defaultConfig.WriteTimeout = cfg.WriteTimeout
+		}
+		if cfg.IdleTimeout > 0 {
+			defaultConfig.IdleTimeout = cfg.IdleTimeout
+		}
+		if cfg.ShutdownTimeout > 0 {
+			defaultConfig.ShutdownTimeout = cfg.ShutdownTimeout
+		}
+		if cfg.DrainDelay > 0 {
+			defaultConfig.DrainDelay = cfg.DrainDelay
+		}
+		if cfg.RouteTimeout > 0 {
+			defaultConfig.RouteTimeout = cfg.RouteTimeout
+		}
+		defaultConfig.RouteTimeouts = cfg.RouteTimeouts
+		if cfg.LogLevel != "" {
+			defaultConfig.LogLevel = cfg.LogLevel
+		}
+		defaultConfig.V1DeprecatedAt = cfg.V1DeprecatedAt
+		defaultConfig.V1Sunset = cfg.V1Sunset
+		defaultConfig.PlatePolicy = cfg.PlatePolicy
+		if cfg.TelemetryRetention > 0 {
+			defaultConfig.TelemetryRetention = cfg.TelemetryRetention
+		}
+		if cfg.GraphQLMaxDepth > 0 {
+			defaultConfig.GraphQLMaxDepth = cfg.GraphQLMaxDepth
+		}
+		if cfg.GraphQLMaxComplexity > 0 {
+			defaultConfig.GraphQLMaxComplexity = cfg.GraphQLMaxComplexity
+		}
+		if cfg.ServiceName != "" {
+			defaultConfig.ServiceName = cfg.ServiceName
+		}
+		defaultConfig.TracingExporter = cfg.TracingExporter
+		defaultConfig.TracingEndpoint = cfg.TracingEndpoint
+		defaultConfig.TracingInsecure = cfg.TracingInsecure
 	}
 
 	return &ServerChi{
-		serverAddress:  defaultConfig.ServerAddress,
-		loaderFilePath: defaultConfig.LoaderFilePath,
+		serverAddress:       defaultConfig.ServerAddress,
+		grpcAddress:         defaultConfig.GRPCAddress,
+		loaderFilePath:      defaultConfig.LoaderFilePath,
+		catalogFilePath:     defaultConfig.CatalogFilePath,
+		maintenanceFilePath: defaultConfig.MaintenanceFilePath,
+		driverFilePath:      defaultConfig.DriverFilePath,
+		reservationFilePath: defaultConfig.ReservationFilePath,
+		tripFilePath:        defaultConfig.TripFilePath,
+		fuelFilePath:        defaultConfig.FuelFilePath,
+		zoneFilePath:        defaultConfig.ZoneFilePath,
+		attributeFilePath:   defaultConfig.AttributeFilePath,
+		documentDir:         defaultConfig.DocumentDir,
+		readTimeout:         defaultConfig.ReadTimeout,
+		readHeaderTimeout:   defaultConfig.ReadHeaderTimeout,
+		writeTimeout:        defaultConfig.WriteTimeout,
+		idleTimeout:         defaultConfig.IdleTimeout,
+		shutdownTimeout:     defaultConfig.ShutdownTimeout,
+		drainDelay:          defaultConfig.DrainDelay,
+		routeTimeoutDefault: defaultConfig.RouteTimeout,
+		routeTimeouts:       defaultConfig.RouteTimeouts,
+		v1DeprecatedAt:      defaultConfig.V1DeprecatedAt,
+		v1Sunset:            defaultConfig.V1Sunset,
+		platePolicy:         defaultConfig.PlatePolicy,
+		telemetryRetention:  defaultConfig.TelemetryRetention,
+		graphqlLimits: graphql.Limits{
+			MaxDepth:      defaultConfig.GraphQLMaxDepth,
+			MaxComplexity: defaultConfig.GraphQLMaxComplexity,
+		},
+		logger:   logger.New(os.Stdout, defaultConfig.LogLevel),
+		registry: metrics.NewRegistry(),
+		tracing: tracing.Config{
+			ServiceName: defaultConfig.ServiceName,
+			Exporter:    defaultConfig.TracingExporter,
+			Endpoint:    defaultConfig.TracingEndpoint,
+			Insecure:    defaultConfig.TracingInsecure,
+		},
 	}
 }
 
@@ -44,80 +240,439 @@ func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 type ServerChi struct {
 	// serverAddress is the address where the server will be listening
 	serverAddress string
+	// grpcAddress is the address where the gRPC server will be listening
+	grpcAddress string
 	// loaderFilePath is the path to the file that contains the vehicles
 	loaderFilePath string
+	// catalogFilePath is the path to the file that contains the catalog of the brands
+	catalogFilePath string
+	// maintenanceFilePath is the path to the file that contains the maintenance events and plans
+	maintenanceFilePath string
+	// driverFilePath is the path to the file that contains the drivers and their assignments
+	driverFilePath string
+	// reservationFilePath is the path to the file that contains the reservations of the pool vehicles
+	reservationFilePath string
+	// tripFilePath is the path to the file that contains the trips of the vehicles
+	tripFilePath string
+	// fuelFilePath is the path to the file that contains the fuel transactions of the vehicles
+	fuelFilePath string
+	// zoneFilePath is the path to the file that contains the zones of the geofencing
+	zoneFilePath string
+	// attributeFilePath is the path to the file that contains the custom attributes of the vehicles of the tenants
+	attributeFilePath string
+	// documentDir is the directory of the files of the documents of the vehicles
+	documentDir string
+	// readTimeout, readHeaderTimeout, writeTimeout and idleTimeout are the timeouts of the http server
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	// shutdownTimeout is the maximum duration of the graceful shutdown
+	shutdownTimeout time.Duration
+	// drainDelay is how long the server keeps serving while reporting draining, before stopping
+	drainDelay time.Duration
+	// routeTimeoutDefault and routeTimeouts are the deadlines of the vehicle routes
+	routeTimeoutDefault time.Duration
+	routeTimeouts       map[string]time.Duration
+	// v1DeprecatedAt and v1Sunset are the dates announced by the v1 routes
+	v1DeprecatedAt time.Time
+	v1Sunset       time.Time
+	// platePolicy are the formats of the registrations accepted for each tenant
+	platePolicy plate.Policy
+	// telemetryRetention is how long the telemetry samples, the speed alerts and the zone events are kept
+	telemetryRetention time.Duration
+	// graphqlLimits are the limits of the queries of /graphql
+	graphqlLimits graphql.Limits
+	// logger is the structured logger of the application
+	logger *slog.Logger
+	// registry is the registry of the prometheus metrics
+	registry *prometheus.Registry
+	// tracing is the configuration of the tracer provider
+	tracing tracing.Config
+
+	// routerOnce and setUpOnce guard the routers and the dependencies, built once however many
+	// times Run or Routes is called since their collectors can only be registered once
+	routerOnce sync.Once
+	rt         *chi.Mux
+	setUpOnce  sync.Once
+	apiRt      *chi.Mux
+	gs         *grpc.Server
+	setUpErr   error
+	// api is the handler with the vehicle routes, available once the loader has finished
+	api atomic.Pointer[chi.Mux]
+	// draining is set once the shutdown has started, the readiness probe failing from then on
+	draining atomic.Bool
+	// onShutdown are the functions called after the server stopped accepting requests,
+	// used to flush and release the dependencies
+	onShutdown []func(ctx context.Context) error
+	// sweepers are the functions dropping the data past the retention, called periodically while running
+	sweepers []func(ctx context.Context) error
 }
 
 // Run is a method that runs the application
 func (a *ServerChi) Run() (err error) {
+	// signals
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	slog.SetDefault(a.logger)
+
+	// tracing
+	tp, err := tracing.NewTracerProvider(ctx, a.tracing)
+	if err != nil {
+		return
+	}
+	a.onShutdown = append(a.onShutdown, tp.Shutdown)
+
+	// router
+	rt := a.router()
+
+	// server
+	srv := &http.Server{
+		Addr:              a.serverAddress,
+		Handler:           rt,
+		ReadTimeout:       a.readTimeout,
+		ReadHeaderTimeout: a.readHeaderTimeout,
+		WriteTimeout:      a.writeTimeout,
+		IdleTimeout:       a.idleTimeout,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	// dependencies
+	api, gs, err := a.setUp()
+	if err != nil {
+		a.logger.Error("application: set up failed", slog.String("error", err.Error()))
+		err = errors.Join(err, a.shutdown(srv, nil))
+		return
+	}
+	a.api.Store(api)
+	a.onShutdown = append(a.onShutdown, a.sweep(min(a.telemetryRetention, time.Minute)))
+
+	// grpc server, sharing the service of the routes, listening once the loader has finished
+	lis, err := net.Listen("tcp", a.grpcAddress)
+	if err != nil {
+		a.logger.Error("application: grpc listen failed", slog.String("error", err.Error()))
+		err = errors.Join(err, a.shutdown(srv, nil))
+		return
+	}
+	grpcErrCh := make(chan error, 1)
+	go func() {
+		if err := gs.Serve(lis); err != nil {
+			grpcErrCh <- err
+		}
+		close(grpcErrCh)
+	}()
+	a.logger.Info("application: ready",
+		slog.String("address", a.serverAddress),
+		slog.String("grpc_address", a.grpcAddress),
+	)
+
+	// wait for a signal or a server failure
+	select {
+	case <-ctx.Done():
+		a.logger.Info("application: shutting down")
+		err = a.shutdown(srv, gs)
+	case err = <-errCh:
+		err = errors.Join(err, a.shutdown(srv, gs))
+	case err = <-grpcErrCh:
+		err = errors.Join(err, a.shutdown(srv, gs))
+	}
+	return
+}
+
+// router is a method that returns the router of the probes, the metrics and the docs, built once
+func (a *ServerChi) router() (rt *chi.Mux) {
+	a.routerOnce.Do(func() { a.rt = a.newRouter(openapi.NewAPIDocument()) })
+	return a.rt
+}
+
+// newRouter is a method that returns the router of the probes, the metrics and the docs, with the
+// api mounted at / and served once the loader has finished
+func (a *ServerChi) newRouter(doc *openapi.Document) (rt *chi.Mux) {
+	rt = chi.NewRouter()
+	// - middlewares
+	rt.Use(middleware.RequestID)
+	rt.Use(tenant.Middleware)
+	rt.Use(tracing.Middleware)
+	rt.Use(logger.Middleware(a.logger))
+	rt.Use(metrics.Middleware(a.registry))
+	rt.Use(middleware.Recoverer)
+	// - probes
+	rt.Get("/healthz", a.Healthz())
+	rt.Get("/readyz", a.Readyz())
+	// - metrics
+	rt.Method(http.MethodGet, "/metrics", metrics.Handler(a.registry))
+	// - docs
+	rt.Get("/openapi.json", openapi.Handler(doc))
+	rt.Get("/docs", openapi.UI())
+	rt.Method(http.MethodGet, "/docs/assets/*", openapi.Assets())
+	// - api, served once the loader has finished
+	rt.Mount("/", http.HandlerFunc(a.serveAPI))
+	return
+}
+
+// Routes is a method that returns the routers of the application, loading its dependencies as Run
+// does but starting no server, used to check the routes against the OpenAPI document
+func (a *ServerChi) Routes() (routes []chi.Routes, err error) {
+	api, _, err := a.setUp()
+	if err != nil {
+		return
+	}
+	routes = []chi.Routes{a.router(), api}
+	return
+}
+
+// setUp is a method that returns the router with the vehicle routes and the gRPC server, the
+// dependencies loaded once
+func (a *ServerChi) setUp() (rt *chi.Mux, gs *grpc.Server, err error) {
+	a.setUpOnce.Do(func() { a.apiRt, a.gs, a.setUpErr = a.load() })
+	return a.apiRt, a.gs, a.setUpErr
+}
+
+// load is a method that loads the vehicles and builds the router with the vehicle routes
+// and the gRPC server, both over the same service
+func (a *ServerChi) load() (rt *chi.Mux, gs *grpc.Server, err error) {
 	// dependencies
-	// - loader
-	ld := loader.NewVehicleJSONFile(a.loaderFilePath)
+	// - catalog of the brands, the brands and aliases added at runtime written back to its file
+	var brands []internal.Brand
+	var ldBrand internal.BrandLoader
+	if a.catalogFilePath != "" {
+		ld := loader.NewBrandJSONFile(a.catalogFilePath)
+		if brands, err = ld.Load(); err != nil {
+			return
+		}
+		ldBrand = ld
+	}
+	ct, err := catalog.NewBrandMap(brands, ldBrand)
+	if err != nil {
+		return
+	}
+	// - loader, the brands and models written as in the catalog
+	ld := metrics.NewVehicleLoader(catalog.NewVehicleLoader(loader.NewVehicleJSONFile(a.loaderFilePath), ct), a.registry)
 	db, err := ld.Load()
 	if err != nil {
 		return
 	}
+	for _, u := range ct.Unknown() {
+		a.logger.Warn("application: brand not in the catalog", slog.String("brand", u.Name), slog.Int("vehicles", u.Vehicles))
+	}
 	// - repository
-	rp := repository.NewVehicleMap(db)
+	rpMap := repository.NewVehicleMap(db)
+	for registration, ids := range rpMap.DuplicateRegistrations() {
+		a.logger.Warn("application: registration shared by vehicles", slog.String("registration", registration), slog.Any("ids", ids))
+	}
+	rp := tracing.NewVehicleRepository(metrics.NewVehicleRepository(rpMap, a.registry))
+	// - fleet gauges read the undecorated repository so scrapes are not timed as operations
+	a.registry.MustRegister(metrics.NewFleetCollector(rpMap))
+	// - maintenance of the vehicles
+	var maintenance internal.MaintenanceLog
+	if a.maintenanceFilePath != "" {
+		if maintenance, err = loader.NewMaintenanceJSONFile(a.maintenanceFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - drivers and their assignments
+	var drivers internal.DriverRegistry
+	if a.driverFilePath != "" {
+		if drivers, err = loader.NewDriverJSONFile(a.driverFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - reservations of the pool vehicles
+	var reservations []internal.Reservation
+	if a.reservationFilePath != "" {
+		if reservations, err = loader.NewReservationJSONFile(a.reservationFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - trips of the vehicles
+	var trips []internal.Trip
+	if a.tripFilePath != "" {
+		if trips, err = loader.NewTripJSONFile(a.tripFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - fuel transactions of the vehicles
+	var fuel []internal.FuelTransaction
+	if a.fuelFilePath != "" {
+		if fuel, err = loader.NewFuelJSONFile(a.fuelFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - zones of the geofencing
+	var zones []internal.Zone
+	if a.zoneFilePath != "" {
+		if zones, err = loader.NewZoneJSONFile(a.zoneFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - custom attributes of the vehicles of the tenants
+	var attributes []internal.AttributeDefinition
+	if a.attributeFilePath != "" {
+		if attributes, err = loader.NewAttributeJSONFile(a.attributeFilePath).Load(); err != nil {
+			return
+		}
+	}
+	// - documents of the vehicles, their metadata written beside their files
+	ldDocument := loader.NewDocumentJSONFile(filepath.Join(a.documentDir, "documents.json"))
+	documents, err := ldDocument.Load()
+	if err != nil {
+		return
+	}
+	rpDocument := repository.NewDocumentMap(documents, ldDocument)
+	a.onShutdown = append(a.onShutdown, rpDocument.Flush)
 	// - service
-	sv := service.NewVehicleDefault(rp)
+	rpAttribute := repository.NewAttributeMap(attributes)
+	sv := tracing.NewVehicleService(service.NewVehicleDefault(rp, ct, a.platePolicy, rpAttribute))
+	svMaintenance := service.NewMaintenanceDefault(repository.NewMaintenanceMap(maintenance), rp)
+	rpDriver := repository.NewDriverMap(drivers)
+	svDriver := service.NewDriverDefault(rpDriver, rp)
+	svReservation := service.NewReservationDefault(repository.NewReservationMap(reservations), rp)
+	svTrip := service.NewTripDefault(repository.NewTripMap(trips), rp, rpDriver)
+	svFuel := service.NewFuelDefault(repository.NewFuelMap(fuel), rp)
+	svZone := service.NewZoneDefault(repository.NewZoneMap(zones), repository.NewZoneEventLog(a.telemetryRetention))
+	rpTelemetry := repository.NewTelemetrySeries(a.telemetryRetention)
+	a.sweepers = append(a.sweepers, rpTelemetry.Sweep)
+	svTelemetry := metrics.NewTelemetryService(service.NewTelemetryGeofence(
+		service.NewTelemetryDefault(rpTelemetry, rp, a.telemetryRetention), svZone,
+	), a.registry)
 	// - handler
 	hd := handler.NewVehicleDefault(sv)
+	hdV2 := handler.NewVehicleV2(sv)
+	hdCatalog := handler.NewCatalogV2(service.NewCatalogCanonical(ct, rp))
+	hdMaintenance := handler.NewMaintenanceV2(svMaintenance)
+	hdDriver := handler.NewDriverV2(svDriver)
+	hdReservation := handler.NewReservationV2(svReservation, sv)
+	hdTrip := handler.NewTripV2(svTrip)
+	hdFuel := handler.NewFuelV2(svFuel)
+	hdTelemetry := handler.NewTelemetryV2(svTelemetry, sv, svReservation)
+	hdZone := handler.NewZoneV2(svZone)
+	hdAttribute := handler.NewAttributeV2(service.NewAttributeDefault(rpAttribute))
+	hdDocument := handler.NewDocumentV2(service.NewDocumentDefault(rpDocument, repository.NewBlobFS(a.documentDir), rp))
+	schema, err := graphql.NewSchema(sv)
+	if err != nil {
+		return
+	}
+	// - grpc
+	gs = fleetgrpc.NewServer(sv, a.logger, a.registry)
+	// - usage of the api versions
+	usage := promauto.With(a.registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "http_api_version_requests_total",
+		Help: "Total of requests by API version, method and route pattern.",
+	}, []string{"version", "method", "route"})
 	// router
-	rt := chi.NewRouter()
+	rt = chi.NewRouter()
 	// - middlewares
-	rt.Use(middleware.Logger)
-	rt.Use(middleware.Recoverer)
+	rt.Use(a.routeTimeout(rt))
 	// - endpoints
-	rt.Route("/vehicles", func(rt chi.Router) {
-		// - GET /vehicles
-		rt.Get("/", hd.GetAll())
-		// -  GET /GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
-		rt.Get("/brand/{brand}/between/{start_year}/{end_year}", hd.GetByMarcaAndYearInterval())
-		// -  GET /GET /vehicles/average_speed/brand/{brand}
-		rt.Get("/average_speed/brand/{brand}", hd.GetVelocidadeMediaMarca())
-
-		///vehicles/fuel_type/{type}
-		rt.Get("/fuel_type/{type}", hd.GetTipoCombustivel())
-
-		// Rota 1 adicionar veiculo
-		rt.Post("/", hd.Save())
-		// - POST multiplos veiculos
-		rt.Post("/batch", hd.SaveMultipleVehicles())
-
-		// - PATCH - vehicles/{id}
-		rt.Patch("/{id}", hd.Patch())
-		// - PATCH - vehicles/{id}/update_speed
-		rt.Patch("/{id}/update_speed", hd.UpdateMaxSpeed())
-		// - PATCH /vehicles/{id}/update_fuel
-
-		rt.Patch("/{id}/update_fuel", hd.UpdateFuel())
-
-		// - PATCH - /vehicles/transmission/{type}
-		rt.Get("/transmission/{type}", hd.GetTransmissionType())
+	// - v1, frozen, and its unversioned aliases, both deprecated in favor of v2
+	rt.Route("/v1", func(rt chi.Router) {
+		rt.Use(a.deprecated("/v2/vehicles"))
+		rt.Use(a.apiVersion("v1", usage))
+		routesV1(rt, hd)
+	})
+	rt.Group(func(rt chi.Router) {
+		rt.Use(a.deprecated("/v2/vehicles"))
+		rt.Use(a.apiVersion("unversioned", usage))
+		routesV1(rt, hd)
+	})
+	// - v2
+	rt.Route("/v2", func(rt chi.Router) {
+		rt.Use(a.apiVersion("v2", usage))
+		routesV2(rt, hdV2, hdCatalog, hdMaintenance, hdDriver, hdReservation, hdTrip, hdFuel, hdTelemetry, hdZone, hdDocument, hdAttribute)
+	})
+	// - graphql
+	rt.Group(func(rt chi.Router) {
+		rt.Use(a.apiVersion("graphql", usage))
+		rt.Method(http.MethodGet, "/graphql", graphql.Handler(schema, a.graphqlLimits))
+		rt.Method(http.MethodPost, "/graphql", graphql.Handler(schema, a.graphqlLimits))
+	})
 
-		// - GET -  /vehicles/average_capacity/brand/{brand}
-		// Obter a capacidade média de pessoas por marca
-		rt.Get("/average_capacity/brand/{brand}", hd.GetMediaPessoaPorMarca())
+	return
+}
 
-		// - DELETE - /vehicles/{id}
-		rt.Delete("/{id}", hd.DeleteById())
+// shutdown is a method that stops the HTTP and gRPC servers, waiting for the in-flight requests,
+// and then flushes the dependencies
+func (a *ServerChi) shutdown(srv *http.Server, gs *grpc.Server) (err error) {
+	// - report not ready and keep serving, for the load balancers to notice before the
+	// connections are closed
+	a.draining.Store(true)
+	if a.api.Load() != nil && a.drainDelay > 0 {
+		a.logger.Info("application: draining", slog.Duration("delay", a.drainDelay))
+		time.Sleep(a.drainDelay)
+	}
 
-		// - GET - /vehicles/dimensions?length={min_length}-{max_length}&width={min_width}-{max_width}
-		rt.Get("/dimensions", hd.GetByDimensions())
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
 
-		// - GET /vehicles/weight?min={weight_min}&max={weight_max}
-		rt.Get("/weight", hd.GetByPeso())
+	// - stop accepting requests and drain the open connections
+	err = srv.Shutdown(ctx)
+	if gs != nil {
+		stopped := make(chan struct{})
+		go func() {
+			gs.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			// the streams still open are cut
+			gs.Stop()
+			err = errors.Join(err, ctx.Err())
+		}
+	}
 
-	})
+	// - flush dependencies
+	for _, fn := range a.onShutdown {
+		err = errors.Join(err, fn(ctx))
+	}
+	return
+}
 
-	rt.Route("/vehiclesc", func(rt chi.Router) {
-		// - GET /vehicles by color and years
-		rt.Get("/", hd.GetByColorAndYears())
+// sweep is a method that calls the sweepers every interval, until the function returned is called
+func (a *ServerChi) sweep(interval time.Duration) (stop func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				for _, fn := range a.sweepers {
+					if err := fn(context.Background()); err != nil {
+						a.logger.Warn("application: sweep failed", slog.String("error", err.Error()))
+					}
+				}
+			}
+		}
+	}()
 
-	})
+	return func(ctx context.Context) error {
+		ticker.Stop()
+		close(done)
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
 
-	// run server
-	err = http.ListenAndServe(a.serverAddress, rt)
-	return
+// serveAPI is a method that serves the vehicle routes, or 503 while the loader is running
+func (a *ServerChi) serveAPI(w http.ResponseWriter, r *http.Request) {
+	api := a.api.Load()
+	if api == nil {
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	api.ServeHTTP(w, r)
 }
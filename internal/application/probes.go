@@ -0,0 +1,39 @@
+package application
+
+import (
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// Healthz is a method that returns a handler for the liveness probe GET /healthz
+func (a *ServerChi) Healthz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, map[string]any{
+			"status": "ok",
+		})
+	}
+}
+
+// Readyz is a method that returns a handler for the readiness probe GET /readyz,
+// reporting ready only after the loader has finished and until the shutdown starts
+func (a *ServerChi) Readyz() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if a.draining.Load() {
+			response.JSON(w, http.StatusServiceUnavailable, map[string]any{
+				"status": "draining",
+			})
+			return
+		}
+		if a.api.Load() == nil {
+			response.JSON(w, http.StatusServiceUnavailable, map[string]any{
+				"status": "loading",
+			})
+			return
+		}
+
+		response.JSON(w, http.StatusOK, map[string]any{
+			"status": "ready",
+		})
+	}
+}
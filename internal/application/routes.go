@@ -0,0 +1,250 @@
+package application
+
+import (
+	"app/internal/handler"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routesV1 is a function that registers the v1 vehicle routes, frozen as they were
+// before the versioning, on rt
+func routesV1(rt chi.Router, hd *handler.VehicleDefault) {
+	rt.Route("/vehicles", func(rt chi.Router) {
+		// - GET /vehicles
+		rt.Get("/", hd.GetAll())
+		// -  GET /GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
+		rt.Get("/brand/{brand}/between/{start_year}/{end_year}", hd.GetByMarcaAndYearInterval())
+		// -  GET /GET /vehicles/average_speed/brand/{brand}
+		rt.Get("/average_speed/brand/{brand}", hd.GetVelocidadeMediaMarca())
+
+		///vehicles/fuel_type/{type}
+		rt.Get("/fuel_type/{type}", hd.GetTipoCombustivel())
+
+		// Rota 1 adicionar veiculo
+		rt.Post("/", hd.Save())
+		// - POST multiplos veiculos
+		rt.Post("/batch", hd.SaveMultipleVehicles())
+
+		// - PATCH - vehicles/{id}
+		rt.Patch("/{id}", hd.Patch())
+		// - PATCH - vehicles/{id}/update_speed
+		rt.Patch("/{id}/update_speed", hd.UpdateMaxSpeed())
+		// - PATCH /vehicles/{id}/update_fuel
+
+		rt.Patch("/{id}/update_fuel", hd.UpdateFuel())
+
+		// - PATCH - /vehicles/transmission/{type}
+		rt.Get("/transmission/{type}", hd.GetTransmissionType())
+
+		// - GET -  /vehicles/average_capacity/brand/{brand}
+		// Obter a capacidade média de pessoas por marca
+		rt.Get("/average_capacity/brand/{brand}", hd.GetMediaPessoaPorMarca())
+
+		// - DELETE - /vehicles/{id}
+		rt.Delete("/{id}", hd.DeleteById())
+
+		// - GET - /vehicles/dimensions?length={min_length}-{max_length}&width={min_width}-{max_width}
+		rt.Get("/dimensions", hd.GetByDimensions())
+
+		// - GET /vehicles/weight?min={weight_min}&max={weight_max}
+		rt.Get("/weight", hd.GetByPeso())
+
+	})
+
+	rt.Route("/vehiclesc", func(rt chi.Router) {
+		// - GET /vehicles by color and years
+		rt.Get("/", hd.GetByColorAndYears())
+
+	})
+}
+
+// routesV2 is a function that registers the v2 vehicle and catalog routes on rt
+func routesV2(rt chi.Router, hd *handler.VehicleV2, hdCatalog *handler.CatalogV2, hdMaintenance *handler.MaintenanceV2, hdDriver *handler.DriverV2, hdReservation *handler.ReservationV2, hdTrip *handler.TripV2, hdFuel *handler.FuelV2, hdTelemetry *handler.TelemetryV2, hdZone *handler.ZoneV2, hdDocument *handler.DocumentV2, hdAttribute *handler.AttributeV2) {
+	rt.Route("/vehicles", func(rt chi.Router) {
+		// - GET /v2/vehicles?color=&year=&brand=&year_from=&year_to=&fuel_type=&transmission=&length=&width=&weight_min=&weight_max=
+		rt.Get("/", hd.List())
+		// - GET /v2/vehicles/search?q=&limit=
+		rt.Get("/search", hd.Search())
+		// - POST /v2/vehicles
+		rt.Post("/", hd.Create())
+		// - POST /v2/vehicles/batch
+		rt.Post("/batch", hd.CreateBatch())
+		// - GET /v2/vehicles/compare
+		rt.Get("/compare", hd.Compare())
+		// - GET /v2/vehicles/registration/{plate}
+		rt.Get("/registration/{plate}", hd.GetByRegistration())
+		// - GET /v2/vehicles/{id}
+		rt.Get("/{id}", hd.Get())
+		// - PATCH /v2/vehicles/{id}
+		rt.Patch("/{id}", hd.Patch())
+		// - DELETE /v2/vehicles/{id}
+		rt.Delete("/{id}", hd.Delete())
+
+		rt.Route("/{id}/maintenance", func(rt chi.Router) {
+			// - GET /v2/vehicles/{id}/maintenance
+			rt.Get("/", hdMaintenance.Events())
+			// - POST /v2/vehicles/{id}/maintenance
+			rt.Post("/", hdMaintenance.RecordEvent())
+			// - DELETE /v2/vehicles/{id}/maintenance/{event}
+			rt.Delete("/{event}", hdMaintenance.DeleteEvent())
+			// - GET /v2/vehicles/{id}/maintenance/plans
+			rt.Get("/plans", hdMaintenance.Plans())
+			// - POST /v2/vehicles/{id}/maintenance/plans
+			rt.Post("/plans", hdMaintenance.SavePlan())
+			// - DELETE /v2/vehicles/{id}/maintenance/plans/{plan}
+			rt.Delete("/plans/{plan}", hdMaintenance.DeletePlan())
+			// - GET /v2/vehicles/{id}/maintenance/due?at=&within_days=&within_km=
+			rt.Get("/due", hdMaintenance.Due())
+		})
+
+		// - GET /v2/vehicles/{id}/assignments
+		rt.Get("/{id}/assignments", hdDriver.VehicleAssignments())
+		// - POST /v2/vehicles/{id}/assignments
+		rt.Post("/{id}/assignments", hdDriver.Assign())
+		// - GET /v2/vehicles/{id}/assignment?at=
+		rt.Get("/{id}/assignment", hdDriver.VehicleCurrent())
+		// - DELETE /v2/vehicles/{id}/assignment?at=
+		rt.Delete("/{id}/assignment", hdDriver.Unassign())
+		// - GET /v2/vehicles/{id}/trips?from=&to=
+		rt.Get("/{id}/trips", hdTrip.Trips())
+		// - POST /v2/vehicles/{id}/trips
+		rt.Post("/{id}/trips", hdTrip.Record())
+		// - DELETE /v2/vehicles/{id}/trips/{trip}
+		rt.Delete("/{id}/trips/{trip}", hdTrip.Delete())
+		// - GET /v2/vehicles/{id}/fuel
+		rt.Get("/{id}/fuel", hdFuel.Transactions())
+		// - POST /v2/vehicles/{id}/fuel
+		rt.Post("/{id}/fuel", hdFuel.Record())
+		// - DELETE /v2/vehicles/{id}/fuel/{transaction}
+		rt.Delete("/{id}/fuel/{transaction}", hdFuel.Delete())
+		// - GET /v2/vehicles/{id}/fuel/consumption?tolerance=
+		rt.Get("/{id}/fuel/consumption", hdFuel.Consumption())
+		// - GET /v2/vehicles/{id}/documents
+		rt.Get("/{id}/documents", hdDocument.List())
+		// - POST /v2/vehicles/{id}/documents
+		rt.Post("/{id}/documents", hdDocument.Upload())
+		// - GET /v2/vehicles/{id}/documents/{document}
+		rt.Get("/{id}/documents/{document}", hdDocument.Get())
+		// - DELETE /v2/vehicles/{id}/documents/{document}
+		rt.Delete("/{id}/documents/{document}", hdDocument.Delete())
+		// - GET /v2/vehicles/{id}/documents/{document}/content
+		rt.Get("/{id}/documents/{document}/content", hdDocument.Content())
+		// - GET /v2/vehicles/{id}/documents/{document}/thumbnail
+		rt.Get("/{id}/documents/{document}/thumbnail", hdDocument.Thumbnail())
+		// - GET /v2/vehicles/nearby?lat=&lon=&radius=&limit=&available_until=&...
+		rt.Get("/nearby", hdTelemetry.Nearby())
+		// - GET /v2/vehicles/{id}/position
+		rt.Get("/{id}/position", hdTelemetry.Position())
+		// - GET /v2/vehicles/{id}/track?from=&to=
+		rt.Get("/{id}/track", hdTelemetry.Track())
+	})
+
+	rt.Route("/drivers", func(rt chi.Router) {
+		// - GET /v2/drivers
+		rt.Get("/", hdDriver.List())
+		// - POST /v2/drivers
+		rt.Post("/", hdDriver.Create())
+		// - GET /v2/drivers/{driver}
+		rt.Get("/{driver}", hdDriver.Get())
+		// - PATCH /v2/drivers/{driver}
+		rt.Patch("/{driver}", hdDriver.Patch())
+		// - GET /v2/drivers/{driver}/assignments
+		rt.Get("/{driver}/assignments", hdDriver.DriverAssignments())
+		// - GET /v2/drivers/{driver}/assignment?at=
+		rt.Get("/{driver}/assignment", hdDriver.DriverCurrent())
+	})
+
+	rt.Route("/reservations", func(rt chi.Router) {
+		// - GET /v2/reservations?vehicle_id=&from=&to=&status=&format=
+		rt.Get("/", hdReservation.List())
+		// - POST /v2/reservations
+		rt.Post("/", hdReservation.Create())
+		// - GET /v2/reservations/availability?start=&end=&...
+		rt.Get("/availability", hdReservation.Availability())
+		// - GET /v2/reservations/{reservation}
+		rt.Get("/{reservation}", hdReservation.Get())
+		// - POST /v2/reservations/{reservation}/cancel
+		rt.Post("/{reservation}/cancel", hdReservation.Cancel())
+		// - POST /v2/reservations/{reservation}/checkout?at=
+		rt.Post("/{reservation}/checkout", hdReservation.CheckOut())
+		// - POST /v2/reservations/{reservation}/checkin?at=
+		rt.Post("/{reservation}/checkin", hdReservation.CheckIn())
+	})
+
+	rt.Route("/utilization", func(rt chi.Router) {
+		// - GET /v2/utilization?from=&to=
+		rt.Get("/", hdTrip.Utilization())
+		// - GET /v2/utilization/underused?from=&to=&min_km=&min_usage=
+		rt.Get("/underused", hdTrip.Underused())
+	})
+
+	rt.Route("/telemetry", func(rt chi.Router) {
+		// - POST /v2/telemetry
+		rt.Post("/", hdTelemetry.Ingest())
+		// - GET /v2/telemetry/alerts?vehicle_id=&from=
+		rt.Get("/alerts", hdTelemetry.Alerts())
+	})
+
+	rt.Route("/documents", func(rt chi.Router) {
+		// - GET /v2/documents/expiring?at=&within_days=
+		rt.Get("/expiring", hdDocument.Expiring())
+	})
+
+	rt.Route("/zones", func(rt chi.Router) {
+		// - GET /v2/zones
+		rt.Get("/", hdZone.List())
+		// - POST /v2/zones
+		rt.Post("/", hdZone.Create())
+		// - GET /v2/zones/events?zone_id=&vehicle_id=&from=
+		rt.Get("/events", hdZone.Events())
+		// - GET /v2/zones/{zone}
+		rt.Get("/{zone}", hdZone.Get())
+		// - PUT /v2/zones/{zone}
+		rt.Put("/{zone}", hdZone.Replace())
+		// - DELETE /v2/zones/{zone}
+		rt.Delete("/{zone}", hdZone.Delete())
+		// - GET /v2/zones/{zone}/vehicles
+		rt.Get("/{zone}/vehicles", hdZone.Occupants())
+	})
+
+	rt.Route("/attributes", func(rt chi.Router) {
+		// - GET /v2/attributes
+		rt.Get("/", hdAttribute.List())
+		// - POST /v2/attributes
+		rt.Post("/", hdAttribute.Create())
+		// - GET /v2/attributes/{name}
+		rt.Get("/{name}", hdAttribute.Get())
+		// - PUT /v2/attributes/{name}
+		rt.Put("/{name}", hdAttribute.Replace())
+		// - DELETE /v2/attributes/{name}
+		rt.Delete("/{name}", hdAttribute.Delete())
+	})
+
+	rt.Route("/fuel", func(rt chi.Router) {
+		// - GET /v2/fuel/anomalies?tolerance=
+		rt.Get("/anomalies", hdFuel.Anomalies())
+	})
+
+	rt.Route("/maintenance", func(rt chi.Router) {
+		// - GET /v2/maintenance/due?at=&within_days=&within_km=
+		rt.Get("/due", hdMaintenance.FleetDue())
+	})
+
+	rt.Route("/brands", func(rt chi.Router) {
+		// - GET /v2/brands/{brand}/stats
+		rt.Get("/{brand}/stats", hd.BrandStats())
+	})
+
+	rt.Route("/catalog", func(rt chi.Router) {
+		// - GET /v2/catalog/brands
+		rt.Get("/brands", hdCatalog.Brands())
+		// - PUT /v2/catalog/brands/{brand}
+		rt.Put("/brands/{brand}", hdCatalog.SaveBrand())
+		// - PUT /v2/catalog/brands/{brand}/aliases/{alias}
+		rt.Put("/brands/{brand}/aliases/{alias}", hdCatalog.AddAlias())
+		// - DELETE /v2/catalog/brands/{brand}/aliases/{alias}
+		rt.Delete("/brands/{brand}/aliases/{alias}", hdCatalog.RemoveAlias())
+		// - GET /v2/catalog/unknown_brands
+		rt.Get("/unknown_brands", hdCatalog.UnknownBrands())
+	})
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// routeTimeout is a method that returns a middleware bounding the request context with
+// the timeout configured for the matched route ("METHOD /pattern"), or the default one
+func (a *ServerChi) routeTimeout(routes chi.Routes) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := a.routeTimeoutDefault
+
+			// the pattern is resolved up front as the middleware runs before the routing
+			path := r.URL.Path
+			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+				path = rctx.RoutePath
+			}
+			tctx := chi.NewRouteContext()
+			if routes.Match(tctx, r.Method, path) {
+				if d, ok := a.routeTimeouts[r.Method+" "+tctx.RoutePattern()]; ok {
+					timeout = d
+				}
+			}
+
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
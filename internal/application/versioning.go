@@ -0,0 +1,59 @@
+package application
+
+import (
+	"app/pkg/logger"
+	"app/pkg/tenant"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// deprecated is a method that returns a middleware announcing the deprecation of the
+// routes it wraps with the Deprecation, Sunset and Link headers
+func (a *ServerChi) deprecated(successor string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.v1DeprecatedAt.IsZero() {
+				w.Header().Set("Deprecation", "@"+strconv.FormatInt(a.v1DeprecatedAt.Unix(), 10))
+			}
+			if !a.v1Sunset.IsZero() {
+				w.Header().Set("Sunset", a.v1Sunset.UTC().Format(http.TimeFormat))
+			}
+			w.Header().Set("Link", "<"+successor+">; rel=\"successor-version\"")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// apiVersion is a method that returns a middleware counting the usage of the routes of
+// an API version and logging the first use of each route by each tenant, so we know when a
+// version can be retired and who still calls it
+func (a *ServerChi) apiVersion(version string, usage *prometheus.CounterVec) func(http.Handler) http.Handler {
+	// seen are the routes used by the tenants, by tenant, method and route
+	var seen sync.Map
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+			usage.WithLabelValues(version, r.Method, route).Inc()
+
+			t := tenant.FromContext(r.Context())
+			if _, ok := seen.LoadOrStore(t+" "+r.Method+" "+route, true); !ok {
+				logger.FromContext(r.Context()).Info("application: api version used",
+					slog.String("version", version),
+					slog.String("tenant", t),
+					slog.String("method", r.Method),
+					slog.String("route", route),
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package application
+
+import (
+	"app/pkg/logger"
+	"app/pkg/tenant"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestServerChi_Versioning is a function that checks that the v1 and unversioned routes announce
+// their deprecation and the v2 routes do not, and that the first use of each route by each tenant
+// is logged once
+func TestServerChi_Versioning(t *testing.T) {
+	deprecatedAt := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)
+	app := NewServerChi(&ConfigServerChi{
+		LoaderFilePath:  "../../docs/db/vehicles_100.json",
+		CatalogFilePath: "../../docs/db/brands.json",
+		DocumentDir:     t.TempDir(),
+		V1DeprecatedAt:  deprecatedAt,
+		V1Sunset:        sunset,
+	})
+	var logs bytes.Buffer
+	app.logger = logger.New(&logs, "info")
+	routes, err := app.Routes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.api.Store(routes[1].(*chi.Mux))
+	rt := routes[0].(*chi.Mux)
+
+	requests := []struct {
+		tenant, path   string
+		wantDeprecated bool
+	}{
+		{tenant: "acme", path: "/v1/vehicles", wantDeprecated: true},
+		{tenant: "acme", path: "/v1/vehicles", wantDeprecated: true},
+		{tenant: "beta", path: "/v1/vehicles", wantDeprecated: true},
+		{tenant: "acme", path: "/vehicles", wantDeprecated: true},
+		{tenant: "acme", path: "/v2/vehicles"},
+		{tenant: "acme", path: "/v2/vehicles"},
+	}
+	for _, req := range requests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, req.path, nil)
+		r.Header.Set(tenant.Header, req.tenant)
+		rt.ServeHTTP(w, r)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: got status %d, want 200", req.path, w.Code)
+		}
+		h := w.Header()
+		if !req.wantDeprecated {
+			for _, name := range []string{"Deprecation", "Sunset", "Link"} {
+				if v := h.Get(name); v != "" {
+					t.Errorf("%s: got %s %q, want none", req.path, name, v)
+				}
+			}
+			continue
+		}
+		if got, want := h.Get("Deprecation"), "@1772323200"; got != want {
+			t.Errorf("%s: got Deprecation %q, want %q", req.path, got, want)
+		}
+		if got, want := h.Get("Sunset"), "Mon, 01 Mar 2027 00:00:00 GMT"; got != want {
+			t.Errorf("%s: got Sunset %q, want %q", req.path, got, want)
+		}
+		if got, want := h.Get("Link"), `</v2/vehicles>; rel="successor-version"`; got != want {
+			t.Errorf("%s: got Link %q, want %q", req.path, got, want)
+		}
+	}
+
+	// the first use of each version, tenant and route
+	used := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry struct {
+			Msg     string `json:"msg"`
+			Version string `json:"version"`
+			Tenant  string `json:"tenant"`
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry.Msg == "application: api version used" {
+			used[entry.Version+" "+entry.Tenant]++
+		}
+	}
+	want := map[string]int{"v1 acme": 1, "v1 beta": 1, "unversioned acme": 1, "v2 acme": 1}
+	if len(used) != len(want) {
+		t.Errorf("got first uses %v, want %v", used, want)
+	}
+	for key, n := range want {
+		if used[key] != n {
+			t.Errorf("got %d first uses of %s, want %d", used[key], key, n)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// types of custom attribute
+const (
+	// AttributeString is an attribute of text values
+	AttributeString = "string"
+	// AttributeNumber is an attribute of numeric values
+	AttributeNumber = "number"
+	// AttributeBoolean is an attribute of true or false values
+	AttributeBoolean = "boolean"
+)
+
+// AttributeTypes are the types of custom attribute
+var AttributeTypes = []string{AttributeString, AttributeNumber, AttributeBoolean}
+
+// AttributeName is the format of the names of the custom attributes, lower case letters, digits
+// and underscores starting with a letter
+var AttributeName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// AttributeDefinition is a struct that represents a custom attribute of the vehicles of a tenant,
+// the fields the fleet does not model
+type AttributeDefinition struct {
+	// Tenant is the tenant the attribute is defined for
+	Tenant string
+	// Name is the name of the attribute, unique in the tenant, in the format of AttributeName
+	Name string
+	// Type is the type of the values, one of AttributeTypes
+	Type string
+	// Required is whether every vehicle written must have a value
+	Required bool
+	// Allowed are the values accepted, any value of the type when empty
+	Allowed []any
+}
+
+// Validate is a method that validates an attribute definition to be written, its allowed values
+// converted to the type
+func (d *AttributeDefinition) Validate() error {
+	if !AttributeName.MatchString(d.Name) {
+		return errors.New("name must be lower case letters, digits and underscores starting with a letter, up to 64")
+	}
+	switch d.Type {
+	case AttributeString, AttributeNumber:
+	case AttributeBoolean:
+		if len(d.Allowed) > 0 {
+			return errors.New("allowed values are not supported by the boolean attributes")
+		}
+	default:
+		return fmt.Errorf("type must be one of %s", strings.Join(AttributeTypes, ", "))
+	}
+
+	allowed := make([]any, 0, len(d.Allowed))
+	for _, value := range d.Allowed {
+		v, err := d.convert(value)
+		if err != nil {
+			return fmt.Errorf("allowed: %w", err)
+		}
+		for _, a := range allowed {
+			if a == v {
+				return fmt.Errorf("allowed: %v is repeated", v)
+			}
+		}
+		allowed = append(allowed, v)
+	}
+	d.Allowed = allowed
+	return nil
+}
+
+// Check is a method that returns a value of the attribute converted to its type, an error when it
+// is not of the type or not one of the allowed values
+func (d *AttributeDefinition) Check(value any) (v any, err error) {
+	if v, err = d.convert(value); err != nil {
+		return
+	}
+	if len(d.Allowed) == 0 {
+		return
+	}
+	for _, a := range d.Allowed {
+		if a == v {
+			return
+		}
+	}
+	allowed := make([]string, 0, len(d.Allowed))
+	for _, a := range d.Allowed {
+		allowed = append(allowed, FormatAttribute(a))
+	}
+	err = fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))
+	return
+}
+
+// convert is a method that returns a value as the type of the attribute, a string, a float64 or a bool
+func (d *AttributeDefinition) convert(value any) (v any, err error) {
+	switch d.Type {
+	case AttributeString:
+		s, ok := value.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			err = errors.New("must be a non empty string")
+			return
+		}
+		v = s
+	case AttributeNumber:
+		var f float64
+		switch n := value.(type) {
+		case float64:
+			f = n
+		case int:
+			f = float64(n)
+		default:
+			err = errors.New("must be a number")
+			return
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			err = errors.New("must be a finite number")
+			return
+		}
+		v = f
+	case AttributeBoolean:
+		b, ok := value.(bool)
+		if !ok {
+			err = errors.New("must be true or false")
+			return
+		}
+		v = b
+	default:
+		err = fmt.Errorf("unknown type %q", d.Type)
+	}
+	return
+}
+
+// ValidateCustom is a function that validates the custom attributes of a vehicle against the
+// definitions of its tenant, returning them converted to their types. A null value is an absent
+// one, and the names without definition are rejected.
+func ValidateCustom(defs []AttributeDefinition, custom map[string]any) (v map[string]any, err error) {
+	names := make([]string, 0, len(custom))
+	for name := range custom {
+		names = append(names, name)
+	}
+	// the errors are reported in the order of the names
+	sort.Strings(names)
+	known := make(map[string]struct{}, len(defs))
+	for _, d := range defs {
+		known[d.Name] = struct{}{}
+	}
+	for _, name := range names {
+		if _, ok := known[name]; !ok && custom[name] != nil {
+			err = fmt.Errorf("custom: %s is not an attribute of the tenant", name)
+			return
+		}
+	}
+
+	for _, d := range defs {
+		value := custom[d.Name]
+		if value == nil {
+			if d.Required {
+				err = fmt.Errorf("custom: %s is required", d.Name)
+				return
+			}
+			continue
+		}
+		c, e := d.Check(value)
+		if e != nil {
+			err = fmt.Errorf("custom: %s %w", d.Name, e)
+			return
+		}
+		if v == nil {
+			v = make(map[string]any)
+		}
+		v[d.Name] = c
+	}
+	return
+}
+
+// FormatAttribute is a function that returns the text of a value of a custom attribute, as
+// written in the query parameters
+func FormatAttribute(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// MatchAttribute is a function that reports whether a value of a custom attribute is the one
+// written as s, the strings without case and the numbers by value
+func MatchAttribute(value any, s string) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.EqualFold(v, s)
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		return err == nil && f == v
+	case bool:
+		b, err := strconv.ParseBool(s)
+		return err == nil && b == v
+	default:
+		return false
+	}
+}
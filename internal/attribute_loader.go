@@ -0,0 +1,7 @@
+package internal
+
+// AttributeLoader is an interface that represents the loader for the custom attributes of the tenants
+type AttributeLoader interface {
+	// Load is a method that loads the attribute definitions
+	Load() (d []AttributeDefinition, err error)
+}
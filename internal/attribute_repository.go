@@ -0,0 +1,20 @@
+package internal
+
+import "context"
+
+// AttributeRepository is an interface that represents a repository of the custom attributes of the tenants
+type AttributeRepository interface {
+	// FindAll is a method that returns the attribute definitions of a tenant by name
+	FindAll(ctx context.Context, tenant string) (d []AttributeDefinition, err error)
+	// FindByName is a method that returns an attribute definition of a tenant, ErrAttributeNotFound
+	// when there is none
+	FindByName(ctx context.Context, tenant, name string) (d AttributeDefinition, err error)
+	// Save is a method that adds an attribute definition, ErrAttributeAlreadyExists when its name is
+	// taken in its tenant
+	Save(ctx context.Context, d *AttributeDefinition) (v AttributeDefinition, err error)
+	// Update is a method that replaces an attribute definition, ErrAttributeNotFound when there is none
+	Update(ctx context.Context, d *AttributeDefinition) (v AttributeDefinition, err error)
+	// Delete is a method that removes an attribute definition of a tenant, ErrAttributeNotFound when
+	// there is none
+	Delete(ctx context.Context, tenant, name string) (err error)
+}
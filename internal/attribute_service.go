@@ -0,0 +1,34 @@
+package internal
+
+import "context"
+
+// AttributeService is an interface that represents the service of the custom attributes of the
+// vehicles, each operation on the definitions of the tenant of ctx
+type AttributeService interface {
+	// FindAll is a method that returns the attribute definitions by name
+	FindAll(ctx context.Context) (d []AttributeDefinition, err error)
+	// FindByName is a method that returns an attribute definition
+	FindByName(ctx context.Context, name string) (d AttributeDefinition, err error)
+	// Save is a method that adds an attribute definition
+	Save(ctx context.Context, d *AttributeDefinition) (v AttributeDefinition, err error)
+	// Update is a method that replaces an attribute definition, the vehicles checked against it at
+	// their next write
+	Update(ctx context.Context, d *AttributeDefinition) (v AttributeDefinition, err error)
+	// Delete is a method that removes an attribute definition, the values of the vehicles kept and
+	// rejected at their next write unless set to null
+	Delete(ctx context.Context, name string) (err error)
+}
+
+type customUncheckedKey struct{}
+
+// WithoutCustomChecks is a function that returns a copy of ctx whose vehicle writes skip the
+// checks of the custom attributes, for the contracts without them such as v1
+func WithoutCustomChecks(ctx context.Context) context.Context {
+	return context.WithValue(ctx, customUncheckedKey{}, true)
+}
+
+// CustomChecked is a function that reports whether the vehicle writes of ctx check their custom attributes
+func CustomChecked(ctx context.Context) bool {
+	unchecked, _ := ctx.Value(customUncheckedKey{}).(bool)
+	return !unchecked
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestValidateCustom is a function that checks the custom attributes of a vehicle against the
+// definitions of its tenant, the values converted to their types
+func TestValidateCustom(t *testing.T) {
+	defs := []AttributeDefinition{
+		{Name: "cost_center", Type: AttributeString, Required: true},
+		{Name: "region", Type: AttributeString, Allowed: []any{"north", "south"}},
+		{Name: "insured_value", Type: AttributeNumber},
+		{Name: "armored", Type: AttributeBoolean},
+	}
+
+	cases := map[string]struct {
+		custom  map[string]any
+		want    map[string]any
+		wantErr bool
+	}{
+		"required only": {
+			custom: map[string]any{"cost_center": "cc-1"},
+			want:   map[string]any{"cost_center": "cc-1"},
+		},
+		"every type": {
+			custom: map[string]any{"cost_center": "cc-1", "region": "north", "insured_value": 100000, "armored": true},
+			want:   map[string]any{"cost_center": "cc-1", "region": "north", "insured_value": float64(100000), "armored": true},
+		},
+		"null as absent": {
+			custom: map[string]any{"cost_center": "cc-1", "region": nil, "unknown": nil},
+			want:   map[string]any{"cost_center": "cc-1"},
+		},
+		"required missing":    {custom: map[string]any{"region": "north"}, wantErr: true},
+		"required null":       {custom: map[string]any{"cost_center": nil}, wantErr: true},
+		"unknown name":        {custom: map[string]any{"cost_center": "cc-1", "color": "red"}, wantErr: true},
+		"not allowed":         {custom: map[string]any{"cost_center": "cc-1", "region": "east"}, wantErr: true},
+		"string for a number": {custom: map[string]any{"cost_center": "cc-1", "insured_value": "100"}, wantErr: true},
+		"number for a bool":   {custom: map[string]any{"cost_center": "cc-1", "armored": 1.0}, wantErr: true},
+		"blank string":        {custom: map[string]any{"cost_center": " "}, wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ValidateCustom(defs, c.custom)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("got %v and no error, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package internal
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore is an interface that represents a store of files by key, keys being slash separated
+// paths of letters, digits, dots, dashes and underscores
+type BlobStore interface {
+	// Put is a method that writes the content of a file, replacing the one of key
+	Put(ctx context.Context, key string, r io.Reader) (err error)
+	// Get is a method that opens a file for reading, ErrBlobNotFound when there is none
+	Get(ctx context.Context, key string) (rc io.ReadCloser, err error)
+	// Delete is a method that removes a file, none being no error
+	Delete(ctx context.Context, key string) (err error)
+}
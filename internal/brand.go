@@ -0,0 +1,19 @@
+package internal
+
+// Brand is a struct that represents a brand of the catalog
+type Brand struct {
+	// Name is the canonical name of the brand
+	Name string
+	// Aliases are the other names the brand is written as
+	Aliases []string
+	// Models are the canonical names of the models of the brand
+	Models []string
+}
+
+// UnknownBrand is a struct that represents a brand of the vehicles missing from the catalog
+type UnknownBrand struct {
+	// Name is the brand as written in the vehicles, the most frequent of its spellings
+	Name string
+	// Vehicles is the number of vehicles of the brand
+	Vehicles int
+}
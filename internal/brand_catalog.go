@@ -0,0 +1,23 @@
+package internal
+
+// BrandCatalog is an interface that represents the catalog of the canonical brands and models,
+// its names are matched ignoring case, accents, spaces and punctuation
+type BrandCatalog interface {
+	// Brand is a method that returns the canonical name of a brand or of one of its aliases,
+	// and false with the name as it is when the brand is unknown
+	Brand(name string) (canonical string, ok bool)
+	// Canonicalize is a method that replaces the brand and the model of a vehicle by their
+	// canonical names, reporting whether the brand is known
+	Canonicalize(vh *VehicleAttributes) (ok bool)
+	// FindAll is a method that returns the brands sorted by name
+	FindAll() (b []Brand)
+	// SaveBrand is a method that adds a brand, returning the existing one and false when it is known
+	SaveBrand(name string) (b Brand, created bool, err error)
+	// AddAlias is a method that adds an alias to a brand
+	AddAlias(brand, alias string) (b Brand, err error)
+	// RemoveAlias is a method that removes an alias of a brand
+	RemoveAlias(brand, alias string) (b Brand, err error)
+	// Unknown is a method that returns the brands missing from the catalog found when the vehicles
+	// were loaded, the most frequent first
+	Unknown() (u []UnknownBrand)
+}
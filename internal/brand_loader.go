@@ -0,0 +1,9 @@
+package internal
+
+// BrandLoader is an interface that represents the loader for the brands of the catalog
+type BrandLoader interface {
+	// Load is a method that loads the brands
+	Load() (b []Brand, err error)
+	// Save is a method that writes the brands, replacing the ones written before
+	Save(b []Brand) (err error)
+}
@@ -0,0 +1,318 @@
+package catalog
+
+import (
+	"app/internal"
+	"app/internal/search"
+	"app/pkg/apperrors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NewBrandMap is a function that returns a new instance of BrandMap with the brands,
+// a name or an alias naming two brands is an error. Every change is written with ld,
+// kept in memory only when ld is nil.
+func NewBrandMap(brands []internal.Brand, ld internal.BrandLoader) (c *BrandMap, err error) {
+	c = &BrandMap{
+		brands: make(map[string]*entry),
+		names:  make(map[string]string),
+		ld:     ld,
+	}
+	for _, b := range brands {
+		key := Key(b.Name)
+		if key == "" {
+			err = fmt.Errorf("catalog: %w: brand without name", apperrors.ErrInvalidVehicleData)
+			return
+		}
+		if other, ok := c.names[key]; ok {
+			err = fmt.Errorf("catalog: %w: %q is %s", apperrors.ErrBrandAliasConflict, b.Name, c.brands[other].brand.Name)
+			return
+		}
+		e := &entry{brand: internal.Brand{Name: b.Name}, models: make(map[string]string)}
+		c.brands[key] = e
+		c.names[key] = key
+		for _, alias := range b.Aliases {
+			if _, err = c.addAlias(key, alias); err != nil {
+				err = fmt.Errorf("catalog: %s: %w", b.Name, err)
+				return
+			}
+		}
+		for _, model := range b.Models {
+			if mk := Key(model); mk != "" {
+				if _, ok := e.models[mk]; !ok {
+					e.models[mk] = model
+					e.brand.Models = append(e.brand.Models, model)
+				}
+			}
+		}
+	}
+	return
+}
+
+// BrandMap is a struct that implements the BrandCatalog interface in memory, safe for concurrent use
+type BrandMap struct {
+	mu sync.RWMutex
+	// brands are the brands by the key of their canonical name
+	brands map[string]*entry
+	// names are the keys of the canonical names by the keys of the names and aliases
+	names map[string]string
+	// unknown are the brands missing from the catalog found by the last load of the vehicles
+	unknown []internal.UnknownBrand
+	// ld is the loader the brands are written with after every change, nil for none
+	ld internal.BrandLoader
+}
+
+// entry is a struct that represents a brand of the catalog and its models by key
+type entry struct {
+	brand  internal.Brand
+	models map[string]string
+}
+
+// Key is a function that returns the key a name is matched by, in lower case and
+// without accents, spaces or punctuation: "Mercedes Benz" and "mercedes-benz" are the same
+func Key(name string) string {
+	return strings.Join(search.Tokenize(name), "")
+}
+
+// Brand is a method that returns the canonical name of a brand or of one of its aliases,
+// and false with the name as it is, trimmed, when the brand is unknown
+func (c *BrandMap) Brand(name string) (canonical string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.find(name)
+	if !ok {
+		return strings.TrimSpace(name), false
+	}
+	return e.brand.Name, true
+}
+
+// Canonicalize is a method that replaces the brand and the model of a vehicle by their
+// canonical names, reporting whether the brand is known. The model is kept when it is
+// not in the catalog.
+func (c *BrandMap) Canonicalize(vh *internal.VehicleAttributes) (ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	vh.Brand, vh.Model = strings.TrimSpace(vh.Brand), strings.TrimSpace(vh.Model)
+	e, ok := c.find(vh.Brand)
+	if !ok {
+		return
+	}
+	vh.Brand = e.brand.Name
+	if model, found := e.models[Key(vh.Model)]; found {
+		vh.Model = model
+	}
+	return
+}
+
+// FindAll is a method that returns the brands sorted by name
+func (c *BrandMap) FindAll() (b []internal.Brand) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	b = make([]internal.Brand, 0, len(c.brands))
+	for _, e := range c.brands {
+		b = append(b, e.copy())
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i].Name < b[j].Name })
+	return
+}
+
+// SaveBrand is a method that adds a brand, returning the existing one and false when
+// the name is already a brand or an alias
+func (c *BrandMap) SaveBrand(name string) (b internal.Brand, created bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name = strings.TrimSpace(name)
+	key := Key(name)
+	if key == "" {
+		err = fmt.Errorf("%w: the brand has no name", apperrors.ErrInvalidVehicleData)
+		return
+	}
+	if e, ok := c.find(name); ok {
+		b = e.copy()
+		return
+	}
+
+	e := &entry{brand: internal.Brand{Name: name}, models: make(map[string]string)}
+	c.brands[key] = e
+	c.names[key] = key
+	if err = c.write(); err != nil {
+		delete(c.brands, key)
+		delete(c.names, key)
+		return
+	}
+	c.dropKnown()
+	b, created = e.copy(), true
+	return
+}
+
+// AddAlias is a method that adds an alias to a brand, doing nothing when it already has it
+func (c *BrandMap) AddAlias(brand, alias string) (b internal.Brand, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.find(brand)
+	if !ok {
+		err = fmt.Errorf("%w: %q", apperrors.ErrBrandNotFound, brand)
+		return
+	}
+	n := len(e.brand.Aliases)
+	if e, err = c.addAlias(Key(e.brand.Name), alias); err != nil {
+		return
+	}
+	// an alias the brand already had is not written again
+	if len(e.brand.Aliases) > n {
+		if err = c.write(); err != nil {
+			delete(c.names, Key(alias))
+			e.brand.Aliases = e.brand.Aliases[:n]
+			return
+		}
+	}
+	c.dropKnown()
+	b = e.copy()
+	return
+}
+
+// RemoveAlias is a method that removes an alias of a brand, the canonical name is not an alias
+func (c *BrandMap) RemoveAlias(brand, alias string) (b internal.Brand, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.find(brand)
+	if !ok {
+		err = fmt.Errorf("%w: %q", apperrors.ErrBrandNotFound, brand)
+		return
+	}
+	key := Key(alias)
+	i := -1
+	for j, a := range e.brand.Aliases {
+		if Key(a) == key {
+			i = j
+		}
+	}
+	if i < 0 {
+		err = fmt.Errorf("%w: %q is not an alias of %s", apperrors.ErrBrandAliasNotFound, alias, e.brand.Name)
+		return
+	}
+	removed := e.brand.Aliases[i]
+	e.brand.Aliases = slices.Delete(e.brand.Aliases, i, i+1)
+	delete(c.names, key)
+	if err = c.write(); err != nil {
+		e.brand.Aliases = slices.Insert(e.brand.Aliases, i, removed)
+		c.names[key] = Key(e.brand.Name)
+		return
+	}
+	b = e.copy()
+	return
+}
+
+// Unknown is a method that returns the brands missing from the catalog found by the last
+// load of the vehicles, the most frequent first
+func (c *BrandMap) Unknown() (u []internal.UnknownBrand) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	u = make([]internal.UnknownBrand, len(c.unknown))
+	copy(u, c.unknown)
+	return
+}
+
+// setUnknown is a method that replaces the report of the brands missing from the catalog, the
+// counts of the spellings of each brand by key reported together under the most frequent one
+func (c *BrandMap) setUnknown(counts map[string]map[string]int) {
+	u := make([]internal.UnknownBrand, 0, len(counts))
+	for _, spellings := range counts {
+		var ub internal.UnknownBrand
+		most := 0
+		for name, n := range spellings {
+			ub.Vehicles += n
+			if n > most || n == most && name < ub.Name {
+				ub.Name, most = name, n
+			}
+		}
+		u = append(u, ub)
+	}
+	sort.Slice(u, func(i, j int) bool {
+		if u[i].Vehicles != u[j].Vehicles {
+			return u[i].Vehicles > u[j].Vehicles
+		}
+		return u[i].Name < u[j].Name
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unknown = u
+}
+
+// dropKnown is a method that removes from the report the brands the catalog now names, after a
+// brand or an alias is added, the lock held
+func (c *BrandMap) dropKnown() {
+	u := make([]internal.UnknownBrand, 0, len(c.unknown))
+	for _, value := range c.unknown {
+		if _, ok := c.find(value.Name); !ok {
+			u = append(u, value)
+		}
+	}
+	c.unknown = u
+}
+
+// write is a method that writes the brands sorted by name with the loader, the lock held
+func (c *BrandMap) write() (err error) {
+	if c.ld == nil {
+		return
+	}
+
+	b := make([]internal.Brand, 0, len(c.brands))
+	for _, e := range c.brands {
+		b = append(b, e.copy())
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i].Name < b[j].Name })
+	if err = c.ld.Save(b); err != nil {
+		err = fmt.Errorf("catalog: write: %w", err)
+	}
+	return
+}
+
+// find is a method that returns the brand of a name or an alias, the lock held
+func (c *BrandMap) find(name string) (e *entry, ok bool) {
+	key, ok := c.names[Key(name)]
+	if !ok {
+		return
+	}
+	e = c.brands[key]
+	return
+}
+
+// addAlias is a method that adds an alias to the brand of key, the lock held
+func (c *BrandMap) addAlias(key, alias string) (e *entry, err error) {
+	e = c.brands[key]
+	alias = strings.TrimSpace(alias)
+	aliasKey := Key(alias)
+	if aliasKey == "" {
+		err = fmt.Errorf("%w: the alias has no letters or digits", apperrors.ErrInvalidVehicleData)
+		return
+	}
+	if other, ok := c.names[aliasKey]; ok {
+		if other != key {
+			err = fmt.Errorf("%w: %q is %s", apperrors.ErrBrandAliasConflict, alias, c.brands[other].brand.Name)
+		}
+		return
+	}
+	c.names[aliasKey] = key
+	e.brand.Aliases = append(e.brand.Aliases, alias)
+	return
+}
+
+// copy is a method that returns a copy of the brand, not sharing its slices
+func (e *entry) copy() internal.Brand {
+	return internal.Brand{
+		Name:    e.brand.Name,
+		Aliases: append([]string{}, e.brand.Aliases...),
+		Models:  append([]string{}, e.brand.Models...),
+	}
+}
@@ -0,0 +1,250 @@
+package catalog
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestBrandMap_Unknown is a function that checks that the brands reported missing from the
+// catalog leave the report once the catalog names them, by a brand or an alias added
+func TestBrandMap_Unknown(t *testing.T) {
+	cases := map[string]struct {
+		change func(c *BrandMap) error
+		want   []internal.UnknownBrand
+	}{
+		"alias added": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.AddAlias("Volkswagen", "vw")
+				return
+			},
+			want: []internal.UnknownBrand{{Name: "Great Wall", Vehicles: 2}, {Name: "Lada", Vehicles: 1}},
+		},
+		"brand added by another spelling": {
+			change: func(c *BrandMap) (err error) {
+				_, _, err = c.SaveBrand("great-wall")
+				return
+			},
+			want: []internal.UnknownBrand{{Name: "VW", Vehicles: 3}, {Name: "Lada", Vehicles: 1}},
+		},
+		"unrelated brand added": {
+			change: func(c *BrandMap) (err error) {
+				_, _, err = c.SaveBrand("Fiat")
+				return
+			},
+			want: []internal.UnknownBrand{{Name: "VW", Vehicles: 3}, {Name: "Great Wall", Vehicles: 2}, {Name: "Lada", Vehicles: 1}},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ct, err := NewBrandMap([]internal.Brand{{Name: "Volkswagen"}}, nil)
+			if err != nil {
+				t.Fatalf("new catalog: %v", err)
+			}
+			ct.setUnknown(map[string]map[string]int{"vw": {"VW": 3}, "greatwall": {"Great Wall": 2}, "lada": {"Lada": 1}})
+
+			if err := c.change(ct); err != nil {
+				t.Fatalf("change: %v", err)
+			}
+			if got := ct.Unknown(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// vehicleLoaderStub is a struct that implements the VehicleLoader interface with fixed vehicles
+type vehicleLoaderStub map[int]internal.Vehicle
+
+// Load is a method that returns the vehicles of the stub
+func (l vehicleLoaderStub) Load() (v map[int]internal.Vehicle, err error) {
+	return l, nil
+}
+
+// TestVehicleLoader_Unknown is a function that checks that the spellings of an unknown brand are
+// reported together, under the most frequent one
+func TestVehicleLoader_Unknown(t *testing.T) {
+	ct, err := NewBrandMap([]internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	brands := []string{"Tesla", "tesla", "Tesla", "TESLA", "Lada", "lada", "vw"}
+	v := make(vehicleLoaderStub)
+	for i, brand := range brands {
+		v[i+1] = internal.Vehicle{Id: i + 1, VehicleAttributes: internal.VehicleAttributes{Brand: brand}}
+	}
+
+	if _, err := NewVehicleLoader(v, ct).Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []internal.UnknownBrand{{Name: "Tesla", Vehicles: 4}, {Name: "Lada", Vehicles: 2}}
+	if got := ct.Unknown(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// brandLoaderStub is a struct that implements the BrandLoader interface, keeping the brands saved
+type brandLoaderStub struct {
+	saved []internal.Brand
+	err   error
+}
+
+// Load is a method that returns the brands saved
+func (l *brandLoaderStub) Load() (b []internal.Brand, err error) {
+	return l.saved, nil
+}
+
+// Save is a method that keeps the brands, or fails with the error of the stub
+func (l *brandLoaderStub) Save(b []internal.Brand) (err error) {
+	if l.err != nil {
+		return l.err
+	}
+	l.saved = b
+	return
+}
+
+// TestBrandMap_Write is a function that checks that every change of the catalog is written with
+// its loader, and rolled back when the write fails
+func TestBrandMap_Write(t *testing.T) {
+	errWrite := errors.New("disk full")
+	cases := map[string]struct {
+		change      func(c *BrandMap) error
+		writeErr    error
+		wantWritten bool
+		want        []internal.Brand
+	}{
+		"brand added": {
+			change: func(c *BrandMap) (err error) {
+				_, _, err = c.SaveBrand("Fiat")
+				return
+			},
+			wantWritten: true,
+			want:        []internal.Brand{{Name: "Fiat", Aliases: []string{}, Models: []string{}}, {Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+		"known brand not written": {
+			change: func(c *BrandMap) (err error) {
+				_, _, err = c.SaveBrand("vw")
+				return
+			},
+			want: []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+		"alias added": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.AddAlias("Volkswagen", "Fusca")
+				return
+			},
+			wantWritten: true,
+			want:        []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW", "Fusca"}, Models: []string{}}},
+		},
+		"alias kept not written": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.AddAlias("Volkswagen", "v.w.")
+				return
+			},
+			want: []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+		"alias removed": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.RemoveAlias("Volkswagen", "vw")
+				return
+			},
+			wantWritten: true,
+			want:        []internal.Brand{{Name: "Volkswagen", Aliases: []string{}, Models: []string{}}},
+		},
+		"brand rolled back": {
+			change: func(c *BrandMap) (err error) {
+				_, _, err = c.SaveBrand("Fiat")
+				return
+			},
+			writeErr: errWrite,
+			want:     []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+		"alias added rolled back": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.AddAlias("Volkswagen", "Fusca")
+				return
+			},
+			writeErr: errWrite,
+			want:     []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+		"alias removed rolled back": {
+			change: func(c *BrandMap) (err error) {
+				_, err = c.RemoveAlias("Volkswagen", "vw")
+				return
+			},
+			writeErr: errWrite,
+			want:     []internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}, Models: []string{}}},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			ld := &brandLoaderStub{err: c.writeErr}
+			ct, err := NewBrandMap([]internal.Brand{{Name: "Volkswagen", Aliases: []string{"VW"}}}, ld)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.change(ct)
+
+			if !errors.Is(err, c.writeErr) {
+				t.Fatalf("got error %v, want %v", err, c.writeErr)
+			}
+			if got := ct.FindAll(); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got brands %v, want %v", got, c.want)
+			}
+			if written := ld.saved != nil; written != c.wantWritten {
+				t.Fatalf("got written %t, want %t", written, c.wantWritten)
+			}
+			if c.wantWritten && !reflect.DeepEqual(ld.saved, c.want) {
+				t.Errorf("got brands written %v, want %v", ld.saved, c.want)
+			}
+			// the names of the catalog follow its brands
+			for _, alias := range []string{"Fusca", "VW"} {
+				_, known := ct.Brand(alias)
+				want := false
+				for _, b := range c.want {
+					for _, a := range b.Aliases {
+						want = want || a == alias
+					}
+				}
+				if known != want {
+					t.Errorf("got %s known %t, want %t", alias, known, want)
+				}
+			}
+		})
+	}
+}
+
+// TestBrandMap_Write_Restart is a function that checks that the brands and aliases added are
+// loaded back from the catalog file
+func TestBrandMap_Write_Restart(t *testing.T) {
+	ld := loader.NewBrandJSONFile(filepath.Join(t.TempDir(), "brands.json"))
+	ct, err := NewBrandMap([]internal.Brand{{Name: "Volkswagen", Models: []string{"Gol"}}}, ld)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ct.SaveBrand("Tesla"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ct.AddAlias("volkswagen", "VW"); err != nil {
+		t.Fatal(err)
+	}
+
+	brands, err := ld.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := NewBrandMap(brands, ld)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := restarted.FindAll(), ct.FindAll(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got brands %v after the restart, want %v", got, want)
+	}
+}
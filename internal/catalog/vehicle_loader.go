@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"app/internal"
+)
+
+// NewVehicleLoader is a function that returns a loader decorator replacing the brands and models
+// of the vehicles by their canonical names and reporting the unknown brands to the catalog
+func NewVehicleLoader(ld internal.VehicleLoader, c *BrandMap) *VehicleLoader {
+	return &VehicleLoader{ld: ld, c: c}
+}
+
+// VehicleLoader is a struct that decorates a VehicleLoader with the catalog
+type VehicleLoader struct {
+	// ld is the decorated loader
+	ld internal.VehicleLoader
+	// c is the catalog of the brands
+	c *BrandMap
+}
+
+// Load is a method that loads the vehicles with their canonical brands and models
+func (l *VehicleLoader) Load() (v map[int]internal.Vehicle, err error) {
+	v, err = l.ld.Load()
+	if err != nil {
+		return
+	}
+
+	// the spellings of the unknown brands by key, "Tesla" and "tesla" being the same brand
+	unknown := make(map[string]map[string]int)
+	for id, vh := range v {
+		if !l.c.Canonicalize(&vh.VehicleAttributes) {
+			key := Key(vh.Brand)
+			if unknown[key] == nil {
+				unknown[key] = make(map[string]int)
+			}
+			unknown[key][vh.Brand]++
+		}
+		v[id] = vh
+	}
+	l.c.setUnknown(unknown)
+	return
+}
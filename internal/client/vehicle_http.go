@@ -0,0 +1,408 @@
+package client
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"app/pkg/tenant"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// NewVehicleHTTP is a function that returns a new instance of VehicleHTTP calling the
+// server at baseURL (e.g. http://localhost:8080) with hc, http.DefaultClient when nil
+func NewVehicleHTTP(baseURL string, hc *http.Client) *VehicleHTTP {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &VehicleHTTP{baseURL: strings.TrimSuffix(baseURL, "/"), hc: hc}
+}
+
+// VehicleHTTP is a struct that implements the VehicleService interface over the /v2 routes
+// of a running server, with the errors of the service mapped back to apperrors
+type VehicleHTTP struct {
+	// baseURL is the URL of the server
+	baseURL string
+	// hc is the http client of the calls
+	hc *http.Client
+}
+
+// FindAll is a method that returns a map of all vehicles
+func (c *VehicleHTTP) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, nil)
+	return
+}
+
+// Save is a method that creates a vehicle
+func (c *VehicleHTTP) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+	var res v2.Data[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodPost, "/v2/vehicles", nil, toRequest(*vh), &res)
+	if err != nil {
+		return
+	}
+
+	v = toDomain(res.Data)
+	return
+}
+
+// FindByColorAndYears is a method that returns the vehicles of a color fabricated in a year
+func (c *VehicleHTTP) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"color": {color}, "year": {year}})
+	if err == nil && len(v) == 0 {
+		err = apperrors.ErrVehicleWithCriteria
+	}
+	return
+}
+
+// FindById is a method that returns a vehicle, the zero Vehicle when it does not exist
+func (c *VehicleHTTP) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	var res v2.Data[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodGet, "/v2/vehicles/"+url.PathEscape(id), nil, nil, &res)
+	if errors.Is(err, apperrors.ErrVehicleNotFound) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	v = toDomain(res.Data)
+	return
+}
+
+// FindByRegistration is a method that returns the vehicle of a registration, ErrVehicleNotFound
+// when there is none
+func (c *VehicleHTTP) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	var res v2.Data[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodGet, "/v2/vehicles/registration/"+url.PathEscape(registration), nil, nil, &res)
+	if err != nil {
+		return
+	}
+
+	v = toDomain(res.Data)
+	return
+}
+
+// FindByMarcaAndYearInterval is a method that returns the vehicles of a brand fabricated between two years
+func (c *VehicleHTTP) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"brand": {brand}, "year_from": {start_year}, "year_to": {end_year}})
+	if err == nil && len(v) == 0 {
+		err = apperrors.ErrVehicleWithCriteria
+	}
+	return
+}
+
+// FindTipoCombustivel is a method that returns the vehicles of a fuel type
+func (c *VehicleHTTP) FindTipoCombustivel(ctx context.Context, typeFuel string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"fuel_type": {typeFuel}})
+	if err == nil && len(v) == 0 {
+		err = apperrors.ErrVehicleNotFound
+	}
+	return
+}
+
+// FindByTransmissionType is a method that returns the vehicles of a transmission
+func (c *VehicleHTTP) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"transmission": {typeTransmission}})
+	if err == nil && len(v) == 0 {
+		err = apperrors.ErrVehicleNotFound
+	}
+	return
+}
+
+// FindMediaPessoaPorMarca is a method that returns the average capacity of people of a brand
+func (c *VehicleHTTP) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	stats, err := c.brandStats(ctx, brand)
+	if err != nil {
+		return
+	}
+
+	m = stats.AverageCapacity
+	return
+}
+
+// FindByDimenssion is a method that returns the vehicles within a length and a width range, as min-max
+func (c *VehicleHTTP) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"length": {lengthParam}, "width": {widthParam}})
+	return
+}
+
+// FindByPeso is a method that returns the vehicles within a weight range
+func (c *VehicleHTTP) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	v, err = c.list(ctx, url.Values{"weight_min": {min}, "weight_max": {max}})
+	return
+}
+
+// FindVelocidadeMediaMarca is a method that returns the average maximum speed of a brand
+func (c *VehicleHTTP) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	stats, err := c.brandStats(ctx, brand)
+	if err != nil {
+		return
+	}
+
+	m = stats.AverageSpeed
+	return
+}
+
+// SaveMultipleVehicles is a method that creates many vehicles
+func (c *VehicleHTTP) SaveMultipleVehicles(ctx context.Context, vh *[]internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+	body := make([]v2.VehicleRequest, 0, len(*vh))
+	for _, attrs := range *vh {
+		body = append(body, toRequest(attrs))
+	}
+
+	var res v2.List[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodPost, "/v2/vehicles/batch", nil, body, &res)
+	if err != nil {
+		return
+	}
+
+	v = toMap(res.Data)
+	return
+}
+
+// Patch is a method that replaces the attributes of a vehicle. The server merges the custom
+// attributes, the ones the vehicle no longer has are sent as null.
+func (c *VehicleHTTP) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
+	current, err := c.FindById(ctx, strconv.Itoa(vh.Id))
+	if err != nil {
+		return
+	}
+	req := toRequest(vh.VehicleAttributes)
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	custom := maps.Clone(req.Custom)
+	for name := range current.Custom {
+		if _, ok := custom[name]; !ok {
+			if custom == nil {
+				custom = make(map[string]any)
+			}
+			custom[name] = nil
+		}
+	}
+	v, err = c.patch(ctx, vh.Id, v2.VehiclePatchRequest{
+		Brand:           &req.Brand,
+		Model:           &req.Model,
+		Registration:    &req.Registration,
+		Color:           &req.Color,
+		FabricationYear: &req.FabricationYear,
+		Capacity:        &req.Capacity,
+		MaxSpeed:        &req.MaxSpeed,
+		FuelType:        &req.FuelType,
+		Transmission:    &req.Transmission,
+		Weight:          &req.Weight,
+		Height:          &req.Height,
+		Length:          &req.Length,
+		Width:           &req.Width,
+		Tags:            &tags,
+		Custom:          custom,
+	})
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (c *VehicleHTTP) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
+	v, err = c.patch(ctx, id, v2.VehiclePatchRequest{MaxSpeed: &maxSpeed})
+	return
+}
+
+// UpdateFuel is a method that updates the fuel type of a vehicle
+func (c *VehicleHTTP) UpdateFuel(ctx context.Context, id int, fuelType string) (v internal.Vehicle, err error) {
+	v, err = c.patch(ctx, id, v2.VehiclePatchRequest{FuelType: &fuelType})
+	return
+}
+
+// DeleteById is a method that deletes a vehicle
+func (c *VehicleHTTP) DeleteById(ctx context.Context, id string) (err error) {
+	err = c.do(ctx, http.MethodDelete, "/v2/vehicles/"+url.PathEscape(id), nil, nil, nil)
+	return
+}
+
+// Search is a method that returns the vehicles matching the words of query, the most relevant first
+func (c *VehicleHTTP) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	params := url.Values{"q": {query}}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	var res v2.List[v2.MatchResponse]
+	err = c.do(ctx, http.MethodGet, "/v2/vehicles/search", params, nil, &res)
+	if err != nil {
+		return
+	}
+
+	v = make([]internal.VehicleMatch, 0, len(res.Data))
+	for _, m := range res.Data {
+		v = append(v, internal.VehicleMatch{Vehicle: toDomain(m.VehicleResponse), Score: m.Score})
+	}
+	return
+}
+
+// list is a method that returns the vehicles of GET /v2/vehicles with the query filters
+func (c *VehicleHTTP) list(ctx context.Context, query url.Values) (v map[int]internal.Vehicle, err error) {
+	var res v2.List[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodGet, "/v2/vehicles", query, nil, &res)
+	if err != nil {
+		return
+	}
+
+	v = toMap(res.Data)
+	return
+}
+
+// brandStats is a method that returns the aggregates of a brand, ErrVehicleBrand when it has no vehicles
+func (c *VehicleHTTP) brandStats(ctx context.Context, brand string) (stats v2.BrandStatsResponse, err error) {
+	var res v2.Data[v2.BrandStatsResponse]
+	err = c.do(ctx, http.MethodGet, "/v2/brands/"+url.PathEscape(brand)+"/stats", nil, nil, &res)
+	if errors.Is(err, apperrors.ErrVehicleNotFound) {
+		err = apperrors.ErrVehicleBrand
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	stats = res.Data
+	return
+}
+
+// patch is a method that updates the attributes present in body
+func (c *VehicleHTTP) patch(ctx context.Context, id int, body v2.VehiclePatchRequest) (v internal.Vehicle, err error) {
+	var res v2.Data[v2.VehicleResponse]
+	err = c.do(ctx, http.MethodPatch, "/v2/vehicles/"+strconv.Itoa(id), nil, body, &res)
+	if err != nil {
+		return
+	}
+
+	v = toDomain(res.Data)
+	return
+}
+
+// do is a method that sends a request with the JSON body, when not nil, and decodes the
+// JSON response into out, when not nil. The error responses are mapped to apperrors.
+func (c *VehicleHTTP) do(ctx context.Context, method, path string, query url.Values, body, out any) (err error) {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		b, e := json.Marshal(body)
+		if e != nil {
+			err = e
+			return
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reqBody)
+	if err != nil {
+		return
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(tenant.Header, tenant.FromContext(ctx))
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		err = responseError(res)
+		return
+	}
+	if out != nil {
+		err = json.NewDecoder(res.Body).Decode(out)
+	}
+	return
+}
+
+// responseError is a function that maps an error response of the server to an error
+func responseError(res *http.Response) error {
+	var body v2.Error
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil || body.Error.Code == "" {
+		return fmt.Errorf("client: %s", res.Status)
+	}
+
+	switch body.Error.Code {
+	case v2.CodeNotFound:
+		return fmt.Errorf("%w: %s", apperrors.ErrVehicleNotFound, body.Error.Message)
+	case v2.CodeConflict:
+		return fmt.Errorf("%w: %s", apperrors.ErrVehicleAlreadyExists, body.Error.Message)
+	case v2.CodeInvalid, v2.CodeBadRequest:
+		return fmt.Errorf("%w: %s", apperrors.ErrInvalidVehicleData, body.Error.Message)
+	case v2.CodeTimeout:
+		return fmt.Errorf("client: server %w", context.DeadlineExceeded)
+	default:
+		return fmt.Errorf("client: %s: %s", res.Status, body.Error.Message)
+	}
+}
+
+// toRequest is a function that maps the attributes of a vehicle to the body to create it
+func toRequest(a internal.VehicleAttributes) v2.VehicleRequest {
+	return v2.VehicleRequest{
+		Brand:           a.Brand,
+		Model:           a.Model,
+		Registration:    a.Registration,
+		Color:           a.Color,
+		FabricationYear: a.FabricationYear,
+		Capacity:        a.Capacity,
+		MaxSpeed:        a.MaxSpeed,
+		FuelType:        a.FuelType,
+		Transmission:    a.Transmission,
+		Weight:          a.Weight,
+		Height:          a.Height,
+		Length:          a.Length,
+		Width:           a.Width,
+		Tags:            a.Tags,
+		Custom:          a.Custom,
+	}
+}
+
+// toDomain is a function that maps a vehicle response to a vehicle
+func toDomain(r v2.VehicleResponse) internal.Vehicle {
+	return internal.Vehicle{
+		Id: r.ID,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           r.Brand,
+			Model:           r.Model,
+			Registration:    r.Registration,
+			Color:           r.Color,
+			FabricationYear: r.FabricationYear,
+			Capacity:        r.Capacity,
+			MaxSpeed:        r.MaxSpeed,
+			FuelType:        r.FuelType,
+			Transmission:    r.Transmission,
+			Weight:          r.Weight,
+			Dimensions: internal.Dimensions{
+				Height: r.Height,
+				Length: r.Length,
+				Width:  r.Width,
+			},
+			Tags:   r.Tags,
+			Custom: r.Custom,
+		},
+	}
+}
+
+// toMap is a function that maps the vehicle responses to vehicles by id
+func toMap(data []v2.VehicleResponse) map[int]internal.Vehicle {
+	v := make(map[int]internal.Vehicle, len(data))
+	for _, r := range data {
+		v[r.ID] = toDomain(r)
+	}
+	return v
+}
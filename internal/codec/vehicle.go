@@ -0,0 +1,336 @@
+package codec
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// formats of the vehicle files
+const (
+	// FormatJSON is a JSON array of vehicles, the format of the data file
+	FormatJSON = "json"
+	// FormatJSONLines is one JSON vehicle per line
+	FormatJSONLines = "jsonl"
+	// FormatCSV is a CSV file with a header line
+	FormatCSV = "csv"
+)
+
+// Formats are the supported formats
+var Formats = []string{FormatJSON, FormatJSONLines, FormatCSV}
+
+// ErrUnknownFormat is the error of a format that is not supported
+var ErrUnknownFormat = errors.New("codec: unknown format")
+
+// Header is the header line of the CSV format, named as the JSON fields of the REST API.
+// The tags are separated by spaces and the custom attributes are a JSON object.
+var Header = []string{
+	"id", "brand", "model", "registration", "color", "year", "passengers", "max_speed",
+	"fuel_type", "transmission", "weight", "height", "length", "width", "tags", "custom",
+}
+
+// Encoder is an interface that represents an encoder of vehicles
+type Encoder interface {
+	// Encode is a method that writes a vehicle
+	Encode(vh internal.Vehicle) (err error)
+	// Close is a method that terminates the file, it does not close the writer
+	Close() (err error)
+}
+
+// NewEncoder is a function that returns an encoder writing vehicles to w in format
+func NewEncoder(w io.Writer, format string) (e Encoder, err error) {
+	switch format {
+	case FormatJSON:
+		e = &jsonEncoder{w: w}
+	case FormatJSONLines:
+		e = &jsonLinesEncoder{enc: json.NewEncoder(w)}
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err = cw.Write(Header); err != nil {
+			return
+		}
+		e = &csvEncoder{w: cw}
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	return
+}
+
+// Decode is a function that reads the vehicles of r in format
+func Decode(r io.Reader, format string) (v []internal.Vehicle, err error) {
+	switch format {
+	case FormatJSON:
+		var records []record
+		if err = json.NewDecoder(r).Decode(&records); err != nil {
+			return
+		}
+		for _, rc := range records {
+			v = append(v, rc.toDomain())
+		}
+	case FormatJSONLines:
+		sc := bufio.NewScanner(r)
+		for line := 1; sc.Scan(); line++ {
+			if strings.TrimSpace(sc.Text()) == "" {
+				continue
+			}
+			var rc record
+			if err = json.Unmarshal(sc.Bytes(), &rc); err != nil {
+				err = fmt.Errorf("line %d: %w", line, err)
+				return
+			}
+			v = append(v, rc.toDomain())
+		}
+		err = sc.Err()
+	case FormatCSV:
+		v, err = decodeCSV(r)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	return
+}
+
+// record is a struct that represents a vehicle of the JSON formats
+type record struct {
+	ID int `json:"id"`
+	v2.VehicleRequest
+}
+
+// toDomain is a method that maps the record to a vehicle
+func (rc record) toDomain() internal.Vehicle {
+	return internal.Vehicle{Id: rc.ID, VehicleAttributes: rc.ToAttributes()}
+}
+
+// jsonEncoder is a struct that implements Encoder for FormatJSON
+type jsonEncoder struct {
+	// w is the writer of the file
+	w io.Writer
+	// n is the number of vehicles written
+	n int
+}
+
+// Encode is a method that writes a vehicle as an element of the array
+func (e *jsonEncoder) Encode(vh internal.Vehicle) (err error) {
+	b, err := json.Marshal(v2.VehicleToResponse(vh))
+	if err != nil {
+		return
+	}
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "["
+	}
+	if _, err = io.WriteString(e.w, sep); err != nil {
+		return
+	}
+	_, err = e.w.Write(b)
+	e.n++
+	return
+}
+
+// Close is a method that closes the array
+func (e *jsonEncoder) Close() (err error) {
+	end := "]\n"
+	if e.n == 0 {
+		end = "[]\n"
+	}
+	_, err = io.WriteString(e.w, end)
+	return
+}
+
+// jsonLinesEncoder is a struct that implements Encoder for FormatJSONLines
+type jsonLinesEncoder struct {
+	// enc is the encoder of the lines
+	enc *json.Encoder
+}
+
+// Encode is a method that writes a vehicle as a line
+func (e *jsonLinesEncoder) Encode(vh internal.Vehicle) error {
+	return e.enc.Encode(v2.VehicleToResponse(vh))
+}
+
+// Close is a method that does nothing, the lines are complete
+func (e *jsonLinesEncoder) Close() error {
+	return nil
+}
+
+// csvEncoder is a struct that implements Encoder for FormatCSV
+type csvEncoder struct {
+	// w is the writer of the records
+	w *csv.Writer
+}
+
+// Encode is a method that writes a vehicle as a record, flushed so that the
+// size of the underlying writer is up to date
+func (e *csvEncoder) Encode(vh internal.Vehicle) (err error) {
+	record, err := csvRecord(vh)
+	if err != nil {
+		return
+	}
+	if err = e.w.Write(record); err != nil {
+		return
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// Close is a method that flushes the records
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvRecord is a function that returns the record of a vehicle in the order of Header
+func csvRecord(vh internal.Vehicle) (record []string, err error) {
+	var custom []byte
+	if len(vh.Custom) > 0 {
+		if custom, err = json.Marshal(vh.Custom); err != nil {
+			return
+		}
+	}
+	record = []string{
+		strconv.Itoa(vh.Id), vh.Brand, vh.Model, vh.Registration, vh.Color,
+		strconv.Itoa(vh.FabricationYear), strconv.Itoa(vh.Capacity), formatFloat(vh.MaxSpeed),
+		vh.FuelType, vh.Transmission, formatFloat(vh.Weight),
+		formatFloat(vh.Height), formatFloat(vh.Length), formatFloat(vh.Width),
+		strings.Join(vh.Tags, " "), string(custom),
+	}
+	return
+}
+
+// decodeCSV is a function that reads the vehicles of a CSV file, its columns are
+// matched by the names of its header line and the absent ones are left empty
+func decodeCSV(r io.Reader) (v []internal.Vehicle, err error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for name := range columns {
+		if !contains(Header, name) {
+			err = fmt.Errorf("csv: unknown column %q", name)
+			return
+		}
+	}
+
+	for line := 2; ; line++ {
+		fields, e := cr.Read()
+		if errors.Is(e, io.EOF) {
+			return
+		}
+		if e != nil {
+			err = e
+			return
+		}
+
+		p := parser{fields: fields, columns: columns}
+		vh := internal.Vehicle{
+			Id: p.integer("id"),
+			VehicleAttributes: internal.VehicleAttributes{
+				Brand:           p.text("brand"),
+				Model:           p.text("model"),
+				Registration:    p.text("registration"),
+				Color:           p.text("color"),
+				FabricationYear: p.integer("year"),
+				Capacity:        p.integer("passengers"),
+				MaxSpeed:        p.number("max_speed"),
+				FuelType:        p.text("fuel_type"),
+				Transmission:    p.text("transmission"),
+				Weight:          p.number("weight"),
+				Dimensions: internal.Dimensions{
+					Height: p.number("height"),
+					Length: p.number("length"),
+					Width:  p.number("width"),
+				},
+				Tags:   internal.NormalizeTags(strings.Fields(p.text("tags"))),
+				Custom: p.object("custom"),
+			},
+		}
+		if p.err != nil {
+			err = fmt.Errorf("csv: line %d: %w", line, p.err)
+			return
+		}
+		v = append(v, vh)
+	}
+}
+
+// parser is a struct that reads the typed fields of a CSV record, keeping the first error
+type parser struct {
+	// fields are the fields of the record
+	fields []string
+	// columns are the indexes of the fields by column name
+	columns map[string]int
+	// err is the first error found
+	err error
+}
+
+// text is a method that returns the field of the column, "" when it is absent
+func (p *parser) text(column string) string {
+	i, ok := p.columns[column]
+	if !ok || i >= len(p.fields) {
+		return ""
+	}
+	return strings.TrimSpace(p.fields[i])
+}
+
+// integer is a method that returns the field of the column as an integer, 0 when it is absent
+func (p *parser) integer(column string) (n int) {
+	s := p.text(column)
+	if s == "" {
+		return
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("%s: %w", column, err)
+	}
+	return
+}
+
+// number is a method that returns the field of the column as a number, 0 when it is absent
+func (p *parser) number(column string) (f float64) {
+	s := p.text(column)
+	if s == "" {
+		return
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("%s: %w", column, err)
+	}
+	return
+}
+
+// object is a method that returns the field of the column as a JSON object, nil when it is absent
+func (p *parser) object(column string) (m map[string]any) {
+	s := p.text(column)
+	if s == "" {
+		return
+	}
+	if err := json.Unmarshal([]byte(s), &m); err != nil && p.err == nil {
+		p.err = fmt.Errorf("%s: %w", column, err)
+	}
+	return
+}
+
+// formatFloat is a function that formats f with the digits needed to read it back
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// contains is a function that reports whether s has name
+func contains(s []string, name string) bool {
+	for _, v := range s {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
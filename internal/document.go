@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// types of document
+const (
+	// DocumentInsurance is an insurance certificate
+	DocumentInsurance = "insurance"
+	// DocumentRegistration is a registration document
+	DocumentRegistration = "registration"
+	// DocumentDamagePhoto is a photo of a damage
+	DocumentDamagePhoto = "damage_photo"
+	// DocumentOther is any other document
+	DocumentOther = "other"
+)
+
+// DocumentTypes are the types of document
+var DocumentTypes = []string{DocumentInsurance, DocumentRegistration, DocumentDamagePhoto, DocumentOther}
+
+// DocumentContentTypes are the content types of the documents accepted, as sniffed from their content
+var DocumentContentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+// Document is a struct that represents a file attached to a vehicle
+type Document struct {
+	// Id is the unique identifier of the document
+	Id int
+	// VehicleId is the identifier of the vehicle
+	VehicleId int
+	// Type is the type of the document, one of DocumentTypes
+	Type string
+	// Name is the name of the file uploaded
+	Name string
+	// ContentType is the content type of the file, one of DocumentContentTypes
+	ContentType string
+	// Size is the size of the file, in bytes
+	Size int64
+	// Checksum is the SHA-256 of the file, in hexadecimal
+	Checksum string
+	// Expiry is the last day the document is valid, nil when it does not expire
+	Expiry *time.Time
+	// UploadedAt is when the file was uploaded
+	UploadedAt time.Time
+	// Thumbnail is true for an image with a thumbnail
+	Thumbnail bool
+}
+
+// ExpiringDocument is a struct that represents a document expiring soon, or expired
+type ExpiringDocument struct {
+	// Document is the document
+	Document Document
+	// DaysLeft is the number of days from today until its expiry, negative once expired
+	DaysLeft int
+}
+
+// Validate is a method that validates a document to be written
+func (d *Document) Validate() error {
+	switch d.Type {
+	case DocumentInsurance, DocumentRegistration, DocumentDamagePhoto, DocumentOther:
+	default:
+		return fmt.Errorf("type must be one of %s", strings.Join(DocumentTypes, ", "))
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name is required")
+	}
+	if d.Expiry != nil && d.Expiry.IsZero() {
+		return errors.New("expiry must be a day")
+	}
+	return nil
+}
+
+// BlobKey is a method that returns the key of the file of a document in the blob store
+func (d *Document) BlobKey() string {
+	return fmt.Sprintf("vehicles/%d/documents/%d", d.VehicleId, d.Id)
+}
+
+// ThumbnailKey is a method that returns the key of the thumbnail of a document in the blob store
+func (d *Document) ThumbnailKey() string {
+	return d.BlobKey() + ".thumbnail.jpg"
+}
@@ -0,0 +1,9 @@
+package internal
+
+// DocumentLoader is an interface that represents the loader for the metadata of the documents of the vehicles
+type DocumentLoader interface {
+	// Load is a method that loads the documents
+	Load() (d []Document, err error)
+	// Save is a method that writes the documents, replacing the ones written before
+	Save(d []Document) (err error)
+}
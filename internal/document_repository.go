@@ -0,0 +1,17 @@
+package internal
+
+import "context"
+
+// DocumentRepository is an interface that represents a repository of the metadata of the documents of the vehicles
+type DocumentRepository interface {
+	// FindAll is a method that returns the documents of a vehicle, of every vehicle for 0, by id
+	FindAll(ctx context.Context, vehicleId int) (d []Document, err error)
+	// FindById is a method that returns a document of a vehicle, ErrDocumentNotFound when there is none
+	FindById(ctx context.Context, vehicleId, id int) (d Document, err error)
+	// NextId is a method that reserves the id of a document to be saved, its file stored under it first
+	NextId(ctx context.Context) (id int, err error)
+	// Save is a method that adds a document with the id reserved for it
+	Save(ctx context.Context, d *Document) (v Document, err error)
+	// Delete is a method that removes a document of a vehicle, ErrDocumentNotFound when there is none
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// DocumentService is an interface that represents the service of the documents of the vehicles
+type DocumentService interface {
+	// FindAll is a method that returns the documents of a vehicle by id
+	FindAll(ctx context.Context, vehicleId int) (d []Document, err error)
+	// FindById is a method that returns a document of a vehicle
+	FindById(ctx context.Context, vehicleId, id int) (d Document, err error)
+	// Upload is a method that stores the file of a document, read from r, its content type sniffed
+	// and a thumbnail made for an image
+	Upload(ctx context.Context, d *Document, r io.Reader) (v Document, err error)
+	// Open is a method that returns a document of a vehicle and its file, or its thumbnail, for reading
+	Open(ctx context.Context, vehicleId, id int, thumbnail bool) (d Document, rc io.ReadCloser, err error)
+	// Delete is a method that removes a document of a vehicle and its files
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+	// Expiring is a method that returns the documents expired or expiring until the day until,
+	// the soonest first
+	Expiring(ctx context.Context, today, until time.Time) (e []ExpiringDocument, err error)
+}
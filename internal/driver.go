@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"app/pkg/license"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Driver is a struct that represents a driver of the fleet
+type Driver struct {
+	// Id is the unique identifier of the driver
+	Id int
+	// Name is the name of the driver
+	Name string
+	// LicenseNumber is the number of the driver's license, unique in the fleet
+	LicenseNumber string
+	// LicenseCategories are the categories of the driver's license
+	LicenseCategories license.Categories
+	// LicenseExpiry is the last day the driver's license is valid
+	LicenseExpiry time.Time
+}
+
+// Assignment is a struct that represents a driver assigned to a vehicle from Start until End
+type Assignment struct {
+	// Id is the unique identifier of the assignment
+	Id int
+	// VehicleId is the identifier of the vehicle
+	VehicleId int
+	// DriverId is the identifier of the driver
+	DriverId int
+	// Start is the beginning of the assignment
+	Start time.Time
+	// End is the end of the assignment, nil while it is open
+	End *time.Time
+}
+
+// AssignmentFilter is a struct that represents the criteria to find assignments, 0 for any
+type AssignmentFilter struct {
+	VehicleId int
+	DriverId  int
+}
+
+// DriverRegistry is a struct that represents the drivers of the fleet and their assignments
+type DriverRegistry struct {
+	// Drivers are the drivers
+	Drivers []Driver
+	// Assignments are the assignments of the drivers to the vehicles
+	Assignments []Assignment
+}
+
+// Validate is a method that validates a driver to be written
+func (d *Driver) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(d.LicenseNumber) == "" {
+		return errors.New("license number is required")
+	}
+	if len(d.LicenseCategories) == 0 {
+		return errors.New("license categories are required")
+	}
+	if d.LicenseExpiry.IsZero() {
+		return errors.New("license expiry is required")
+	}
+	return nil
+}
+
+// LicenseValid is a method that reports whether the driver's license is valid at t
+func (d *Driver) LicenseValid(t time.Time) bool {
+	expiry := time.Date(d.LicenseExpiry.Year(), d.LicenseExpiry.Month(), d.LicenseExpiry.Day()+1, 0, 0, 0, 0, time.UTC)
+	return t.Before(expiry)
+}
+
+// Validate is a method that validates an assignment to be written
+func (a *Assignment) Validate() error {
+	if a.Start.IsZero() {
+		return errors.New("start is required")
+	}
+	if a.End != nil && !a.End.After(a.Start) {
+		return errors.New("end must be after start")
+	}
+	return nil
+}
+
+// ActiveAt is a method that reports whether the assignment holds at t
+func (a *Assignment) ActiveAt(t time.Time) bool {
+	return !t.Before(a.Start) && (a.End == nil || t.Before(*a.End))
+}
+
+// Overlaps is a method that reports whether the intervals of both assignments intersect,
+// an open assignment lasting forever
+func (a *Assignment) Overlaps(b Assignment) bool {
+	return (a.End == nil || b.Start.Before(*a.End)) && (b.End == nil || a.Start.Before(*b.End))
+}
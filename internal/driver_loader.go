@@ -0,0 +1,7 @@
+package internal
+
+// DriverLoader is an interface that represents the loader for the drivers and their assignments
+type DriverLoader interface {
+	// Load is a method that loads the drivers and their assignments
+	Load() (r DriverRegistry, err error)
+}
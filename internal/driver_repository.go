@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// DriverRepository is an interface that represents a repository of the drivers and their assignments
+type DriverRepository interface {
+	// FindAll is a method that returns the drivers by id
+	FindAll(ctx context.Context) (d []Driver, err error)
+	// FindById is a method that returns a driver, ErrDriverNotFound when there is none
+	FindById(ctx context.Context, id int) (d Driver, err error)
+	// Save is a method that adds a driver, assigning its id, ErrDriverAlreadyExists when its
+	// license number is taken
+	Save(ctx context.Context, d *Driver) (v Driver, err error)
+	// Update is a method that replaces a driver, ErrDriverAlreadyExists when its license number
+	// is taken by another driver
+	Update(ctx context.Context, d *Driver) (v Driver, err error)
+	// FindAssignments is a method that returns the assignments matching the filter, the oldest first
+	FindAssignments(ctx context.Context, f AssignmentFilter) (a []Assignment, err error)
+	// SaveAssignment is a method that adds an assignment, assigning its id, ErrAssignmentConflict
+	// when it overlaps another of its vehicle or of its driver
+	SaveAssignment(ctx context.Context, a *Assignment) (v Assignment, err error)
+	// EndAssignment is a method that ends an assignment active at end, ErrAssignmentNotFound when there is none
+	EndAssignment(ctx context.Context, id int, end time.Time) (v Assignment, err error)
+}
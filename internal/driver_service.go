@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// DriverService is an interface that represents the service of the drivers and their assignments
+// to the vehicles
+type DriverService interface {
+	// FindAll is a method that returns the drivers by id
+	FindAll(ctx context.Context) (d []Driver, err error)
+	// FindById is a method that returns a driver
+	FindById(ctx context.Context, id int) (d Driver, err error)
+	// Save is a method that adds a driver
+	Save(ctx context.Context, d *Driver) (v Driver, err error)
+	// Patch is a method that replaces a driver
+	Patch(ctx context.Context, d *Driver) (v Driver, err error)
+	// Assign is a method that assigns a driver to a vehicle, the vehicle and the driver free during
+	// the assignment and the driver's license valid and fit for the vehicle
+	Assign(ctx context.Context, a *Assignment) (v Assignment, err error)
+	// Unassign is a method that ends the assignment of a vehicle active at the time at
+	Unassign(ctx context.Context, vehicleId int, at time.Time) (v Assignment, err error)
+	// VehicleAssignments is a method that returns the history of the assignments of a vehicle
+	VehicleAssignments(ctx context.Context, vehicleId int) (a []Assignment, err error)
+	// DriverAssignments is a method that returns the history of the assignments of a driver
+	DriverAssignments(ctx context.Context, driverId int) (a []Assignment, err error)
+	// CurrentByVehicle is a method that returns the assignment of a vehicle active at the time at,
+	// ErrAssignmentNotFound when it has no driver
+	CurrentByVehicle(ctx context.Context, vehicleId int, at time.Time) (a Assignment, err error)
+	// CurrentByDriver is a method that returns the assignment of a driver active at the time at,
+	// ErrAssignmentNotFound when it has no vehicle
+	CurrentByDriver(ctx context.Context, driverId int, at time.Time) (a Assignment, err error)
+}
@@ -0,0 +1,40 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAssignment_Overlaps is a function that checks the intersection of the intervals of two
+// assignments, the open ones lasting forever and the ones only touching not intersecting
+func TestAssignment_Overlaps(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC) }
+	until := func(day int) *time.Time { end := at(day); return &end }
+
+	cases := map[string]struct {
+		a, b Assignment
+		want bool
+	}{
+		"disjoint":           {a: Assignment{Start: at(1), End: until(3)}, b: Assignment{Start: at(5), End: until(7)}, want: false},
+		"touching":           {a: Assignment{Start: at(1), End: until(3)}, b: Assignment{Start: at(3), End: until(7)}, want: false},
+		"intersecting":       {a: Assignment{Start: at(1), End: until(4)}, b: Assignment{Start: at(3), End: until(7)}, want: true},
+		"contained":          {a: Assignment{Start: at(1), End: until(9)}, b: Assignment{Start: at(3), End: until(4)}, want: true},
+		"same":               {a: Assignment{Start: at(1), End: until(3)}, b: Assignment{Start: at(1), End: until(3)}, want: true},
+		"open after closed":  {a: Assignment{Start: at(1), End: until(3)}, b: Assignment{Start: at(3)}, want: false},
+		"open during closed": {a: Assignment{Start: at(1), End: until(3)}, b: Assignment{Start: at(2)}, want: true},
+		"open before closed": {a: Assignment{Start: at(1)}, b: Assignment{Start: at(5), End: until(7)}, want: true},
+		"closed before open": {a: Assignment{Start: at(5)}, b: Assignment{Start: at(1), End: until(5)}, want: false},
+		"both open":          {a: Assignment{Start: at(1)}, b: Assignment{Start: at(9)}, want: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := c.a.Overlaps(c.b); got != c.want {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+			if got := c.b.Overlaps(c.a); got != c.want {
+				t.Errorf("got %v the other way around, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"app/internal"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// Version is the version of the JSON contract of this package
+const Version = "v1"
+
+// VehicleResponse is a struct that represents a vehicle in the responses
+type VehicleResponse struct {
+	ID              int     `json:"id"`
+	Brand           string  `json:"brand"`
+	Model           string  `json:"model"`
+	Registration    string  `json:"registration"`
+	Color           string  `json:"color"`
+	FabricationYear int     `json:"year"`
+	Capacity        int     `json:"passengers"`
+	MaxSpeed        float64 `json:"max_speed"`
+	FuelType        string  `json:"fuel_type"`
+	Transmission    string  `json:"transmission"`
+	Weight          float64 `json:"weight"`
+	Height          float64 `json:"height"`
+	Length          float64 `json:"length"`
+	Width           float64 `json:"width"`
+}
+
+// VehicleRequest is a struct that represents the body to create a vehicle
+type VehicleRequest struct {
+	Brand           string  `json:"brand"`
+	Model           string  `json:"model"`
+	Registration    string  `json:"registration"`
+	Color           string  `json:"color"`
+	FabricationYear int     `json:"year"`
+	Capacity        int     `json:"passengers"`
+	MaxSpeed        float64 `json:"max_speed"`
+	FuelType        string  `json:"fuel_type"`
+	Transmission    string  `json:"transmission"`
+	Weight          float64 `json:"weight"`
+	Height          float64 `json:"height"`
+	Length          float64 `json:"length"`
+	Width           float64 `json:"width"`
+}
+
+// VehiclePatchRequest is a struct that represents the body to update some attributes of a vehicle,
+// the absent fields are kept
+type VehiclePatchRequest struct {
+	Brand           *string  `json:"brand,omitempty"`
+	Model           *string  `json:"model,omitempty"`
+	Registration    *string  `json:"registration,omitempty"`
+	Color           *string  `json:"color,omitempty"`
+	FabricationYear *int     `json:"year,omitempty"`
+	Capacity        *int     `json:"passengers,omitempty"`
+	MaxSpeed        *float64 `json:"max_speed,omitempty"`
+	FuelType        *string  `json:"fuel_type,omitempty"`
+	Transmission    *string  `json:"transmission,omitempty"`
+	Weight          *float64 `json:"weight,omitempty"`
+	Height          *float64 `json:"height,omitempty"`
+	Length          *float64 `json:"length,omitempty"`
+	Width           *float64 `json:"width,omitempty"`
+}
+
+// UpdateMaxSpeedRequest is a struct that represents the body to update the maximum speed of a vehicle
+type UpdateMaxSpeedRequest struct {
+	MaxSpeed float64 `json:"max_speed"`
+}
+
+// UpdateFuelRequest is a struct that represents the body to update the fuel type of a vehicle
+type UpdateFuelRequest struct {
+	FuelType string `json:"fuel_type"`
+}
+
+// Decode is a function that decodes a single JSON value from r into v,
+// rejecting unknown fields and trailing data
+func Decode(r io.Reader, v any) (err error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(v); err != nil {
+		return
+	}
+	if dec.More() {
+		err = errors.New("body must contain a single JSON value")
+	}
+	return
+}
+
+// VehicleToResponse is a function that maps a vehicle to its response
+func VehicleToResponse(v internal.Vehicle) VehicleResponse {
+	return VehicleResponse{
+		ID:              v.Id,
+		Brand:           v.Brand,
+		Model:           v.Model,
+		Registration:    v.Registration,
+		Color:           v.Color,
+		FabricationYear: v.FabricationYear,
+		Capacity:        v.Capacity,
+		MaxSpeed:        v.MaxSpeed,
+		FuelType:        v.FuelType,
+		Transmission:    v.Transmission,
+		Weight:          v.Weight,
+		Height:          v.Height,
+		Length:          v.Length,
+		Width:           v.Width,
+	}
+}
+
+// VehiclesToResponse is a function that maps the vehicles by id to their responses
+func VehiclesToResponse(v map[int]internal.Vehicle) map[int]VehicleResponse {
+	data := make(map[int]VehicleResponse, len(v))
+	for key, value := range v {
+		data[key] = VehicleToResponse(value)
+	}
+	return data
+}
+
+// ToAttributes is a method that maps the request to the attributes of a vehicle
+func (r VehicleRequest) ToAttributes() internal.VehicleAttributes {
+	return internal.VehicleAttributes{
+		Brand:           r.Brand,
+		Model:           r.Model,
+		Registration:    r.Registration,
+		Color:           r.Color,
+		FabricationYear: r.FabricationYear,
+		Capacity:        r.Capacity,
+		MaxSpeed:        r.MaxSpeed,
+		FuelType:        r.FuelType,
+		Transmission:    r.Transmission,
+		Weight:          r.Weight,
+		Dimensions: internal.Dimensions{
+			Height: r.Height,
+			Length: r.Length,
+			Width:  r.Width,
+		},
+	}
+}
+
+// VehicleRequestsToAttributes is a function that maps the requests to the attributes of vehicles
+func VehicleRequestsToAttributes(rs []VehicleRequest) []internal.VehicleAttributes {
+	attrs := make([]internal.VehicleAttributes, 0, len(rs))
+	for _, r := range rs {
+		attrs = append(attrs, r.ToAttributes())
+	}
+	return attrs
+}
+
+// ApplyTo is a method that overwrites the attributes present in the request
+func (r VehiclePatchRequest) ApplyTo(a *internal.VehicleAttributes) {
+	set(&a.Brand, r.Brand)
+	set(&a.Model, r.Model)
+	set(&a.Registration, r.Registration)
+	set(&a.Color, r.Color)
+	set(&a.FabricationYear, r.FabricationYear)
+	set(&a.Capacity, r.Capacity)
+	set(&a.MaxSpeed, r.MaxSpeed)
+	set(&a.FuelType, r.FuelType)
+	set(&a.Transmission, r.Transmission)
+	set(&a.Weight, r.Weight)
+	set(&a.Height, r.Height)
+	set(&a.Length, r.Length)
+	set(&a.Width, r.Width)
+}
+
+// set is a function that assigns *v to *dst when v is present
+func set[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
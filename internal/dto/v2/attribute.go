@@ -0,0 +1,43 @@
+package v2
+
+import "app/internal"
+
+// AttributeRequest is a struct that represents the body to add or replace a custom attribute of the
+// vehicles, its name in the route when it is replaced
+type AttributeRequest struct {
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Allowed  []any  `json:"allowed,omitempty"`
+}
+
+// AttributeResponse is a struct that represents a custom attribute of the vehicles of the tenant
+type AttributeResponse struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Allowed  []any  `json:"allowed"`
+}
+
+// ToDomain is a method that maps the request to an attribute definition
+func (r AttributeRequest) ToDomain() internal.AttributeDefinition {
+	return internal.AttributeDefinition{Name: r.Name, Type: r.Type, Required: r.Required, Allowed: r.Allowed}
+}
+
+// AttributeToResponse is a function that maps an attribute definition to its response
+func AttributeToResponse(d internal.AttributeDefinition) AttributeResponse {
+	allowed := d.Allowed
+	if allowed == nil {
+		allowed = []any{}
+	}
+	return AttributeResponse{Name: d.Name, Type: d.Type, Required: d.Required, Allowed: allowed}
+}
+
+// AttributesToList is a function that maps attribute definitions to a list, in their order
+func AttributesToList(d []internal.AttributeDefinition) List[AttributeResponse] {
+	data := make([]AttributeResponse, 0, len(d))
+	for _, value := range d {
+		data = append(data, AttributeToResponse(value))
+	}
+	return List[AttributeResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
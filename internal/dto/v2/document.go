@@ -0,0 +1,81 @@
+package v2
+
+import (
+	"app/internal"
+	"fmt"
+	"time"
+)
+
+// DocumentResponse is a struct that represents a document of a vehicle, expiry null when it does
+// not expire and thumbnail true for an image with one
+type DocumentResponse struct {
+	ID          int       `json:"id"`
+	VehicleID   int       `json:"vehicle_id"`
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	Expiry      *string   `json:"expiry"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+	Thumbnail   bool      `json:"thumbnail"`
+}
+
+// ExpiringDocumentResponse is a struct that represents a document expiring soon, days_left
+// negative once expired
+type ExpiringDocumentResponse struct {
+	Document DocumentResponse `json:"document"`
+	DaysLeft int              `json:"days_left"`
+}
+
+// DocumentFromForm is a function that maps the fields of an upload form to a document
+func DocumentFromForm(vehicleId int, typ, name, expiry string) (d internal.Document, err error) {
+	d = internal.Document{VehicleId: vehicleId, Type: typ, Name: name}
+	if expiry != "" {
+		var t time.Time
+		if t, err = time.Parse(DateLayout, expiry); err != nil {
+			err = fmt.Errorf("expiry must be a day as %s", DateLayout)
+			return
+		}
+		d.Expiry = &t
+	}
+	return
+}
+
+// DocumentToResponse is a function that maps a document to its response
+func DocumentToResponse(d internal.Document) DocumentResponse {
+	r := DocumentResponse{
+		ID:          d.Id,
+		VehicleID:   d.VehicleId,
+		Type:        d.Type,
+		Name:        d.Name,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		Checksum:    d.Checksum,
+		UploadedAt:  d.UploadedAt,
+		Thumbnail:   d.Thumbnail,
+	}
+	if d.Expiry != nil {
+		expiry := d.Expiry.Format(DateLayout)
+		r.Expiry = &expiry
+	}
+	return r
+}
+
+// DocumentsToList is a function that maps documents to a list, in their order
+func DocumentsToList(d []internal.Document) List[DocumentResponse] {
+	data := make([]DocumentResponse, 0, len(d))
+	for _, value := range d {
+		data = append(data, DocumentToResponse(value))
+	}
+	return List[DocumentResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ExpiringDocumentsToList is a function that maps the documents expiring to a list, in their order
+func ExpiringDocumentsToList(e []internal.ExpiringDocument) List[ExpiringDocumentResponse] {
+	data := make([]ExpiringDocumentResponse, 0, len(e))
+	for _, value := range e {
+		data = append(data, ExpiringDocumentResponse{Document: DocumentToResponse(value.Document), DaysLeft: value.DaysLeft})
+	}
+	return List[ExpiringDocumentResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"app/internal"
+	"app/pkg/license"
+	"fmt"
+	"time"
+)
+
+// DriverRequest is a struct that represents the body to add a driver
+type DriverRequest struct {
+	Name              string `json:"name"`
+	LicenseNumber     string `json:"license_number"`
+	LicenseCategories string `json:"license_categories"`
+	LicenseExpiry     string `json:"license_expiry"`
+}
+
+// DriverPatchRequest is a struct that represents the body to update some attributes of a driver,
+// the absent fields are kept
+type DriverPatchRequest struct {
+	Name              *string `json:"name,omitempty"`
+	LicenseNumber     *string `json:"license_number,omitempty"`
+	LicenseCategories *string `json:"license_categories,omitempty"`
+	LicenseExpiry     *string `json:"license_expiry,omitempty"`
+}
+
+// DriverResponse is a struct that represents a driver
+type DriverResponse struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	LicenseNumber     string `json:"license_number"`
+	LicenseCategories string `json:"license_categories"`
+	LicenseExpiry     string `json:"license_expiry"`
+}
+
+// AssignmentRequest is a struct that represents the body to assign a driver to a vehicle,
+// from start, now by default, until end, open when absent
+type AssignmentRequest struct {
+	DriverID int        `json:"driver_id"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+}
+
+// AssignmentResponse is a struct that represents an assignment, end null while it is open
+type AssignmentResponse struct {
+	ID        int        `json:"id"`
+	VehicleID int        `json:"vehicle_id"`
+	DriverID  int        `json:"driver_id"`
+	Start     time.Time  `json:"start"`
+	End       *time.Time `json:"end"`
+}
+
+// ToDomain is a method that maps the request to a driver
+func (r DriverRequest) ToDomain() (d internal.Driver, err error) {
+	d = internal.Driver{Name: r.Name, LicenseNumber: r.LicenseNumber}
+	if d.LicenseCategories, err = license.Parse(r.LicenseCategories); err != nil {
+		return
+	}
+	if d.LicenseExpiry, err = time.Parse(DateLayout, r.LicenseExpiry); err != nil {
+		err = fmt.Errorf("license_expiry must be a day as %s", DateLayout)
+	}
+	return
+}
+
+// ApplyTo is a method that overwrites the attributes of the driver present in the request
+func (r DriverPatchRequest) ApplyTo(d *internal.Driver) (err error) {
+	if r.Name != nil {
+		d.Name = *r.Name
+	}
+	if r.LicenseNumber != nil {
+		d.LicenseNumber = *r.LicenseNumber
+	}
+	if r.LicenseCategories != nil {
+		if d.LicenseCategories, err = license.Parse(*r.LicenseCategories); err != nil {
+			return
+		}
+	}
+	if r.LicenseExpiry != nil {
+		if d.LicenseExpiry, err = time.Parse(DateLayout, *r.LicenseExpiry); err != nil {
+			err = fmt.Errorf("license_expiry must be a day as %s", DateLayout)
+		}
+	}
+	return
+}
+
+// ToDomain is a method that maps the request to an assignment of the vehicle starting at now
+// when the start is absent
+func (r AssignmentRequest) ToDomain(vehicleId int, now time.Time) internal.Assignment {
+	a := internal.Assignment{VehicleId: vehicleId, DriverId: r.DriverID, Start: now, End: r.End}
+	if r.Start != nil {
+		a.Start = *r.Start
+	}
+	return a
+}
+
+// DriverToResponse is a function that maps a driver to its response
+func DriverToResponse(d internal.Driver) DriverResponse {
+	return DriverResponse{
+		ID:                d.Id,
+		Name:              d.Name,
+		LicenseNumber:     d.LicenseNumber,
+		LicenseCategories: d.LicenseCategories.String(),
+		LicenseExpiry:     d.LicenseExpiry.Format(DateLayout),
+	}
+}
+
+// DriversToList is a function that maps drivers to a list, in their order
+func DriversToList(d []internal.Driver) List[DriverResponse] {
+	data := make([]DriverResponse, 0, len(d))
+	for _, value := range d {
+		data = append(data, DriverToResponse(value))
+	}
+	return List[DriverResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// AssignmentToResponse is a function that maps an assignment to its response
+func AssignmentToResponse(a internal.Assignment) AssignmentResponse {
+	return AssignmentResponse{ID: a.Id, VehicleID: a.VehicleId, DriverID: a.DriverId, Start: a.Start, End: a.End}
+}
+
+// AssignmentsToList is a function that maps assignments to a list, in their order
+func AssignmentsToList(a []internal.Assignment) List[AssignmentResponse] {
+	data := make([]AssignmentResponse, 0, len(a))
+	for _, value := range a {
+		data = append(data, AssignmentToResponse(value))
+	}
+	return List[AssignmentResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
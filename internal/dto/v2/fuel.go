@@ -0,0 +1,146 @@
+package v2
+
+import (
+	"app/internal"
+	"time"
+)
+
+// FuelTransactionRequest is a struct that represents the body to record a refuel or a charge,
+// fuel_type absent for the current one of the vehicle
+type FuelTransactionRequest struct {
+	Time     time.Time `json:"time"`
+	FuelType string    `json:"fuel_type,omitempty"`
+	Quantity float64   `json:"quantity"`
+	Cost     float64   `json:"cost"`
+	Station  string    `json:"station"`
+	Odometer float64   `json:"odometer"`
+}
+
+// FuelTransactionResponse is a struct that represents a fuel transaction, the quantity in unit,
+// l or kWh
+type FuelTransactionResponse struct {
+	ID        int       `json:"id"`
+	VehicleID int       `json:"vehicle_id"`
+	Time      time.Time `json:"time"`
+	FuelType  string    `json:"fuel_type"`
+	Quantity  float64   `json:"quantity"`
+	Unit      string    `json:"unit"`
+	Cost      float64   `json:"cost"`
+	Station   string    `json:"station"`
+	Odometer  float64   `json:"odometer"`
+}
+
+// ConsumptionResponse is a struct that represents the consumption measured by a transaction,
+// flag high_consumption, a possible fuel fraud, or low_consumption outside the normal band of
+// the vehicle and null inside
+type ConsumptionResponse struct {
+	Transaction FuelTransactionResponse `json:"transaction"`
+	Distance    float64                 `json:"distance"`
+	Value       float64                 `json:"value"`
+	Unit        string                  `json:"unit"`
+	Flag        *string                 `json:"flag"`
+}
+
+// ConsumptionReportResponse is a struct that represents the consumption of the current fuel type
+// of a vehicle, median, low and high null with too few consumptions to tell its normal band
+type ConsumptionReportResponse struct {
+	VehicleID    int                   `json:"vehicle_id"`
+	FuelType     string                `json:"fuel_type"`
+	Unit         string                `json:"unit"`
+	Distance     float64               `json:"distance"`
+	Quantity     float64               `json:"quantity"`
+	Cost         float64               `json:"cost"`
+	Average      *float64              `json:"average"`
+	Median       *float64              `json:"median"`
+	Low          *float64              `json:"low"`
+	High         *float64              `json:"high"`
+	Consumptions []ConsumptionResponse `json:"consumptions"`
+}
+
+// ToDomain is a method that maps the request to a transaction of the vehicle
+func (r FuelTransactionRequest) ToDomain(vehicleId int) internal.FuelTransaction {
+	return internal.FuelTransaction{
+		VehicleId: vehicleId,
+		Time:      r.Time,
+		FuelType:  r.FuelType,
+		Quantity:  r.Quantity,
+		Cost:      r.Cost,
+		Station:   r.Station,
+		Odometer:  r.Odometer,
+	}
+}
+
+// FuelTransactionToResponse is a function that maps a fuel transaction to its response
+func FuelTransactionToResponse(t internal.FuelTransaction) FuelTransactionResponse {
+	return FuelTransactionResponse{
+		ID:        t.Id,
+		VehicleID: t.VehicleId,
+		Time:      t.Time,
+		FuelType:  t.FuelType,
+		Quantity:  t.Quantity,
+		Unit:      internal.FuelUnit(t.FuelType),
+		Cost:      t.Cost,
+		Station:   t.Station,
+		Odometer:  t.Odometer,
+	}
+}
+
+// FuelTransactionsToList is a function that maps fuel transactions to a list, in their order
+func FuelTransactionsToList(t []internal.FuelTransaction) List[FuelTransactionResponse] {
+	data := make([]FuelTransactionResponse, 0, len(t))
+	for _, value := range t {
+		data = append(data, FuelTransactionToResponse(value))
+	}
+	return List[FuelTransactionResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ConsumptionToResponse is a function that maps a consumption to its response
+func ConsumptionToResponse(c internal.Consumption) ConsumptionResponse {
+	rs := ConsumptionResponse{
+		Transaction: FuelTransactionToResponse(c.Transaction),
+		Distance:    c.Distance,
+		Value:       round(c.Value),
+		Unit:        c.Unit,
+	}
+	if c.Flag != "" {
+		flag := c.Flag
+		rs.Flag = &flag
+	}
+	return rs
+}
+
+// ConsumptionsToList is a function that maps consumptions to a list, in their order
+func ConsumptionsToList(c []internal.Consumption) List[ConsumptionResponse] {
+	data := make([]ConsumptionResponse, 0, len(c))
+	for _, value := range c {
+		data = append(data, ConsumptionToResponse(value))
+	}
+	return List[ConsumptionResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ConsumptionReportToResponse is a function that maps a consumption report to its response
+func ConsumptionReportToResponse(r internal.ConsumptionReport) ConsumptionReportResponse {
+	rs := ConsumptionReportResponse{
+		VehicleID:    r.VehicleId,
+		FuelType:     r.FuelType,
+		Unit:         r.Unit,
+		Distance:     r.Distance,
+		Quantity:     round(r.Quantity),
+		Cost:         round(r.Cost),
+		Consumptions: make([]ConsumptionResponse, 0, len(r.Consumptions)),
+	}
+	optional := func(f float64) *float64 {
+		f = round(f)
+		return &f
+	}
+	if r.Distance > 0 {
+		rs.Average = optional(r.Average)
+	}
+	if r.Median > 0 {
+		rs.Median, rs.Low, rs.High = optional(r.Median), optional(r.Low), optional(r.High)
+	}
+	for _, c := range r.Consumptions {
+		rs.Consumptions = append(rs.Consumptions, ConsumptionToResponse(c))
+	}
+	return rs
+}
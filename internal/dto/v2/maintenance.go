@@ -0,0 +1,165 @@
+package v2
+
+import (
+	"app/internal"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the layout of the days in the requests and responses
+const DateLayout = time.DateOnly
+
+// MaintenanceEventRequest is a struct that represents the body to record a maintenance event
+type MaintenanceEventRequest struct {
+	Date     string  `json:"date"`
+	Odometer float64 `json:"odometer"`
+	Cost     float64 `json:"cost"`
+	Type     string  `json:"type"`
+	Workshop string  `json:"workshop"`
+}
+
+// MaintenanceEventResponse is a struct that represents a maintenance event
+type MaintenanceEventResponse struct {
+	ID        int     `json:"id"`
+	VehicleID int     `json:"vehicle_id"`
+	Date      string  `json:"date"`
+	Odometer  float64 `json:"odometer"`
+	Cost      float64 `json:"cost"`
+	Type      string  `json:"type"`
+	Workshop  string  `json:"workshop"`
+}
+
+// MaintenancePlanRequest is a struct that represents the body to add a maintenance plan,
+// the first interval starting today and at the odometer of the vehicle when start_date and
+// start_odometer are absent
+type MaintenancePlanRequest struct {
+	Type          string  `json:"type"`
+	IntervalDays  int     `json:"interval_days"`
+	IntervalKm    float64 `json:"interval_km"`
+	StartDate     string  `json:"start_date,omitempty"`
+	StartOdometer float64 `json:"start_odometer"`
+}
+
+// MaintenancePlanResponse is a struct that represents a maintenance plan
+type MaintenancePlanResponse struct {
+	ID            int     `json:"id"`
+	VehicleID     int     `json:"vehicle_id"`
+	Type          string  `json:"type"`
+	IntervalDays  int     `json:"interval_days"`
+	IntervalKm    float64 `json:"interval_km"`
+	StartDate     string  `json:"start_date"`
+	StartOdometer float64 `json:"start_odometer"`
+}
+
+// MaintenanceDueResponse is a struct that represents when the next service of a plan is due,
+// due_date and due_odometer null for a plan without that interval
+type MaintenanceDueResponse struct {
+	Plan        MaintenancePlanResponse   `json:"plan"`
+	Status      string                    `json:"status"`
+	Odometer    float64                   `json:"odometer"`
+	DueDate     *string                   `json:"due_date"`
+	DueOdometer *float64                  `json:"due_odometer"`
+	Last        *MaintenanceEventResponse `json:"last_event"`
+}
+
+// ToDomain is a method that maps the request to an event of the vehicle
+func (r MaintenanceEventRequest) ToDomain(vehicleId int) (e internal.MaintenanceEvent, err error) {
+	date, err := time.Parse(DateLayout, r.Date)
+	if err != nil {
+		err = fmt.Errorf("date must be a day as %s", DateLayout)
+		return
+	}
+	e = internal.MaintenanceEvent{
+		VehicleId: vehicleId,
+		Date:      date,
+		Odometer:  r.Odometer,
+		Cost:      r.Cost,
+		Type:      r.Type,
+		Workshop:  r.Workshop,
+	}
+	return
+}
+
+// ToDomain is a method that maps the request to a plan of the vehicle
+func (r MaintenancePlanRequest) ToDomain(vehicleId int) (p internal.MaintenancePlan, err error) {
+	p = internal.MaintenancePlan{
+		VehicleId:     vehicleId,
+		Type:          r.Type,
+		IntervalDays:  r.IntervalDays,
+		IntervalKm:    r.IntervalKm,
+		StartOdometer: r.StartOdometer,
+	}
+	if r.StartDate != "" {
+		if p.StartDate, err = time.Parse(DateLayout, r.StartDate); err != nil {
+			err = fmt.Errorf("start_date must be a day as %s", DateLayout)
+		}
+	}
+	return
+}
+
+// MaintenanceEventToResponse is a function that maps a maintenance event to its response
+func MaintenanceEventToResponse(e internal.MaintenanceEvent) MaintenanceEventResponse {
+	return MaintenanceEventResponse{
+		ID:        e.Id,
+		VehicleID: e.VehicleId,
+		Date:      e.Date.Format(DateLayout),
+		Odometer:  e.Odometer,
+		Cost:      e.Cost,
+		Type:      e.Type,
+		Workshop:  e.Workshop,
+	}
+}
+
+// MaintenanceEventsToList is a function that maps maintenance events to a list, in their order
+func MaintenanceEventsToList(e []internal.MaintenanceEvent) List[MaintenanceEventResponse] {
+	data := make([]MaintenanceEventResponse, 0, len(e))
+	for _, value := range e {
+		data = append(data, MaintenanceEventToResponse(value))
+	}
+	return List[MaintenanceEventResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// MaintenancePlanToResponse is a function that maps a maintenance plan to its response
+func MaintenancePlanToResponse(p internal.MaintenancePlan) MaintenancePlanResponse {
+	return MaintenancePlanResponse{
+		ID:            p.Id,
+		VehicleID:     p.VehicleId,
+		Type:          p.Type,
+		IntervalDays:  p.IntervalDays,
+		IntervalKm:    p.IntervalKm,
+		StartDate:     p.StartDate.Format(DateLayout),
+		StartOdometer: p.StartOdometer,
+	}
+}
+
+// MaintenancePlansToList is a function that maps maintenance plans to a list, in their order
+func MaintenancePlansToList(p []internal.MaintenancePlan) List[MaintenancePlanResponse] {
+	data := make([]MaintenancePlanResponse, 0, len(p))
+	for _, value := range p {
+		data = append(data, MaintenancePlanToResponse(value))
+	}
+	return List[MaintenancePlanResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// MaintenanceDueToList is a function that maps the due plans to a list, in their order
+func MaintenanceDueToList(d []internal.MaintenanceDue) List[MaintenanceDueResponse] {
+	data := make([]MaintenanceDueResponse, 0, len(d))
+	for _, value := range d {
+		r := MaintenanceDueResponse{
+			Plan:        MaintenancePlanToResponse(value.Plan),
+			Status:      value.Status,
+			Odometer:    value.Odometer,
+			DueOdometer: value.DueOdometer,
+		}
+		if value.DueDate != nil {
+			date := value.DueDate.Format(DateLayout)
+			r.DueDate = &date
+		}
+		if value.Last != nil {
+			last := MaintenanceEventToResponse(*value.Last)
+			r.Last = &last
+		}
+		data = append(data, r)
+	}
+	return List[MaintenanceDueResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
@@ -0,0 +1,92 @@
+package v2
+
+import (
+	"app/internal"
+	"app/pkg/ical"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReservationRequest is a struct that represents the body to book a vehicle
+type ReservationRequest struct {
+	VehicleID int       `json:"vehicle_id"`
+	Requester string    `json:"requester"`
+	Purpose   string    `json:"purpose"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// ReservationResponse is a struct that represents a reservation, checked_out_at and
+// checked_in_at null before the check-out and the check-in
+type ReservationResponse struct {
+	ID           int        `json:"id"`
+	VehicleID    int        `json:"vehicle_id"`
+	Requester    string     `json:"requester"`
+	Purpose      string     `json:"purpose"`
+	Start        time.Time  `json:"start"`
+	End          time.Time  `json:"end"`
+	Status       string     `json:"status"`
+	CheckedOutAt *time.Time `json:"checked_out_at"`
+	CheckedInAt  *time.Time `json:"checked_in_at"`
+}
+
+// ToDomain is a method that maps the request to a reservation
+func (r ReservationRequest) ToDomain() internal.Reservation {
+	return internal.Reservation{VehicleId: r.VehicleID, Requester: r.Requester, Purpose: r.Purpose, Start: r.Start, End: r.End}
+}
+
+// ReservationToResponse is a function that maps a reservation to its response
+func ReservationToResponse(r internal.Reservation) ReservationResponse {
+	return ReservationResponse{
+		ID:           r.Id,
+		VehicleID:    r.VehicleId,
+		Requester:    r.Requester,
+		Purpose:      r.Purpose,
+		Start:        r.Start,
+		End:          r.End,
+		Status:       r.Status,
+		CheckedOutAt: r.CheckedOutAt,
+		CheckedInAt:  r.CheckedInAt,
+	}
+}
+
+// ReservationsToList is a function that maps reservations to a list, in their order
+func ReservationsToList(r []internal.Reservation) List[ReservationResponse] {
+	data := make([]ReservationResponse, 0, len(r))
+	for _, value := range r {
+		data = append(data, ReservationToResponse(value))
+	}
+	return List[ReservationResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ReservationsToCalendar is a function that maps reservations to an iCalendar, one event per
+// reservation titled by its vehicle, found in vehicles, stamped at now
+func ReservationsToCalendar(r []internal.Reservation, vehicles map[int]internal.Vehicle, now time.Time) ical.Calendar {
+	c := ical.Calendar{ProdID: "-//go-api-rest//reservations//EN", Name: "Pool vehicle reservations"}
+	for _, value := range r {
+		summary := fmt.Sprintf("Vehicle %d", value.VehicleId)
+		if vh, ok := vehicles[value.VehicleId]; ok {
+			summary = strings.TrimSpace(fmt.Sprintf("%s %s %s", vh.Brand, vh.Model, vh.Registration))
+		}
+		description := "Requester: " + value.Requester
+		if value.Purpose != "" {
+			description += "\nPurpose: " + value.Purpose
+		}
+		status := ical.StatusConfirmed
+		if value.Status == internal.ReservationCancelled {
+			status = ical.StatusCancelled
+		}
+
+		c.Events = append(c.Events, ical.Event{
+			UID:         fmt.Sprintf("reservation-%d@go-api-rest", value.Id),
+			Start:       value.Start,
+			End:         value.End,
+			Stamp:       now,
+			Summary:     summary,
+			Description: description,
+			Status:      status,
+		})
+	}
+	return c
+}
@@ -0,0 +1,115 @@
+package v2
+
+import (
+	"app/internal"
+	"time"
+)
+
+// TelemetrySampleRequest is a struct that represents a reading of a GPS tracker, a line of the
+// NDJSON body or an element of the JSON array of the ingestion
+type TelemetrySampleRequest struct {
+	VehicleID int       `json:"vehicle_id"`
+	Time      time.Time `json:"time"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Speed     float64   `json:"speed"`
+}
+
+// TelemetrySampleResponse is a struct that represents a reading of a GPS tracker
+type TelemetrySampleResponse struct {
+	VehicleID int       `json:"vehicle_id"`
+	Time      time.Time `json:"time"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+	Speed     float64   `json:"speed"`
+}
+
+// SpeedAlertResponse is a struct that represents a reading above the max speed of its vehicle
+type SpeedAlertResponse struct {
+	Sample   TelemetrySampleResponse `json:"sample"`
+	MaxSpeed float64                 `json:"max_speed"`
+}
+
+// IngestRejectionResponse is a struct that represents a sample not ingested, index its position
+// in the batch or the line of the NDJSON body, from 0
+type IngestRejectionResponse struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// IngestResponse is a struct that represents the outcome of the ingestion of a batch of samples
+type IngestResponse struct {
+	Accepted int                       `json:"accepted"`
+	Rejected []IngestRejectionResponse `json:"rejected"`
+	Alerts   []SpeedAlertResponse      `json:"alerts"`
+}
+
+// ToDomain is a method that maps the request to a sample
+func (r TelemetrySampleRequest) ToDomain() internal.TelemetrySample {
+	return internal.TelemetrySample{VehicleId: r.VehicleID, Time: r.Time, Latitude: r.Latitude, Longitude: r.Longitude, Speed: r.Speed}
+}
+
+// TelemetrySampleToResponse is a function that maps a sample to its response
+func TelemetrySampleToResponse(s internal.TelemetrySample) TelemetrySampleResponse {
+	return TelemetrySampleResponse{VehicleID: s.VehicleId, Time: s.Time, Latitude: s.Latitude, Longitude: s.Longitude, Speed: s.Speed}
+}
+
+// TelemetrySamplesToList is a function that maps samples to a list, in their order
+func TelemetrySamplesToList(s []internal.TelemetrySample) List[TelemetrySampleResponse] {
+	data := make([]TelemetrySampleResponse, 0, len(s))
+	for _, value := range s {
+		data = append(data, TelemetrySampleToResponse(value))
+	}
+	return List[TelemetrySampleResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// SpeedAlertToResponse is a function that maps a speed alert to its response
+func SpeedAlertToResponse(a internal.SpeedAlert) SpeedAlertResponse {
+	return SpeedAlertResponse{Sample: TelemetrySampleToResponse(a.Sample), MaxSpeed: a.MaxSpeed}
+}
+
+// SpeedAlertsToList is a function that maps speed alerts to a list, in their order
+func SpeedAlertsToList(a []internal.SpeedAlert) List[SpeedAlertResponse] {
+	data := make([]SpeedAlertResponse, 0, len(a))
+	for _, value := range a {
+		data = append(data, SpeedAlertToResponse(value))
+	}
+	return List[SpeedAlertResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// IngestToResponse is a function that maps the outcome of an ingestion to its response
+func IngestToResponse(r internal.IngestResult) IngestResponse {
+	rs := IngestResponse{
+		Accepted: r.Accepted,
+		Rejected: make([]IngestRejectionResponse, 0, len(r.Rejected)),
+		Alerts:   make([]SpeedAlertResponse, 0, len(r.Alerts)),
+	}
+	for _, value := range r.Rejected {
+		rs.Rejected = append(rs.Rejected, IngestRejectionResponse{Index: value.Index, Reason: value.Reason})
+	}
+	for _, value := range r.Alerts {
+		rs.Alerts = append(rs.Alerts, SpeedAlertToResponse(value))
+	}
+	return rs
+}
+
+// NearbyVehicleResponse is a struct that represents a vehicle near a point, at its latest position,
+// distance in km
+type NearbyVehicleResponse struct {
+	Vehicle  VehicleResponse         `json:"vehicle"`
+	Position TelemetrySampleResponse `json:"position"`
+	Distance float64                 `json:"distance"`
+}
+
+// NearbyToList is a function that maps vehicles near a point to a list, in their order
+func NearbyToList(n []internal.NearbyVehicle) List[NearbyVehicleResponse] {
+	data := make([]NearbyVehicleResponse, 0, len(n))
+	for _, value := range n {
+		data = append(data, NearbyVehicleResponse{
+			Vehicle:  VehicleToResponse(value.Vehicle),
+			Position: TelemetrySampleToResponse(value.Sample),
+			Distance: round(value.Distance),
+		})
+	}
+	return List[NearbyVehicleResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
@@ -0,0 +1,175 @@
+package v2
+
+import (
+	"app/internal"
+	"math"
+	"time"
+)
+
+// TripRequest is a struct that represents the body to record a trip, driver_id absent when unknown
+type TripRequest struct {
+	DriverID      *int      `json:"driver_id,omitempty"`
+	Purpose       string    `json:"purpose"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	StartOdometer float64   `json:"start_odometer"`
+	EndOdometer   float64   `json:"end_odometer"`
+}
+
+// TripResponse is a struct that represents a trip, driver_id null when unknown
+type TripResponse struct {
+	ID            int       `json:"id"`
+	VehicleID     int       `json:"vehicle_id"`
+	DriverID      *int      `json:"driver_id"`
+	Purpose       string    `json:"purpose"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	StartOdometer float64   `json:"start_odometer"`
+	EndOdometer   float64   `json:"end_odometer"`
+	Distance      float64   `json:"distance"`
+}
+
+// UtilizationResponse is a struct that represents the use of the fleet over a period of days
+type UtilizationResponse struct {
+	From     string                       `json:"from"`
+	To       string                       `json:"to"`
+	Days     int                          `json:"days"`
+	Vehicles []VehicleUtilizationResponse `json:"vehicles"`
+	Brands   []BrandUtilizationResponse   `json:"brands"`
+}
+
+// VehicleUtilizationResponse is a struct that represents the use of a vehicle over a period,
+// usage the share of the days with a trip
+type VehicleUtilizationResponse struct {
+	VehicleID    int     `json:"vehicle_id"`
+	Brand        string  `json:"brand"`
+	Model        string  `json:"model"`
+	Registration string  `json:"registration"`
+	Trips        int     `json:"trips"`
+	Distance     float64 `json:"distance"`
+	Hours        float64 `json:"hours"`
+	ActiveDays   int     `json:"active_days"`
+	IdleDays     int     `json:"idle_days"`
+	Usage        float64 `json:"usage"`
+}
+
+// BrandUtilizationResponse is a struct that represents the use of the vehicles of a brand over a period,
+// usage the share of the vehicle days with a trip
+type BrandUtilizationResponse struct {
+	Brand      string  `json:"brand"`
+	Vehicles   int     `json:"vehicles"`
+	Trips      int     `json:"trips"`
+	Distance   float64 `json:"distance"`
+	Hours      float64 `json:"hours"`
+	ActiveDays int     `json:"active_days"`
+	IdleDays   int     `json:"idle_days"`
+	Usage      float64 `json:"usage"`
+}
+
+// UnderusedResponse is a struct that represents a vehicle underused over a period, the reasons
+// low_distance and low_usage the thresholds it is below
+type UnderusedResponse struct {
+	VehicleUtilizationResponse
+	Reasons []string `json:"reasons"`
+}
+
+// ToDomain is a method that maps the request to a trip of the vehicle
+func (r TripRequest) ToDomain(vehicleId int) internal.Trip {
+	t := internal.Trip{
+		VehicleId:     vehicleId,
+		Purpose:       r.Purpose,
+		Start:         r.Start,
+		End:           r.End,
+		StartOdometer: r.StartOdometer,
+		EndOdometer:   r.EndOdometer,
+	}
+	if r.DriverID != nil {
+		t.DriverId = *r.DriverID
+	}
+	return t
+}
+
+// TripToResponse is a function that maps a trip to its response
+func TripToResponse(t internal.Trip) TripResponse {
+	rs := TripResponse{
+		ID:            t.Id,
+		VehicleID:     t.VehicleId,
+		Purpose:       t.Purpose,
+		Start:         t.Start,
+		End:           t.End,
+		StartOdometer: t.StartOdometer,
+		EndOdometer:   t.EndOdometer,
+		Distance:      t.Distance(),
+	}
+	if t.DriverId != 0 {
+		driverId := t.DriverId
+		rs.DriverID = &driverId
+	}
+	return rs
+}
+
+// TripsToList is a function that maps trips to a list, in their order
+func TripsToList(t []internal.Trip) List[TripResponse] {
+	data := make([]TripResponse, 0, len(t))
+	for _, value := range t {
+		data = append(data, TripToResponse(value))
+	}
+	return List[TripResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// UtilizationToResponse is a function that maps a utilization report to its response
+func UtilizationToResponse(r internal.UtilizationReport) UtilizationResponse {
+	rs := UtilizationResponse{
+		From:     r.From.Format(DateLayout),
+		To:       r.To.Format(DateLayout),
+		Days:     r.Days,
+		Vehicles: make([]VehicleUtilizationResponse, 0, len(r.Vehicles)),
+		Brands:   make([]BrandUtilizationResponse, 0, len(r.Brands)),
+	}
+	for _, u := range r.Vehicles {
+		rs.Vehicles = append(rs.Vehicles, vehicleUtilizationToResponse(u))
+	}
+	for _, b := range r.Brands {
+		rs.Brands = append(rs.Brands, BrandUtilizationResponse{
+			Brand:      b.Brand,
+			Vehicles:   b.Vehicles,
+			Trips:      b.Trips,
+			Distance:   round(b.Distance),
+			Hours:      round(b.Hours),
+			ActiveDays: b.ActiveDays,
+			IdleDays:   b.IdleDays,
+			Usage:      round(b.Usage()),
+		})
+	}
+	return rs
+}
+
+// UnderusedToList is a function that maps underused vehicles to a list, in their order
+func UnderusedToList(u []internal.UnderusedVehicle) List[UnderusedResponse] {
+	data := make([]UnderusedResponse, 0, len(u))
+	for _, value := range u {
+		data = append(data, UnderusedResponse{VehicleUtilizationResponse: vehicleUtilizationToResponse(value.Utilization), Reasons: value.Reasons})
+	}
+	return List[UnderusedResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// vehicleUtilizationToResponse is a function that maps the utilization of a vehicle to its response
+func vehicleUtilizationToResponse(u internal.Utilization) VehicleUtilizationResponse {
+	return VehicleUtilizationResponse{
+		VehicleID:    u.Vehicle.Id,
+		Brand:        u.Vehicle.Brand,
+		Model:        u.Vehicle.Model,
+		Registration: u.Vehicle.Registration,
+		Trips:        u.Trips,
+		Distance:     round(u.Distance),
+		Hours:        round(u.Hours),
+		ActiveDays:   u.ActiveDays,
+		IdleDays:     u.IdleDays,
+		Usage:        round(u.Usage()),
+	}
+}
+
+// round is a function that rounds f to 2 decimals
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,388 @@
+package v2
+
+import (
+	"app/internal"
+	"app/internal/dto/v1"
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the version of the JSON contract of this package
+const Version = "v2"
+
+// VehicleResponse is a struct that represents a vehicle in the responses, the v1 fields with
+// the tags and the custom attributes
+type VehicleResponse struct {
+	ID              int            `json:"id"`
+	Brand           string         `json:"brand"`
+	Model           string         `json:"model"`
+	Registration    string         `json:"registration"`
+	Color           string         `json:"color"`
+	FabricationYear int            `json:"year"`
+	Capacity        int            `json:"passengers"`
+	MaxSpeed        float64        `json:"max_speed"`
+	FuelType        string         `json:"fuel_type"`
+	Transmission    string         `json:"transmission"`
+	Weight          float64        `json:"weight"`
+	Height          float64        `json:"height"`
+	Length          float64        `json:"length"`
+	Width           float64        `json:"width"`
+	Tags            []string       `json:"tags,omitempty"`
+	Custom          map[string]any `json:"custom,omitempty"`
+}
+
+// VehicleRequest is a struct that represents the body to create a vehicle
+type VehicleRequest struct {
+	Brand           string         `json:"brand"`
+	Model           string         `json:"model"`
+	Registration    string         `json:"registration"`
+	Color           string         `json:"color"`
+	FabricationYear int            `json:"year"`
+	Capacity        int            `json:"passengers"`
+	MaxSpeed        float64        `json:"max_speed"`
+	FuelType        string         `json:"fuel_type"`
+	Transmission    string         `json:"transmission"`
+	Weight          float64        `json:"weight"`
+	Height          float64        `json:"height"`
+	Length          float64        `json:"length"`
+	Width           float64        `json:"width"`
+	Tags            []string       `json:"tags,omitempty"`
+	Custom          map[string]any `json:"custom,omitempty"`
+}
+
+// VehiclePatchRequest is a struct that represents the body to update some attributes of a vehicle,
+// including the maximum speed and the fuel type that v1 updates on dedicated routes. The absent
+// fields are kept, the tags present replace the ones of the vehicle, and the custom attributes
+// present are merged into its ones, a null removing an attribute.
+type VehiclePatchRequest struct {
+	Brand           *string        `json:"brand,omitempty"`
+	Model           *string        `json:"model,omitempty"`
+	Registration    *string        `json:"registration,omitempty"`
+	Color           *string        `json:"color,omitempty"`
+	FabricationYear *int           `json:"year,omitempty"`
+	Capacity        *int           `json:"passengers,omitempty"`
+	MaxSpeed        *float64       `json:"max_speed,omitempty"`
+	FuelType        *string        `json:"fuel_type,omitempty"`
+	Transmission    *string        `json:"transmission,omitempty"`
+	Weight          *float64       `json:"weight,omitempty"`
+	Height          *float64       `json:"height,omitempty"`
+	Length          *float64       `json:"length,omitempty"`
+	Width           *float64       `json:"width,omitempty"`
+	Tags            *[]string      `json:"tags,omitempty"`
+	Custom          map[string]any `json:"custom,omitempty"`
+}
+
+// Decode is a function that decodes a single JSON value rejecting unknown fields
+var Decode = v1.Decode
+
+// Data is a struct that represents a successful response with a single resource
+type Data[T any] struct {
+	Data T `json:"data"`
+}
+
+// List is a struct that represents a successful response with a list of resources
+type List[T any] struct {
+	Data []T  `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// Meta is a struct that represents the metadata of a list
+type Meta struct {
+	// Total is the number of resources in the list
+	Total int `json:"total"`
+}
+
+// Error is a struct that represents an error response
+type Error struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody is a struct that represents the details of an error
+type ErrorBody struct {
+	// Code is a stable, machine readable identifier of the error
+	Code string `json:"code"`
+	// Message is a human readable description of the error
+	Message string `json:"message"`
+}
+
+// BrandStatsResponse is a struct that represents the aggregates of a brand
+type BrandStatsResponse struct {
+	Brand           string  `json:"brand"`
+	AverageSpeed    float64 `json:"average_speed"`
+	AverageCapacity int     `json:"average_capacity"`
+}
+
+// BrandResponse is a struct that represents a brand of the catalog
+type BrandResponse struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Models  []string `json:"models"`
+}
+
+// UnknownBrandResponse is a struct that represents a brand of the vehicles missing from the catalog
+type UnknownBrandResponse struct {
+	Name     string `json:"name"`
+	Vehicles int    `json:"vehicles"`
+}
+
+// MatchResponse is a struct that represents a vehicle found by a search and its relevance
+type MatchResponse struct {
+	VehicleResponse
+	Score float64 `json:"score"`
+}
+
+// ComparisonResponse is a struct that represents vehicles side by side, the values of each
+// field in the order of ids
+type ComparisonResponse struct {
+	IDs    []int                   `json:"ids"`
+	Fields []ComparedFieldResponse `json:"fields"`
+}
+
+// ComparedFieldResponse is a struct that represents a field of the vehicles compared, a null
+// value being a metric that cannot be computed. Ranking is higher_is_better or lower_is_better
+// for the fields with a best and a worst vehicle.
+type ComparedFieldResponse struct {
+	Field   string `json:"field"`
+	Derived bool   `json:"derived"`
+	Values  []any  `json:"values"`
+	Ranking string `json:"ranking,omitempty"`
+	Best    []int  `json:"best,omitempty"`
+	Worst   []int  `json:"worst,omitempty"`
+}
+
+// rankings of the compared fields
+const (
+	RankingHigherIsBetter = "higher_is_better"
+	RankingLowerIsBetter  = "lower_is_better"
+)
+
+// Error codes
+const (
+	CodeBadRequest           = "bad_request"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodeInvalid              = "invalid_vehicle"
+	CodeInvalidMaintenance   = "invalid_maintenance"
+	CodeInvalidDriver        = "invalid_driver"
+	CodeLicenseNotAllowed    = "license_not_allowed"
+	CodeInvalidReservation   = "invalid_reservation"
+	CodeInvalidTrip          = "invalid_trip"
+	CodeInvalidFuel          = "invalid_fuel"
+	CodeFuelTypeMismatch     = "fuel_type_mismatch"
+	CodeInvalidTelemetry     = "invalid_telemetry"
+	CodeInvalidZone          = "invalid_zone"
+	CodeInvalidDocument      = "invalid_document"
+	CodeInvalidAttribute     = "invalid_attribute"
+	CodeTooLarge             = "too_large"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeTimeout              = "timeout"
+	CodeInternal             = "internal"
+)
+
+// NewError is a function that returns an error response
+func NewError(code, message string) Error {
+	return Error{Error: ErrorBody{Code: code, Message: message}}
+}
+
+// VehicleToResponse is a function that maps a vehicle to its response
+func VehicleToResponse(v internal.Vehicle) VehicleResponse {
+	return VehicleResponse{
+		ID:              v.Id,
+		Brand:           v.Brand,
+		Model:           v.Model,
+		Registration:    v.Registration,
+		Color:           v.Color,
+		FabricationYear: v.FabricationYear,
+		Capacity:        v.Capacity,
+		MaxSpeed:        v.MaxSpeed,
+		FuelType:        v.FuelType,
+		Transmission:    v.Transmission,
+		Weight:          v.Weight,
+		Height:          v.Height,
+		Length:          v.Length,
+		Width:           v.Width,
+		Tags:            v.Tags,
+		Custom:          v.Custom,
+	}
+}
+
+// ToAttributes is a method that maps the request to the attributes of a vehicle
+func (r VehicleRequest) ToAttributes() internal.VehicleAttributes {
+	return internal.VehicleAttributes{
+		Brand:           r.Brand,
+		Model:           r.Model,
+		Registration:    r.Registration,
+		Color:           r.Color,
+		FabricationYear: r.FabricationYear,
+		Capacity:        r.Capacity,
+		MaxSpeed:        r.MaxSpeed,
+		FuelType:        r.FuelType,
+		Transmission:    r.Transmission,
+		Weight:          r.Weight,
+		Dimensions: internal.Dimensions{
+			Height: r.Height,
+			Length: r.Length,
+			Width:  r.Width,
+		},
+		Tags:   internal.NormalizeTags(r.Tags),
+		Custom: r.Custom,
+	}
+}
+
+// ApplyTo is a method that overwrites the attributes present in the request
+func (r VehiclePatchRequest) ApplyTo(a *internal.VehicleAttributes) {
+	v1.VehiclePatchRequest{
+		Brand:           r.Brand,
+		Model:           r.Model,
+		Registration:    r.Registration,
+		Color:           r.Color,
+		FabricationYear: r.FabricationYear,
+		Capacity:        r.Capacity,
+		MaxSpeed:        r.MaxSpeed,
+		FuelType:        r.FuelType,
+		Transmission:    r.Transmission,
+		Weight:          r.Weight,
+		Height:          r.Height,
+		Length:          r.Length,
+		Width:           r.Width,
+	}.ApplyTo(a)
+	if r.Tags != nil {
+		a.Tags = internal.NormalizeTags(*r.Tags)
+	}
+	if len(r.Custom) > 0 {
+		// the vehicle may share its attributes with the repository, they are copied before the merge
+		custom := maps.Clone(a.Custom)
+		if custom == nil {
+			custom = make(map[string]any, len(r.Custom))
+		}
+		for name, value := range r.Custom {
+			if value == nil {
+				delete(custom, name)
+				continue
+			}
+			custom[name] = value
+		}
+		a.Custom = custom
+	}
+}
+
+// VehiclesToList is a function that maps the vehicles by id to a list sorted by id
+func VehiclesToList(v map[int]internal.Vehicle) List[VehicleResponse] {
+	data := make([]VehicleResponse, 0, len(v))
+	for _, value := range v {
+		data = append(data, VehicleToResponse(value))
+	}
+	sort.Slice(data, func(i, j int) bool { return data[i].ID < data[j].ID })
+	return List[VehicleResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// OrderedVehiclesToList is a function that maps vehicles to a list, in their order
+func OrderedVehiclesToList(v []internal.Vehicle) List[VehicleResponse] {
+	data := make([]VehicleResponse, 0, len(v))
+	for _, value := range v {
+		data = append(data, VehicleToResponse(value))
+	}
+	return List[VehicleResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// MatchesToList is a function that maps the vehicles found by a search to a list, in the order of relevance
+func MatchesToList(v []internal.VehicleMatch) List[MatchResponse] {
+	data := make([]MatchResponse, 0, len(v))
+	for _, value := range v {
+		data = append(data, MatchResponse{VehicleResponse: VehicleToResponse(value.Vehicle), Score: value.Score})
+	}
+	return List[MatchResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// BrandToResponse is a function that maps a brand of the catalog to its response
+func BrandToResponse(b internal.Brand) BrandResponse {
+	return BrandResponse{Name: b.Name, Aliases: b.Aliases, Models: b.Models}
+}
+
+// BrandsToList is a function that maps the brands of the catalog to a list, in their order
+func BrandsToList(b []internal.Brand) List[BrandResponse] {
+	data := make([]BrandResponse, 0, len(b))
+	for _, value := range b {
+		data = append(data, BrandToResponse(value))
+	}
+	return List[BrandResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// UnknownBrandsToList is a function that maps the brands missing from the catalog to a list, in their order
+func UnknownBrandsToList(u []internal.UnknownBrand) List[UnknownBrandResponse] {
+	data := make([]UnknownBrandResponse, 0, len(u))
+	for _, value := range u {
+		data = append(data, UnknownBrandResponse{Name: value.Name, Vehicles: value.Vehicles})
+	}
+	return List[UnknownBrandResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ComparisonToResponse is a function that maps a comparison of vehicles to its response
+func ComparisonToResponse(c internal.VehicleComparison) (r ComparisonResponse) {
+	r.IDs = make([]int, 0, len(c.Vehicles))
+	for _, vh := range c.Vehicles {
+		r.IDs = append(r.IDs, vh.Id)
+	}
+	r.Fields = make([]ComparedFieldResponse, 0, len(c.Fields))
+	for _, f := range c.Fields {
+		fr := ComparedFieldResponse{Field: f.Name, Derived: f.Derived, Values: make([]any, 0, len(r.IDs)), Best: f.Best, Worst: f.Worst}
+		for _, t := range f.Text {
+			fr.Values = append(fr.Values, t)
+		}
+		for _, n := range f.Numbers {
+			if math.IsNaN(n) {
+				fr.Values = append(fr.Values, nil)
+				continue
+			}
+			fr.Values = append(fr.Values, n)
+		}
+		if f.Ranked {
+			fr.Ranking = RankingLowerIsBetter
+			if f.HigherIsBetter {
+				fr.Ranking = RankingHigherIsBetter
+			}
+		}
+		r.Fields = append(r.Fields, fr)
+	}
+	return
+}
+
+// ComparisonToRecords is a function that maps a comparison of vehicles to CSV records: a header
+// with the ids, then a line per field with its values, the ids of the best and the worst
+// vehicles separated by spaces
+func ComparisonToRecords(c internal.VehicleComparison) (records [][]string) {
+	r := ComparisonToResponse(c)
+	header := []string{"field"}
+	for _, id := range r.IDs {
+		header = append(header, strconv.Itoa(id))
+	}
+	header = append(header, "ranking", "best", "worst")
+	records = append(records, header)
+
+	ids := func(v []int) string {
+		s := make([]string, 0, len(v))
+		for _, id := range v {
+			s = append(s, strconv.Itoa(id))
+		}
+		return strings.Join(s, " ")
+	}
+	for _, f := range r.Fields {
+		line := []string{f.Field}
+		for _, v := range f.Values {
+			switch v := v.(type) {
+			case string:
+				line = append(line, v)
+			case float64:
+				line = append(line, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				line = append(line, "")
+			}
+		}
+		line = append(line, f.Ranking, ids(f.Best), ids(f.Worst))
+		records = append(records, line)
+	}
+	return
+}
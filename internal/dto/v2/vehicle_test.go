@@ -0,0 +1,38 @@
+package v2
+
+import (
+	"app/internal"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestComparisonToRecords is a function that checks the layout of the CSV of a comparison: the
+// header with the ids, a line per field with its values, the missing metrics empty, and the
+// ranking with the ids of the best and the worst vehicles
+func TestComparisonToRecords(t *testing.T) {
+	c := internal.VehicleComparison{
+		Vehicles: []internal.Vehicle{{Id: 7}, {Id: 3}, {Id: 12}},
+		Fields: []internal.ComparedField{
+			{Name: "brand", Text: []string{"Fiat", "Ford, Inc", "Fiat"}},
+			{Name: "year", Numbers: []float64{2020, 2018, 2020}},
+			{Name: "max_speed", Numbers: []float64{200, 150, 200}, Ranked: true, HigherIsBetter: true, Best: []int{7, 12}, Worst: []int{3}},
+			{Name: "weight", Numbers: []float64{1000.5, 800, 1200}, Ranked: true, Best: []int{3}, Worst: []int{12}},
+			{Name: "volume", Derived: true, Numbers: []float64{12, math.NaN(), 8}, Ranked: true, HigherIsBetter: true, Best: []int{7}, Worst: []int{12}},
+		},
+	}
+
+	records := ComparisonToRecords(c)
+
+	want := [][]string{
+		{"field", "7", "3", "12", "ranking", "best", "worst"},
+		{"brand", "Fiat", "Ford, Inc", "Fiat", "", "", ""},
+		{"year", "2020", "2018", "2020", "", "", ""},
+		{"max_speed", "200", "150", "200", RankingHigherIsBetter, "7 12", "3"},
+		{"weight", "1000.5", "800", "1200", RankingLowerIsBetter, "3", "12"},
+		{"volume", "12", "", "8", RankingHigherIsBetter, "7", "12"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got records %q, want %q", records, want)
+	}
+}
@@ -0,0 +1,101 @@
+package v2
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"time"
+)
+
+// ZoneRequest is a struct that represents the body to add or replace a zone, its area a GeoJSON polygon
+type ZoneRequest struct {
+	Name string       `json:"name"`
+	Kind string       `json:"kind"`
+	Area geo.Geometry `json:"area"`
+}
+
+// ZoneResponse is a struct that represents a zone, its area a GeoJSON polygon
+type ZoneResponse struct {
+	ID   int          `json:"id"`
+	Name string       `json:"name"`
+	Kind string       `json:"kind"`
+	Area geo.Geometry `json:"area"`
+}
+
+// ZoneEventResponse is a struct that represents a vehicle entering or leaving a zone, type
+// enter or exit, at the position of the sample that detected it
+type ZoneEventResponse struct {
+	ID        int       `json:"id"`
+	ZoneID    int       `json:"zone_id"`
+	VehicleID int       `json:"vehicle_id"`
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+}
+
+// ZoneOccupantResponse is a struct that represents a vehicle inside a zone, at the position of its latest sample
+type ZoneOccupantResponse struct {
+	VehicleID int       `json:"vehicle_id"`
+	Since     time.Time `json:"since"`
+	LastSeen  time.Time `json:"last_seen"`
+	Latitude  float64   `json:"latitude"`
+	Longitude float64   `json:"longitude"`
+}
+
+// ToDomain is a method that maps the request to a zone
+func (r ZoneRequest) ToDomain() (z internal.Zone, err error) {
+	z = internal.Zone{Name: r.Name, Kind: r.Kind}
+	z.Area, err = r.Area.Polygon()
+	return
+}
+
+// ZoneToResponse is a function that maps a zone to its response
+func ZoneToResponse(z internal.Zone) ZoneResponse {
+	return ZoneResponse{ID: z.Id, Name: z.Name, Kind: z.Kind, Area: geo.PolygonGeometry(z.Area)}
+}
+
+// ZonesToList is a function that maps zones to a list, in their order
+func ZonesToList(z []internal.Zone) List[ZoneResponse] {
+	data := make([]ZoneResponse, 0, len(z))
+	for _, value := range z {
+		data = append(data, ZoneToResponse(value))
+	}
+	return List[ZoneResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ZoneEventToResponse is a function that maps a zone event to its response
+func ZoneEventToResponse(e internal.ZoneEvent) ZoneEventResponse {
+	return ZoneEventResponse{
+		ID:        e.Id,
+		ZoneID:    e.ZoneId,
+		VehicleID: e.VehicleId,
+		Type:      e.Type,
+		Time:      e.Time,
+		Latitude:  e.Position.Latitude,
+		Longitude: e.Position.Longitude,
+	}
+}
+
+// ZoneEventsToList is a function that maps zone events to a list, in their order
+func ZoneEventsToList(e []internal.ZoneEvent) List[ZoneEventResponse] {
+	data := make([]ZoneEventResponse, 0, len(e))
+	for _, value := range e {
+		data = append(data, ZoneEventToResponse(value))
+	}
+	return List[ZoneEventResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
+
+// ZoneOccupantsToList is a function that maps the vehicles inside a zone to a list, in their order
+func ZoneOccupantsToList(o []internal.ZoneOccupant) List[ZoneOccupantResponse] {
+	data := make([]ZoneOccupantResponse, 0, len(o))
+	for _, value := range o {
+		data = append(data, ZoneOccupantResponse{
+			VehicleID: value.VehicleId,
+			Since:     value.Since,
+			LastSeen:  value.LastSeen,
+			Latitude:  value.Position.Latitude,
+			Longitude: value.Position.Longitude,
+		})
+	}
+	return List[ZoneOccupantResponse]{Data: data, Meta: Meta{Total: len(data)}}
+}
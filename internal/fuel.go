@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// FuelElectric is the fuel type of the electric vehicles, charged in kWh
+const FuelElectric = "electric"
+
+// units of the fuel transactions and of the consumption
+const (
+	// FuelLiters is the unit of the liquid and gaseous fuels
+	FuelLiters = "l"
+	// FuelKWh is the unit of the charges of the electric vehicles
+	FuelKWh = "kWh"
+	// ConsumptionKmPerLiter is the consumption of the fuels, the higher the better
+	ConsumptionKmPerLiter = "km/l"
+	// ConsumptionKWhPer100Km is the consumption of the electric vehicles, the lower the better
+	ConsumptionKWhPer100Km = "kWh/100km"
+)
+
+// consumption flags
+const (
+	// ConsumptionHigh is a consumption worse than the normal band of the vehicle, possible fuel fraud
+	ConsumptionHigh = "high_consumption"
+	// ConsumptionLow is a consumption better than the normal band of the vehicle, possibly a wrong odometer reading
+	ConsumptionLow = "low_consumption"
+)
+
+// FuelUnit is a function that returns the unit of the transactions of a fuel type,
+// FuelKWh for FuelElectric and FuelLiters otherwise
+func FuelUnit(fuelType string) string {
+	if strings.EqualFold(strings.TrimSpace(fuelType), FuelElectric) {
+		return FuelKWh
+	}
+	return FuelLiters
+}
+
+// FuelTransaction is a struct that represents a refuel or a charge of a vehicle, the tank or the
+// battery filled up to compute the consumption since the previous one
+type FuelTransaction struct {
+	// Id is the unique identifier of the transaction
+	Id int
+	// VehicleId is the identifier of the vehicle refueled
+	VehicleId int
+	// Time is when the vehicle was refueled
+	Time time.Time
+	// FuelType is the fuel type of the vehicle at the transaction
+	FuelType string
+	// Quantity is the fuel added, in FuelUnit of the fuel type
+	Quantity float64
+	// Cost is the cost of the transaction
+	Cost float64
+	// Station is the fuel or charging station
+	Station string
+	// Odometer is the distance traveled by the vehicle at the transaction, in km
+	Odometer float64
+}
+
+// Validate is a method that validates a transaction to be recorded
+func (t *FuelTransaction) Validate() error {
+	if t.Time.IsZero() {
+		return errors.New("time is required")
+	}
+	if t.FuelType == "" {
+		return errors.New("fuel_type is required")
+	}
+	if t.Quantity <= 0 {
+		return errors.New("quantity must be positive")
+	}
+	if t.Cost < 0 {
+		return errors.New("cost must not be negative")
+	}
+	if t.Odometer < 0 {
+		return errors.New("odometer must not be negative")
+	}
+	return nil
+}
+
+// Consumption is a struct that represents the consumption of a vehicle between a transaction and
+// the previous one of the same fuel type
+type Consumption struct {
+	// Transaction is the transaction that refilled the fuel consumed
+	Transaction FuelTransaction
+	// Distance is the km driven since the previous transaction
+	Distance float64
+	// Value is the consumption, in Unit
+	Value float64
+	// Unit is ConsumptionKmPerLiter or ConsumptionKWhPer100Km
+	Unit string
+	// Flag is ConsumptionHigh or ConsumptionLow outside the normal band of the vehicle, empty inside
+	Flag string
+}
+
+// ConsumptionReport is a struct that represents the consumption of the current fuel type of a vehicle
+type ConsumptionReport struct {
+	// VehicleId is the identifier of the vehicle
+	VehicleId int
+	// FuelType is the current fuel type of the vehicle
+	FuelType string
+	// Unit is the unit of the consumption of the fuel type
+	Unit string
+	// Distance, Quantity and Cost are the sums of the consumptions measured
+	Distance float64
+	Quantity float64
+	Cost     float64
+	// Average is the consumption over the whole Distance, 0 without consumption measured
+	Average float64
+	// Median is the median consumption, Low and High the normal band around it, all 0 with
+	// too few consumptions to tell
+	Median float64
+	Low    float64
+	High   float64
+	// Consumptions are the consumptions measured, the oldest first
+	Consumptions []Consumption
+}
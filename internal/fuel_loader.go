@@ -0,0 +1,7 @@
+package internal
+
+// FuelLoader is an interface that represents the loader for the fuel transactions of the vehicles
+type FuelLoader interface {
+	// Load is a method that loads the fuel transactions
+	Load() (t []FuelTransaction, err error)
+}
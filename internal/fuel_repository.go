@@ -0,0 +1,16 @@
+package internal
+
+import "context"
+
+// FuelRepository is an interface that represents a repository of the fuel transactions
+type FuelRepository interface {
+	// FindAll is a method that returns the transactions of a vehicle, of every vehicle for 0,
+	// the oldest first
+	FindAll(ctx context.Context, vehicleId int) (t []FuelTransaction, err error)
+	// Save is a method that records a transaction, assigning its id, ErrOdometerDecreased when
+	// its odometer is lower than the one of an earlier transaction of the vehicle or higher
+	// than the one of a later transaction
+	Save(ctx context.Context, t *FuelTransaction) (v FuelTransaction, err error)
+	// Delete is a method that removes a transaction of a vehicle, ErrFuelTransactionNotFound when there is none
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+}
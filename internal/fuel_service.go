@@ -0,0 +1,21 @@
+package internal
+
+import "context"
+
+// FuelService is an interface that represents the service of the fuel transactions and the
+// consumption of the vehicles
+type FuelService interface {
+	// FindAll is a method that returns the transactions of a vehicle, the oldest first
+	FindAll(ctx context.Context, vehicleId int) (t []FuelTransaction, err error)
+	// Record is a method that records a transaction of a vehicle, ErrFuelTypeMismatch when its
+	// fuel type is not the current one of the vehicle
+	Record(ctx context.Context, t *FuelTransaction) (v FuelTransaction, err error)
+	// Delete is a method that removes a transaction of a vehicle
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+	// Consumption is a method that returns the consumption of the current fuel type of a vehicle,
+	// the normal band within tolerance, a share of the median, from it
+	Consumption(ctx context.Context, vehicleId int, tolerance float64) (r ConsumptionReport, err error)
+	// Anomalies is a method that returns the consumptions of the fleet outside the normal band of
+	// their vehicle, the most recent first
+	Anomalies(ctx context.Context, tolerance float64) (c []Consumption, err error)
+}
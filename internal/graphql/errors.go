@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+)
+
+// codes of the "extensions.code" of the GraphQL errors
+const (
+	CodeBadRequest = "BAD_REQUEST"
+	CodeNotFound   = "NOT_FOUND"
+	CodeConflict   = "CONFLICT"
+	CodeInvalid    = "INVALID"
+	CodeTimeout    = "TIMEOUT"
+	CodeCanceled   = "CANCELED"
+	CodeInternal   = "INTERNAL"
+	CodeTooComplex = "QUERY_TOO_COMPLEX"
+)
+
+// Error is a struct that represents an error of a resolver, reported with its code
+// in the "extensions" of the GraphQL error
+type Error struct {
+	// Code is the machine readable code of the error
+	Code string
+	// Message is the human readable message of the error
+	Message string
+}
+
+// Error is a method that returns the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions is a method that returns the extensions of the GraphQL error
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+// newError is a function that returns a new Error
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// serviceError is a function that maps an error of the service to an Error
+func serviceError(err error) *Error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return newError(CodeCanceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return newError(CodeTimeout, "request timed out")
+	case service.IsNotFound(err):
+		return newError(CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrVehicleAlreadyExists):
+		return newError(CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidVehicleData):
+		return newError(CodeInvalid, err.Error())
+	default:
+		return newError(CodeInternal, "internal error")
+	}
+}
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"app/pkg/logger"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Request is a struct that represents the body of a GraphQL request
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Handler is a function that returns the handler of GET and POST /graphql,
+// rejecting the queries beyond limits before executing them.
+// GET only executes queries, mutations must be sent with POST.
+func Handler(schema gql.Schema, limits Limits) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			req.Query = q.Get("query")
+			req.OperationName = q.Get("operationName")
+			if vars := q.Get("variables"); vars != "" {
+				if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+					writeError(w, http.StatusBadRequest, newError(CodeBadRequest, "malformed variables: "+err.Error()))
+					return
+				}
+			}
+		default:
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				writeError(w, http.StatusBadRequest, newError(CodeBadRequest, "malformed body: "+err.Error()))
+				return
+			}
+		}
+		if req.Query == "" {
+			writeError(w, http.StatusBadRequest, newError(CodeBadRequest, "query is required"))
+			return
+		}
+
+		doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, &gql.Result{Errors: gqlerrors.FormatErrors(err)})
+			return
+		}
+		if r.Method == http.MethodGet && hasMutation(doc) {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, newError(CodeBadRequest, "mutations must be sent with POST"))
+			return
+		}
+		if err := limits.Check(doc, req.Variables); err != nil {
+			logger.FromContext(r.Context()).Info("graphql: query rejected", slog.String("error", err.Error()))
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		result := gql.Do(gql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			VariableValues: req.Variables,
+			OperationName:  req.OperationName,
+			Context:        r.Context(),
+		})
+		if result.HasErrors() {
+			logger.FromContext(r.Context()).Debug("graphql: result with errors", slog.Any("errors", result.Errors))
+		}
+
+		response.JSON(w, http.StatusOK, result)
+	}
+}
+
+// hasMutation is a function that reports whether doc defines a mutation
+func hasMutation(doc *ast.Document) bool {
+	for _, def := range doc.Definitions {
+		if op, ok := def.(*ast.OperationDefinition); ok && op.Operation == ast.OperationTypeMutation {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError is a function that writes a GraphQL response with the single error err,
+// with its code when it is an Error
+func writeError(w http.ResponseWriter, status int, err error) {
+	formatted := gqlerrors.FormatError(err)
+	var e *Error
+	if errors.As(err, &e) {
+		formatted.Extensions = e.Extensions()
+	}
+	response.JSON(w, status, &gql.Result{Errors: []gqlerrors.FormattedError{formatted}})
+}
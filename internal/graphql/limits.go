@@ -0,0 +1,159 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Limits is a struct that represents the limits a query must respect to be executed
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed
+	MaxDepth int
+	// MaxComplexity is the highest complexity allowed, every field costs 1 and
+	// the fields below a paginated field are multiplied by its limit
+	MaxComplexity int
+}
+
+// Check is a method that returns an Error when an operation of doc exceeds the limits,
+// the introspection fields are not counted.
+// Fragments spreading themselves are rejected here, the validation of graphql-go
+// overflows the stack on them.
+func (l Limits) Check(doc *ast.Document, variables map[string]any) (err error) {
+	w := walker{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+		maxDepth:  l.MaxDepth,
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			w.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for name, fragment := range w.fragments {
+		w.selectionSet(fragment.SelectionSet, 1, []string{name})
+		if w.cycle != "" {
+			err = newError(CodeBadRequest, fmt.Sprintf("fragment %s spreads itself", w.cycle))
+			return
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		depth, complexity := w.selectionSet(op.SelectionSet, 1, nil)
+		if l.MaxDepth > 0 && depth > l.MaxDepth {
+			err = newError(CodeTooComplex, fmt.Sprintf("query depth exceeds the limit of %d", l.MaxDepth))
+			return
+		}
+		if l.MaxComplexity > 0 && complexity > l.MaxComplexity {
+			err = newError(CodeTooComplex, fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity))
+			return
+		}
+	}
+	return
+}
+
+// walker is a struct that measures the depth and complexity of the selection sets of a document
+type walker struct {
+	// fragments are the fragment definitions of the document by name
+	fragments map[string]*ast.FragmentDefinition
+	// variables are the values of the variables of the request
+	variables map[string]any
+	// maxDepth stops the walk of the selections deeper than the limit
+	maxDepth int
+	// cycle is the name of the first fragment found spreading itself
+	cycle string
+}
+
+// selectionSet is a method that returns the depth and complexity of set at level,
+// spreading is the chain of fragments being spread
+func (w *walker) selectionSet(set *ast.SelectionSet, level int, spreading []string) (depth, complexity int) {
+	if set == nil {
+		return
+	}
+	if w.maxDepth > 0 && level > w.maxDepth+1 {
+		depth = level
+		return
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			d, c = w.selectionSet(s.SelectionSet, level+1, spreading)
+			d = max(d, level)
+			c = 1 + c*w.multiplier(s)
+		case *ast.InlineFragment:
+			d, c = w.selectionSet(s.SelectionSet, level, spreading)
+		case *ast.FragmentSpread:
+			name := s.Name.Value
+			fragment, ok := w.fragments[name]
+			if !ok {
+				continue
+			}
+			if contains(spreading, name) {
+				if w.cycle == "" {
+					w.cycle = name
+				}
+				continue
+			}
+			d, c = w.selectionSet(fragment.SelectionSet, level, append(spreading, name))
+		}
+		depth = max(depth, d)
+		complexity += c
+	}
+	return
+}
+
+// multiplier is a method that returns how many times the selection of field is resolved,
+// its "limit" argument up to MaxLimit or the default page size for the paginated fields and 1 otherwise
+func (w *walker) multiplier(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		var n float64
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.ParseFloat(v.Value, 64)
+		case *ast.Variable:
+			switch value := w.variables[v.Name.Value].(type) {
+			case float64:
+				n = value
+			case int:
+				n = float64(value)
+			}
+		}
+		// the limits the resolver serves empty or rejects count as the default page size
+		if !(n > 0) {
+			return DefaultLimit
+		}
+		return int(math.Ceil(min(n, MaxLimit)))
+	}
+
+	if field.Name.Value == "vehicles" {
+		return DefaultLimit
+	}
+	return 1
+}
+
+// contains is a function that reports whether s has name
+func contains(s []string, name string) bool {
+	for _, v := range s {
+		if v == name {
+			return true
+		}
+	}
+	return false
+}
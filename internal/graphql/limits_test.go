@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// TestLimits_Check is a function that checks the depth and complexity limits, the limits of the
+// pages counted up to MaxLimit and as the default page size when out of range, and the rejection
+// of the fragments spreading themselves
+func TestLimits_Check(t *testing.T) {
+	// the complexity of a page is 1 plus 4 by vehicle: items, id, brand and total
+	const page = `{ items { id brand } total }`
+
+	cases := map[string]struct {
+		query     string
+		variables map[string]any
+		limits    Limits
+		wantCode  string
+	}{
+		"depth at the limit": {
+			query:  `{ vehicles { items { dimensions { length } } } }`,
+			limits: Limits{MaxDepth: 4},
+		},
+		"depth over the limit": {
+			query:    `{ vehicles { items { dimensions { length } } } }`,
+			limits:   Limits{MaxDepth: 3},
+			wantCode: CodeTooComplex,
+		},
+		"depth over the limit in a fragment": {
+			query:    `{ vehicles { ...items } } fragment items on VehiclePage { items { dimensions { length } } }`,
+			limits:   Limits{MaxDepth: 3},
+			wantCode: CodeTooComplex,
+		},
+		"literal limit at the complexity limit": {
+			query:  `{ vehicles(limit: 5) ` + page + ` }`,
+			limits: Limits{MaxComplexity: 21},
+		},
+		"literal limit over the complexity limit": {
+			query:    `{ vehicles(limit: 5) ` + page + ` }`,
+			limits:   Limits{MaxComplexity: 20},
+			wantCode: CodeTooComplex,
+		},
+		"absent limit counted as the default page size": {
+			query:    `{ vehicles ` + page + ` }`,
+			limits:   Limits{MaxComplexity: 1 + 4*DefaultLimit - 1},
+			wantCode: CodeTooComplex,
+		},
+		"zero limit counted as the default page size": {
+			query:    `{ vehicles(limit: 0) ` + page + ` }`,
+			limits:   Limits{MaxComplexity: 1 + 4*DefaultLimit - 1},
+			wantCode: CodeTooComplex,
+		},
+		"negative limit counted as the default page size": {
+			query:    `{ vehicles(limit: -3) ` + page + ` }`,
+			limits:   Limits{MaxComplexity: 1 + 4*DefaultLimit - 1},
+			wantCode: CodeTooComplex,
+		},
+		"literal limit counted up to the largest page": {
+			query:  `{ vehicles(limit: 100000) ` + page + ` }`,
+			limits: Limits{MaxComplexity: 1 + 4*MaxLimit},
+		},
+		"variable limit counted up to the largest page": {
+			query:     `query($limit: Int) { vehicles(limit: $limit) ` + page + ` }`,
+			variables: map[string]any{"limit": 1e300},
+			limits:    Limits{MaxComplexity: 1 + 4*MaxLimit},
+		},
+		"variable limit over the complexity limit": {
+			query:     `query($limit: Int) { vehicles(limit: $limit) ` + page + ` }`,
+			variables: map[string]any{"limit": float64(MaxLimit)},
+			limits:    Limits{MaxComplexity: 4 * MaxLimit},
+			wantCode:  CodeTooComplex,
+		},
+		"variable limit not a number counted as the default page size": {
+			query:     `query($limit: Int) { vehicles(limit: $limit) ` + page + ` }`,
+			variables: map[string]any{"limit": "many"},
+			limits:    Limits{MaxComplexity: 1 + 4*DefaultLimit},
+		},
+		"variable limit absent counted as the default page size": {
+			query:    `query($limit: Int) { vehicles(limit: $limit) ` + page + ` }`,
+			limits:   Limits{MaxComplexity: 1 + 4*DefaultLimit - 1},
+			wantCode: CodeTooComplex,
+		},
+		"introspection not counted": {
+			query:  `{ __schema { types { name fields { name } } } }`,
+			limits: Limits{MaxDepth: 1, MaxComplexity: 1},
+		},
+		"fragment spreading itself": {
+			query:    `{ vehicles { ...a } } fragment a on VehiclePage { total ...a }`,
+			wantCode: CodeBadRequest,
+		},
+		"fragments spreading each other": {
+			query: `{ vehicles { ...a } } fragment a on VehiclePage { total ...b } ` +
+				`fragment b on VehiclePage { offset ...a }`,
+			wantCode: CodeBadRequest,
+		},
+		"fragment spread twice": {
+			query: `{ vehicles { ...a ...b } } fragment a on VehiclePage { total ...b } ` +
+				`fragment b on VehiclePage { offset }`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(c.query)})})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = c.limits.Check(doc, c.variables)
+
+			if c.wantCode == "" {
+				if err != nil {
+					t.Fatalf("got error %v, want none", err)
+				}
+				return
+			}
+			var e *Error
+			if !errors.As(err, &e) || e.Code != c.wantCode {
+				t.Fatalf("got error %v, want code %s", err, c.wantCode)
+			}
+		})
+	}
+}
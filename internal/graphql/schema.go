@@ -0,0 +1,384 @@
+package graphql
+
+import (
+	"app/internal"
+	"app/internal/service"
+	"context"
+	"maps"
+	"math"
+	"sort"
+	"strconv"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+const (
+	// DefaultLimit is the page size of the vehicle lists when no limit is informed
+	DefaultLimit = 20
+	// MaxLimit is the largest page size accepted by the vehicle lists
+	MaxLimit = 100
+)
+
+// NewSchema is a function that returns the GraphQL schema of the fleet, resolved by sv
+func NewSchema(sv internal.VehicleService) (s gql.Schema, err error) {
+	r := &resolver{sv: sv}
+
+	s, err = gql.NewSchema(gql.SchemaConfig{
+		Query: gql.NewObject(gql.ObjectConfig{
+			Name: "Query",
+			Fields: gql.Fields{
+				"vehicle": &gql.Field{
+					Type:        vehicleType,
+					Description: "The vehicle with the id, null when it does not exist",
+					Args: gql.FieldConfigArgument{
+						"id": {Type: gql.NewNonNull(gql.Int)},
+					},
+					Resolve: r.vehicle,
+				},
+				"vehicles": &gql.Field{
+					Type:        gql.NewNonNull(vehiclePageType),
+					Description: "A page of the vehicles matching every informed filter, ordered by id",
+					Args: gql.FieldConfigArgument{
+						"filter": {Type: vehicleFilterInput},
+						"offset": {Type: gql.Int, DefaultValue: 0},
+						"limit":  {Type: gql.Int, DefaultValue: DefaultLimit},
+					},
+					Resolve: r.vehicles,
+				},
+				"brandStats": &gql.Field{
+					Type:        brandStatsType,
+					Description: "The aggregates of the vehicles of the brand, null when the brand has no vehicles",
+					Args: gql.FieldConfigArgument{
+						"brand": {Type: gql.NewNonNull(gql.String)},
+					},
+					Resolve: r.brandStats,
+				},
+			},
+		}),
+		Mutation: gql.NewObject(gql.ObjectConfig{
+			Name: "Mutation",
+			Fields: gql.Fields{
+				"createVehicle": &gql.Field{
+					Type:        gql.NewNonNull(vehicleType),
+					Description: "Creates a vehicle, the registration must be unique",
+					Args: gql.FieldConfigArgument{
+						"input": {Type: gql.NewNonNull(vehicleInput)},
+					},
+					Resolve: r.createVehicle,
+				},
+				"patchVehicle": &gql.Field{
+					Type:        gql.NewNonNull(vehicleType),
+					Description: "Updates the informed attributes of a vehicle, the absent ones are kept",
+					Args: gql.FieldConfigArgument{
+						"id":    {Type: gql.NewNonNull(gql.Int)},
+						"input": {Type: gql.NewNonNull(vehiclePatchInput)},
+					},
+					Resolve: r.patchVehicle,
+				},
+				"updateFuel": &gql.Field{
+					Type:        gql.NewNonNull(vehicleType),
+					Description: "Updates the fuel type of a vehicle",
+					Args: gql.FieldConfigArgument{
+						"id":       {Type: gql.NewNonNull(gql.Int)},
+						"fuelType": {Type: gql.NewNonNull(gql.String)},
+					},
+					Resolve: r.updateFuel,
+				},
+				"deleteVehicle": &gql.Field{
+					Type:        gql.NewNonNull(gql.Boolean),
+					Description: "Deletes a vehicle",
+					Args: gql.FieldConfigArgument{
+						"id": {Type: gql.NewNonNull(gql.Int)},
+					},
+					Resolve: r.deleteVehicle,
+				},
+			},
+		}),
+	})
+	return
+}
+
+// page is a struct that represents a page of vehicles
+type page struct {
+	Items  []internal.Vehicle
+	Total  int
+	Offset int
+	Limit  int
+}
+
+// brandStats is a struct that represents the aggregates of the vehicles of a brand
+type brandStats struct {
+	Brand           string
+	AverageSpeed    float64
+	AverageCapacity int
+}
+
+// resolver is a struct with the resolvers of the root fields, backed by the vehicle service
+type resolver struct {
+	// sv is the service that will be used by the resolvers
+	sv internal.VehicleService
+}
+
+// vehicle is a method that resolves Query.vehicle
+func (r *resolver) vehicle(p gql.ResolveParams) (v any, err error) {
+	vh, err := r.sv.FindById(p.Context, strconv.Itoa(p.Args["id"].(int)))
+	if err != nil {
+		if service.IsNotFound(err) {
+			err = nil
+			return
+		}
+		err = serviceError(err)
+		return
+	}
+	if vh.Id == 0 {
+		return
+	}
+
+	v = vh
+	return
+}
+
+// vehicles is a method that resolves Query.vehicles, each filter narrows the result of the previous
+func (r *resolver) vehicles(p gql.ResolveParams) (v any, err error) {
+	offset, limit := p.Args["offset"].(int), p.Args["limit"].(int)
+	if offset < 0 {
+		err = newError(CodeBadRequest, "offset must not be negative")
+		return
+	}
+	if limit < 0 || limit > MaxLimit {
+		err = newError(CodeBadRequest, "limit must be between 0 and "+strconv.Itoa(MaxLimit))
+		return
+	}
+
+	filter, err := vehicleFilter(p.Args["filter"])
+	if err != nil {
+		return
+	}
+
+	found, err := service.FindByFilter(p.Context, r.sv, filter)
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	items := make([]internal.Vehicle, 0, len(found))
+	for _, vh := range found {
+		items = append(items, vh)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Id < items[j].Id })
+
+	pg := page{Total: len(items), Offset: offset, Limit: limit}
+	if offset < len(items) {
+		pg.Items = items[offset:min(offset+limit, len(items))]
+	}
+	v = pg
+	return
+}
+
+// vehicleFilter is a function that converts the VehicleFilter input to the filter of the service
+func vehicleFilter(arg any) (f service.VehicleFilter, err error) {
+	in, _ := arg.(map[string]any)
+
+	set(&f.Color, in["color"])
+	if year, ok := in["year"].(int); ok {
+		f.Year = &year
+	}
+	if (f.Color != "") != (f.Year != nil) {
+		err = newError(CodeBadRequest, "color and year must be informed together")
+		return
+	}
+	set(&f.Brand, in["brand"])
+	if year, ok := in["yearFrom"].(int); ok {
+		f.YearFrom = &year
+	}
+	if year, ok := in["yearTo"].(int); ok {
+		f.YearTo = &year
+	}
+	set(&f.FuelType, in["fuelType"])
+	set(&f.Transmission, in["transmission"])
+	if length, ok := in["length"].(map[string]any); ok {
+		f.Length = rangeOf(length)
+	}
+	if width, ok := in["width"].(map[string]any); ok {
+		f.Width = rangeOf(width)
+	}
+	if (f.Length != nil && (f.Length.Min < 0 || f.Length.Max < 0)) ||
+		(f.Width != nil && (f.Width.Min < 0 || f.Width.Max < 0)) {
+		err = newError(CodeBadRequest, "dimensions must not be negative")
+		return
+	}
+	if weight, ok := in["weight"].(map[string]any); ok {
+		r := rangeOf(weight)
+		f.WeightMin, f.WeightMax = &r.Min, &r.Max
+	}
+	return
+}
+
+// brandStats is a method that resolves Query.brandStats
+func (r *resolver) brandStats(p gql.ResolveParams) (v any, err error) {
+	brand := p.Args["brand"].(string)
+
+	speed, err := r.sv.FindVelocidadeMediaMarca(p.Context, brand)
+	if err != nil {
+		if service.IsNotFound(err) {
+			err = nil
+			return
+		}
+		err = serviceError(err)
+		return
+	}
+	capacity, err := r.sv.FindMediaPessoaPorMarca(p.Context, brand)
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	v = brandStats{Brand: brand, AverageSpeed: speed, AverageCapacity: capacity}
+	return
+}
+
+// createVehicle is a method that resolves Mutation.createVehicle
+func (r *resolver) createVehicle(p gql.ResolveParams) (v any, err error) {
+	var attrs internal.VehicleAttributes
+	applyInput(&attrs, p.Args["input"].(map[string]any))
+	if err = attrs.Validate(); err != nil {
+		err = newError(CodeInvalid, err.Error())
+		return
+	}
+
+	vh, err := r.sv.Save(p.Context, &attrs)
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	v = vh
+	return
+}
+
+// patchVehicle is a method that resolves Mutation.patchVehicle
+func (r *resolver) patchVehicle(p gql.ResolveParams) (v any, err error) {
+	vh, err := r.find(p.Context, p.Args["id"].(int))
+	if err != nil {
+		return
+	}
+
+	applyInput(&vh.VehicleAttributes, p.Args["input"].(map[string]any))
+	if err = vh.Validate(); err != nil {
+		err = newError(CodeInvalid, err.Error())
+		return
+	}
+
+	vh, err = r.sv.Patch(p.Context, &vh)
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	v = vh
+	return
+}
+
+// updateFuel is a method that resolves Mutation.updateFuel
+func (r *resolver) updateFuel(p gql.ResolveParams) (v any, err error) {
+	id := p.Args["id"].(int)
+	if _, err = r.find(p.Context, id); err != nil {
+		return
+	}
+
+	vh, err := r.sv.UpdateFuel(p.Context, id, p.Args["fuelType"].(string))
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	v = vh
+	return
+}
+
+// deleteVehicle is a method that resolves Mutation.deleteVehicle
+func (r *resolver) deleteVehicle(p gql.ResolveParams) (v any, err error) {
+	id := p.Args["id"].(int)
+	if _, err = r.find(p.Context, id); err != nil {
+		return
+	}
+
+	if err = r.sv.DeleteById(p.Context, strconv.Itoa(id)); err != nil {
+		err = serviceError(err)
+		return
+	}
+
+	v = true
+	return
+}
+
+// find is a method that returns the vehicle with the id, or a not found error
+func (r *resolver) find(ctx context.Context, id int) (vh internal.Vehicle, err error) {
+	vh, err = r.sv.FindById(ctx, strconv.Itoa(id))
+	if err != nil {
+		err = serviceError(err)
+		return
+	}
+	if vh.Id == 0 {
+		err = newError(CodeNotFound, "vehicle not found")
+	}
+	return
+}
+
+// applyInput is a function that copies the fields present in a VehicleInput or
+// VehiclePatchInput argument to attrs
+func applyInput(attrs *internal.VehicleAttributes, in map[string]any) {
+	set(&attrs.Brand, in["brand"])
+	set(&attrs.Model, in["model"])
+	set(&attrs.Registration, in["registration"])
+	set(&attrs.Color, in["color"])
+	set(&attrs.FabricationYear, in["year"])
+	set(&attrs.Capacity, in["passengers"])
+	set(&attrs.MaxSpeed, in["maxSpeed"])
+	set(&attrs.FuelType, in["fuelType"])
+	set(&attrs.Transmission, in["transmission"])
+	set(&attrs.Weight, in["weight"])
+	set(&attrs.Height, in["height"])
+	set(&attrs.Length, in["length"])
+	set(&attrs.Width, in["width"])
+	if tags, ok := in["tags"].([]any); ok {
+		attrs.Tags = make([]string, 0, len(tags))
+		for _, t := range tags {
+			if tag, ok := t.(string); ok {
+				attrs.Tags = append(attrs.Tags, tag)
+			}
+		}
+		attrs.Tags = internal.NormalizeTags(attrs.Tags)
+	}
+	// custom attributes are merged, a null value removing one
+	if custom, ok := in["custom"].(map[string]any); ok {
+		merged := maps.Clone(attrs.Custom)
+		if merged == nil {
+			merged = make(map[string]any, len(custom))
+		}
+		for name, value := range custom {
+			if value == nil {
+				delete(merged, name)
+				continue
+			}
+			merged[name] = value
+		}
+		attrs.Custom = merged
+	}
+}
+
+// set is a function that assigns value to dst when it is present and of the type of dst
+func set[T any](dst *T, value any) {
+	if v, ok := value.(T); ok {
+		*dst = v
+	}
+}
+
+// rangeOf is a function that converts a RangeInput, open ends default to zero and to
+// the largest float
+func rangeOf(in map[string]any) *service.Range {
+	r := &service.Range{Min: 0, Max: math.MaxFloat64}
+	set(&r.Min, in["min"])
+	set(&r.Max, in["max"])
+	return r
+}
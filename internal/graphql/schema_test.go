@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"app/internal"
+	"app/internal/catalog"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/pkg/tenant"
+	"context"
+	"encoding/json"
+	"testing"
+
+	gql "github.com/graphql-go/graphql"
+)
+
+// TestSchema_CustomAttributes is a function that checks that the mutations carry the tags and
+// custom attributes, a tenant requiring an attribute rejecting the vehicles without it
+func TestSchema_CustomAttributes(t *testing.T) {
+	const vehicle = `brand: "Fiat", model: "Uno", color: "Red", year: 2020, passengers: 5, maxSpeed: 150, ` +
+		`fuelType: "gasoline", transmission: "manual", weight: 900, height: 1.5, length: 3.8, width: 1.6`
+
+	rp := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Fiat", Model: "Uno", Registration: "ABC1D23", Color: "Red", FabricationYear: 2020,
+			Capacity: 5, MaxSpeed: 150, FuelType: "gasoline", Transmission: "manual", Weight: 900,
+			Dimensions: internal.Dimensions{Length: 3.8, Width: 1.6, Height: 1.5},
+		}},
+	})
+	ct, err := catalog.NewBrandMap(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := repository.NewAttributeMap([]internal.AttributeDefinition{
+		{Tenant: tenant.Default, Name: "cost_center", Type: internal.AttributeString, Required: true},
+	})
+	schema, err := NewSchema(service.NewVehicleDefault(rp, ct, nil, attributes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		query     string
+		variables map[string]any
+		wantErr   bool
+		wantData  string
+	}{
+		"create without the required attribute": {
+			query:   `mutation { createVehicle(input: {` + vehicle + `, registration: "NEW1A11"}) { id } }`,
+			wantErr: true,
+		},
+		"create with the required attribute": {
+			query: `mutation { createVehicle(input: {` + vehicle + `, registration: "NEW1A12", ` +
+				`tags: ["Airport", "night"], custom: {cost_center: "CC-12"}}) { tags custom } }`,
+			wantData: `{"createVehicle":{"custom":{"cost_center":"CC-12"},"tags":["airport","night"]}}`,
+		},
+		"patch without the required attribute": {
+			query:   `mutation { patchVehicle(id: 1, input: {color: "Blue"}) { id } }`,
+			wantErr: true,
+		},
+		"patch with the required attribute": {
+			query:     `mutation($custom: JSON) { patchVehicle(id: 1, input: {tags: ["depot"], custom: $custom}) { tags custom } }`,
+			variables: map[string]any{"custom": map[string]any{"cost_center": "CC-12"}},
+			wantData:  `{"patchVehicle":{"custom":{"cost_center":"CC-12"},"tags":["depot"]}}`,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res := gql.Do(gql.Params{
+				Schema:         schema,
+				RequestString:  c.query,
+				VariableValues: c.variables,
+				Context:        context.Background(),
+			})
+
+			if c.wantErr {
+				if len(res.Errors) == 0 {
+					t.Fatalf("got data %v, want an error", res.Data)
+				}
+				return
+			}
+			if len(res.Errors) > 0 {
+				t.Fatalf("got errors %v", res.Errors)
+			}
+			data, err := json.Marshal(res.Data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != c.wantData {
+				t.Errorf("got data %s, want %s", data, c.wantData)
+			}
+		})
+	}
+}
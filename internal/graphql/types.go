@@ -0,0 +1,228 @@
+package graphql
+
+import (
+	"app/internal"
+	"strconv"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// jsonType is the GraphQL scalar of any JSON value, the custom attributes of a vehicle by name
+var jsonType = gql.NewScalar(gql.ScalarConfig{
+	Name:         "JSON",
+	Description:  "Any JSON value, the numbers read as floats",
+	Serialize:    func(value any) any { return value },
+	ParseValue:   func(value any) any { return value },
+	ParseLiteral: jsonLiteral,
+})
+
+// dimensionsType is the GraphQL type of internal.Dimensions
+var dimensionsType = gql.NewObject(gql.ObjectConfig{
+	Name: "Dimensions",
+	Fields: gql.Fields{
+		"height": vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Height }),
+		"length": vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Length }),
+		"width":  vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Width }),
+	},
+})
+
+// vehicleType is the GraphQL type of internal.Vehicle
+var vehicleType = gql.NewObject(gql.ObjectConfig{
+	Name: "Vehicle",
+	Fields: gql.Fields{
+		"id":           vehicleField(gql.Int, func(v internal.Vehicle) any { return v.Id }),
+		"brand":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Brand }),
+		"model":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Model }),
+		"registration": vehicleField(gql.String, func(v internal.Vehicle) any { return v.Registration }),
+		"color":        vehicleField(gql.String, func(v internal.Vehicle) any { return v.Color }),
+		"year":         vehicleField(gql.Int, func(v internal.Vehicle) any { return v.FabricationYear }),
+		"passengers":   vehicleField(gql.Int, func(v internal.Vehicle) any { return v.Capacity }),
+		"maxSpeed":     vehicleField(gql.Float, func(v internal.Vehicle) any { return v.MaxSpeed }),
+		"fuelType":     vehicleField(gql.String, func(v internal.Vehicle) any { return v.FuelType }),
+		"transmission": vehicleField(gql.String, func(v internal.Vehicle) any { return v.Transmission }),
+		"weight":       vehicleField(gql.Float, func(v internal.Vehicle) any { return v.Weight }),
+		"dimensions":   vehicleField(dimensionsType, func(v internal.Vehicle) any { return v }),
+		"tags": vehicleField(gql.NewList(gql.NewNonNull(gql.String)), func(v internal.Vehicle) any {
+			if v.Tags == nil {
+				return []string{}
+			}
+			return v.Tags
+		}),
+		"custom": vehicleField(jsonType, func(v internal.Vehicle) any {
+			if v.Custom == nil {
+				return map[string]any{}
+			}
+			return v.Custom
+		}),
+	},
+})
+
+// vehiclePageType is the GraphQL type of a page of vehicles
+var vehiclePageType = gql.NewObject(gql.ObjectConfig{
+	Name: "VehiclePage",
+	Fields: gql.Fields{
+		"items": &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(vehicleType))),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(page).Items, nil
+			},
+		},
+		"total": &gql.Field{
+			Type:        gql.NewNonNull(gql.Int),
+			Description: "The number of vehicles matching the filter, regardless of the page",
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(page).Total, nil
+			},
+		},
+		"offset": &gql.Field{
+			Type: gql.NewNonNull(gql.Int),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(page).Offset, nil
+			},
+		},
+		"limit": &gql.Field{
+			Type: gql.NewNonNull(gql.Int),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(page).Limit, nil
+			},
+		},
+	},
+})
+
+// brandStatsType is the GraphQL type of the aggregates of a brand
+var brandStatsType = gql.NewObject(gql.ObjectConfig{
+	Name: "BrandStats",
+	Fields: gql.Fields{
+		"brand": &gql.Field{
+			Type: gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(brandStats).Brand, nil
+			},
+		},
+		"averageSpeed": &gql.Field{
+			Type: gql.NewNonNull(gql.Float),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(brandStats).AverageSpeed, nil
+			},
+		},
+		"averageCapacity": &gql.Field{
+			Type: gql.NewNonNull(gql.Int),
+			Resolve: func(p gql.ResolveParams) (any, error) {
+				return p.Source.(brandStats).AverageCapacity, nil
+			},
+		},
+	},
+})
+
+// rangeInput is the GraphQL input of a closed or open numeric range
+var rangeInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "RangeInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"min": {Type: gql.Float},
+		"max": {Type: gql.Float},
+	},
+})
+
+// vehicleFilterInput is the GraphQL input of the filters of Query.vehicles, combined with AND
+var vehicleFilterInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "VehicleFilter",
+	Fields: gql.InputObjectConfigFieldMap{
+		"color":        {Type: gql.String, Description: "Informed together with year"},
+		"year":         {Type: gql.Int, Description: "Informed together with color"},
+		"brand":        {Type: gql.String},
+		"yearFrom":     {Type: gql.Int, Description: "Used with brand"},
+		"yearTo":       {Type: gql.Int, Description: "Used with brand"},
+		"fuelType":     {Type: gql.String},
+		"transmission": {Type: gql.String},
+		"length":       {Type: rangeInput},
+		"width":        {Type: rangeInput},
+		"weight":       {Type: rangeInput},
+	},
+})
+
+// vehicleInput is the GraphQL input of Mutation.createVehicle
+var vehicleInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "VehicleInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"brand":        {Type: gql.NewNonNull(gql.String)},
+		"model":        {Type: gql.NewNonNull(gql.String)},
+		"registration": {Type: gql.NewNonNull(gql.String)},
+		"color":        {Type: gql.NewNonNull(gql.String)},
+		"year":         {Type: gql.NewNonNull(gql.Int)},
+		"passengers":   {Type: gql.NewNonNull(gql.Int)},
+		"maxSpeed":     {Type: gql.NewNonNull(gql.Float)},
+		"fuelType":     {Type: gql.NewNonNull(gql.String)},
+		"transmission": {Type: gql.NewNonNull(gql.String)},
+		"weight":       {Type: gql.NewNonNull(gql.Float)},
+		"height":       {Type: gql.NewNonNull(gql.Float)},
+		"length":       {Type: gql.NewNonNull(gql.Float)},
+		"width":        {Type: gql.NewNonNull(gql.Float)},
+		"tags":         {Type: gql.NewList(gql.NewNonNull(gql.String))},
+		"custom":       {Type: jsonType, Description: "The custom attributes of the tenant, an object by name"},
+	},
+})
+
+// vehiclePatchInput is the GraphQL input of Mutation.patchVehicle, the absent fields are kept
+var vehiclePatchInput = gql.NewInputObject(gql.InputObjectConfig{
+	Name: "VehiclePatchInput",
+	Fields: gql.InputObjectConfigFieldMap{
+		"brand":        {Type: gql.String},
+		"model":        {Type: gql.String},
+		"registration": {Type: gql.String},
+		"color":        {Type: gql.String},
+		"year":         {Type: gql.Int},
+		"passengers":   {Type: gql.Int},
+		"maxSpeed":     {Type: gql.Float},
+		"fuelType":     {Type: gql.String},
+		"transmission": {Type: gql.String},
+		"weight":       {Type: gql.Float},
+		"height":       {Type: gql.Float},
+		"length":       {Type: gql.Float},
+		"width":        {Type: gql.Float},
+		"tags":         {Type: gql.NewList(gql.NewNonNull(gql.String)), Description: "Replaces the tags of the vehicle"},
+		"custom":       {Type: jsonType, Description: "Merged into the custom attributes of the vehicle, a null value removing one"},
+	},
+})
+
+// vehicleField is a function that returns a non-null field of a vehicle read by value
+func vehicleField(t gql.Output, value func(v internal.Vehicle) any) *gql.Field {
+	return &gql.Field{
+		Type: gql.NewNonNull(t),
+		Resolve: func(p gql.ResolveParams) (any, error) {
+			return value(p.Source.(internal.Vehicle)), nil
+		},
+	}
+}
+
+// jsonLiteral is a function that returns the value of a JSON literal written in a query, nil for
+// the variables
+func jsonLiteral(valueAST ast.Value) any {
+	switch v := valueAST.(type) {
+	case *ast.StringValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.IntValue, *ast.FloatValue:
+		f, err := strconv.ParseFloat(v.GetValue().(string), 64)
+		if err != nil {
+			return nil
+		}
+		return f
+	case *ast.EnumValue:
+		return v.Value
+	case *ast.ListValue:
+		l := make([]any, 0, len(v.Values))
+		for _, value := range v.Values {
+			l = append(l, jsonLiteral(value))
+		}
+		return l
+	case *ast.ObjectValue:
+		o := make(map[string]any, len(v.Fields))
+		for _, f := range v.Fields {
+			o[f.Name.Value] = jsonLiteral(f.Value)
+		}
+		return o
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"app/internal"
+	"app/pkg/fleetpb"
+	"maps"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// toProto is a function that converts a vehicle to its message
+func toProto(vh internal.Vehicle) *fleetpb.Vehicle {
+	return &fleetpb.Vehicle{
+		Id: int64(vh.Id),
+		Attributes: &fleetpb.VehicleAttributes{
+			Brand:           vh.Brand,
+			Model:           vh.Model,
+			Registration:    vh.Registration,
+			Color:           vh.Color,
+			FabricationYear: int32(vh.FabricationYear),
+			Capacity:        int32(vh.Capacity),
+			MaxSpeed:        vh.MaxSpeed,
+			FuelType:        vh.FuelType,
+			Transmission:    vh.Transmission,
+			Weight:          vh.Weight,
+			Dimensions: &fleetpb.Dimensions{
+				Height: vh.Height,
+				Length: vh.Length,
+				Width:  vh.Width,
+			},
+			Tags:   vh.Tags,
+			Custom: customToProto(vh.Custom),
+		},
+	}
+}
+
+// fromProto is a function that converts the attributes message to the vehicle attributes
+func fromProto(a *fleetpb.VehicleAttributes) internal.VehicleAttributes {
+	return internal.VehicleAttributes{
+		Brand:           a.GetBrand(),
+		Model:           a.GetModel(),
+		Registration:    a.GetRegistration(),
+		Color:           a.GetColor(),
+		FabricationYear: int(a.GetFabricationYear()),
+		Capacity:        int(a.GetCapacity()),
+		MaxSpeed:        a.GetMaxSpeed(),
+		FuelType:        a.GetFuelType(),
+		Transmission:    a.GetTransmission(),
+		Weight:          a.GetWeight(),
+		Dimensions: internal.Dimensions{
+			Height: a.GetDimensions().GetHeight(),
+			Length: a.GetDimensions().GetLength(),
+			Width:  a.GetDimensions().GetWidth(),
+		},
+		Tags:   internal.NormalizeTags(a.GetTags()),
+		Custom: customFromProto(a.GetCustom()),
+	}
+}
+
+// applyPatch is a function that copies the fields present in p to attrs
+func applyPatch(attrs *internal.VehicleAttributes, p *fleetpb.VehiclePatch) {
+	if p == nil {
+		return
+	}
+	if p.Brand != nil {
+		attrs.Brand = p.GetBrand()
+	}
+	if p.Model != nil {
+		attrs.Model = p.GetModel()
+	}
+	if p.Registration != nil {
+		attrs.Registration = p.GetRegistration()
+	}
+	if p.Color != nil {
+		attrs.Color = p.GetColor()
+	}
+	if p.FabricationYear != nil {
+		attrs.FabricationYear = int(p.GetFabricationYear())
+	}
+	if p.Capacity != nil {
+		attrs.Capacity = int(p.GetCapacity())
+	}
+	if p.MaxSpeed != nil {
+		attrs.MaxSpeed = p.GetMaxSpeed()
+	}
+	if p.FuelType != nil {
+		attrs.FuelType = p.GetFuelType()
+	}
+	if p.Transmission != nil {
+		attrs.Transmission = p.GetTransmission()
+	}
+	if p.Weight != nil {
+		attrs.Weight = p.GetWeight()
+	}
+	if p.Height != nil {
+		attrs.Height = p.GetHeight()
+	}
+	if p.Length != nil {
+		attrs.Length = p.GetLength()
+	}
+	if p.Width != nil {
+		attrs.Width = p.GetWidth()
+	}
+	if p.Tags != nil {
+		attrs.Tags = internal.NormalizeTags(p.GetTags().GetValues())
+	}
+	if len(p.GetCustom()) > 0 {
+		// the vehicle may share its attributes with the repository, they are copied before the merge
+		custom := maps.Clone(attrs.Custom)
+		if custom == nil {
+			custom = make(map[string]any, len(p.GetCustom()))
+		}
+		for name, value := range p.GetCustom() {
+			if v := value.AsInterface(); v != nil {
+				custom[name] = v
+				continue
+			}
+			delete(custom, name)
+		}
+		attrs.Custom = custom
+	}
+}
+
+// customToProto is a function that converts the custom attributes to their messages
+func customToProto(custom map[string]any) (m map[string]*structpb.Value) {
+	for name, value := range custom {
+		v, err := structpb.NewValue(value)
+		if err != nil {
+			// only strings, numbers and booleans are stored
+			continue
+		}
+		if m == nil {
+			m = make(map[string]*structpb.Value, len(custom))
+		}
+		m[name] = v
+	}
+	return
+}
+
+// customFromProto is a function that converts the messages of the custom attributes, the null
+// values left out as absent
+func customFromProto(m map[string]*structpb.Value) (custom map[string]any) {
+	for name, value := range m {
+		v := value.AsInterface()
+		if v == nil {
+			continue
+		}
+		if custom == nil {
+			custom = make(map[string]any, len(m))
+		}
+		custom[name] = v
+	}
+	return
+}
+
+// rangeParam is a function that formats a range as the min-max parameter of the service
+func rangeParam(r *fleetpb.Range) (s string, err error) {
+	if r == nil {
+		err = status.Error(codes.InvalidArgument, "length and width are required")
+		return
+	}
+	if r.GetMin() < 0 || r.GetMax() < 0 {
+		err = status.Error(codes.InvalidArgument, "dimensions must not be negative")
+		return
+	}
+	s = formatFloat(r.GetMin()) + "-" + formatFloat(r.GetMax())
+	return
+}
+
+// formatFloat is a function that formats f as a service parameter
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,361 @@
+package grpc
+
+import (
+	"app/internal"
+	"app/internal/codec"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"app/pkg/fleetpb"
+	"app/pkg/logger"
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the size from which the buffered export is sent as a chunk
+const exportChunkSize = 32 << 10
+
+// exportFormats are the codec formats of the export formats
+var exportFormats = map[fleetpb.ExportFormat]string{
+	fleetpb.ExportFormat_EXPORT_FORMAT_UNSPECIFIED: codec.FormatCSV,
+	fleetpb.ExportFormat_EXPORT_FORMAT_CSV:         codec.FormatCSV,
+	fleetpb.ExportFormat_EXPORT_FORMAT_JSON_LINES:  codec.FormatJSONLines,
+}
+
+// NewFleetServer is a function that returns a new instance of FleetServer
+func NewFleetServer(sv internal.VehicleService) *FleetServer {
+	return &FleetServer{sv: sv}
+}
+
+// FleetServer is a struct that implements fleetpb.FleetServiceServer over the vehicle service
+type FleetServer struct {
+	fleetpb.UnimplementedFleetServiceServer
+	// sv is the service that will be used by the server
+	sv internal.VehicleService
+}
+
+// ListVehicles is a method that streams every vehicle
+func (s *FleetServer) ListVehicles(_ *fleetpb.ListVehiclesRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	v, err := s.sv.FindAll(stream.Context())
+	return sendVehicles(stream, v, err)
+}
+
+// ExportVehicles is a method that streams every vehicle encoded in the requested format
+func (s *FleetServer) ExportVehicles(req *fleetpb.ExportVehiclesRequest, stream gogrpc.ServerStreamingServer[fleetpb.ExportChunk]) (err error) {
+	ctx := stream.Context()
+	v, err := s.sv.FindAll(ctx)
+	if err != nil {
+		return statusError(ctx, err)
+	}
+
+	format, ok := exportFormats[req.GetFormat()]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown format %s", req.GetFormat())
+	}
+	var buf bytes.Buffer
+	enc, err := codec.NewEncoder(&buf, format)
+	if err != nil {
+		return
+	}
+
+	for _, vh := range sorted(v) {
+		if err = ctx.Err(); err != nil {
+			return statusError(ctx, err)
+		}
+		if err = enc.Encode(vh); err != nil {
+			return
+		}
+		if buf.Len() >= exportChunkSize {
+			if err = stream.Send(&fleetpb.ExportChunk{Data: bytes.Clone(buf.Bytes())}); err != nil {
+				return
+			}
+			buf.Reset()
+		}
+	}
+	if err = enc.Close(); err != nil {
+		return
+	}
+	if buf.Len() > 0 {
+		err = stream.Send(&fleetpb.ExportChunk{Data: buf.Bytes()})
+	}
+	return
+}
+
+// GetVehicle is a method that returns a vehicle
+func (s *FleetServer) GetVehicle(ctx context.Context, req *fleetpb.GetVehicleRequest) (v *fleetpb.Vehicle, err error) {
+	vh, err := s.find(ctx, req.GetId())
+	if err != nil {
+		return
+	}
+
+	v = toProto(vh)
+	return
+}
+
+// CreateVehicle is a method that creates a vehicle
+func (s *FleetServer) CreateVehicle(ctx context.Context, req *fleetpb.CreateVehicleRequest) (v *fleetpb.Vehicle, err error) {
+	attrs := fromProto(req.GetAttributes())
+	if err = attrs.Validate(); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		return
+	}
+
+	vh, err := s.sv.Save(ctx, &attrs)
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	v = toProto(vh)
+	return
+}
+
+// CreateVehicles is a method that creates many vehicles
+func (s *FleetServer) CreateVehicles(ctx context.Context, req *fleetpb.CreateVehiclesRequest) (res *fleetpb.CreateVehiclesResponse, err error) {
+	attrs := make([]internal.VehicleAttributes, 0, len(req.GetAttributes()))
+	for i, a := range req.GetAttributes() {
+		vh := fromProto(a)
+		if err = vh.Validate(); err != nil {
+			err = status.Errorf(codes.InvalidArgument, "vehicle %d: %s", i, err.Error())
+			return
+		}
+		attrs = append(attrs, vh)
+	}
+
+	v, err := s.sv.SaveMultipleVehicles(ctx, &attrs)
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	res = &fleetpb.CreateVehiclesResponse{}
+	for _, vh := range sorted(v) {
+		res.Vehicles = append(res.Vehicles, toProto(vh))
+	}
+	return
+}
+
+// PatchVehicle is a method that updates the attributes present in the request
+func (s *FleetServer) PatchVehicle(ctx context.Context, req *fleetpb.PatchVehicleRequest) (v *fleetpb.Vehicle, err error) {
+	vh, err := s.find(ctx, req.GetId())
+	if err != nil {
+		return
+	}
+
+	applyPatch(&vh.VehicleAttributes, req.GetPatch())
+	if err = vh.Validate(); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		return
+	}
+
+	vh, err = s.sv.Patch(ctx, &vh)
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	v = toProto(vh)
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (s *FleetServer) UpdateMaxSpeed(ctx context.Context, req *fleetpb.UpdateMaxSpeedRequest) (v *fleetpb.Vehicle, err error) {
+	if req.GetMaxSpeed() <= 0 {
+		err = status.Error(codes.InvalidArgument, "max speed must be greater than zero")
+		return
+	}
+	if _, err = s.find(ctx, req.GetId()); err != nil {
+		return
+	}
+
+	vh, err := s.sv.UpdateMaxSpeed(ctx, int(req.GetId()), req.GetMaxSpeed())
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	v = toProto(vh)
+	return
+}
+
+// UpdateFuel is a method that updates the fuel type of a vehicle
+func (s *FleetServer) UpdateFuel(ctx context.Context, req *fleetpb.UpdateFuelRequest) (v *fleetpb.Vehicle, err error) {
+	if req.GetFuelType() == "" {
+		err = status.Error(codes.InvalidArgument, "fuel type is required")
+		return
+	}
+	if _, err = s.find(ctx, req.GetId()); err != nil {
+		return
+	}
+
+	vh, err := s.sv.UpdateFuel(ctx, int(req.GetId()), req.GetFuelType())
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	v = toProto(vh)
+	return
+}
+
+// DeleteVehicle is a method that deletes a vehicle
+func (s *FleetServer) DeleteVehicle(ctx context.Context, req *fleetpb.DeleteVehicleRequest) (res *fleetpb.DeleteVehicleResponse, err error) {
+	if _, err = s.find(ctx, req.GetId()); err != nil {
+		return
+	}
+
+	if err = s.sv.DeleteById(ctx, strconv.FormatInt(req.GetId(), 10)); err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	res = &fleetpb.DeleteVehicleResponse{}
+	return
+}
+
+// FindByColorAndYear is a method that streams the vehicles of a color fabricated in a year
+func (s *FleetServer) FindByColorAndYear(req *fleetpb.FindByColorAndYearRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	v, err := s.sv.FindByColorAndYears(stream.Context(), req.GetColor(), strconv.Itoa(int(req.GetYear())))
+	return sendVehicles(stream, v, err)
+}
+
+// FindByBrandAndYearInterval is a method that streams the vehicles of a brand fabricated between two years
+func (s *FleetServer) FindByBrandAndYearInterval(req *fleetpb.FindByBrandAndYearIntervalRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	v, err := s.sv.FindByMarcaAndYearInterval(stream.Context(), req.GetBrand(),
+		strconv.Itoa(int(req.GetStartYear())), strconv.Itoa(int(req.GetEndYear())))
+	return sendVehicles(stream, v, err)
+}
+
+// FindByFuelType is a method that streams the vehicles of a fuel type
+func (s *FleetServer) FindByFuelType(req *fleetpb.FindByFuelTypeRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	v, err := s.sv.FindTipoCombustivel(stream.Context(), req.GetFuelType())
+	return sendVehicles(stream, v, err)
+}
+
+// FindByTransmission is a method that streams the vehicles of a transmission
+func (s *FleetServer) FindByTransmission(req *fleetpb.FindByTransmissionRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	v, err := s.sv.FindByTransmissionType(stream.Context(), req.GetTransmission())
+	return sendVehicles(stream, v, err)
+}
+
+// FindByDimensions is a method that streams the vehicles within a length and a width range
+func (s *FleetServer) FindByDimensions(req *fleetpb.FindByDimensionsRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	length, err := rangeParam(req.GetLength())
+	if err != nil {
+		return err
+	}
+	width, err := rangeParam(req.GetWidth())
+	if err != nil {
+		return err
+	}
+
+	v, err := s.sv.FindByDimenssion(stream.Context(), length, width)
+	return sendVehicles(stream, v, err)
+}
+
+// FindByWeight is a method that streams the vehicles within a weight range
+func (s *FleetServer) FindByWeight(req *fleetpb.FindByWeightRequest, stream gogrpc.ServerStreamingServer[fleetpb.Vehicle]) error {
+	weight := req.GetWeight()
+	if weight == nil {
+		return status.Error(codes.InvalidArgument, "weight is required")
+	}
+
+	v, err := s.sv.FindByPeso(stream.Context(), formatFloat(weight.GetMin()), formatFloat(weight.GetMax()))
+	return sendVehicles(stream, v, err)
+}
+
+// GetBrandAverageSpeed is a method that returns the average maximum speed of the vehicles of a brand
+func (s *FleetServer) GetBrandAverageSpeed(ctx context.Context, req *fleetpb.GetBrandAverageSpeedRequest) (res *fleetpb.GetBrandAverageSpeedResponse, err error) {
+	m, err := s.sv.FindVelocidadeMediaMarca(ctx, req.GetBrand())
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+
+	res = &fleetpb.GetBrandAverageSpeedResponse{AverageSpeed: m}
+	return
+}
+
+// GetBrandAverageCapacity is a method that returns the average capacity of people of the vehicles of a brand
+func (s *FleetServer) GetBrandAverageCapacity(ctx context.Context, req *fleetpb.GetBrandAverageCapacityRequest) (res *fleetpb.GetBrandAverageCapacityResponse, err error) {
+	m, err := s.sv.FindMediaPessoaPorMarca(ctx, req.GetBrand())
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+	if m == 0 {
+		err = status.Error(codes.NotFound, apperrors.ErrVehicleBrand.Error())
+		return
+	}
+
+	res = &fleetpb.GetBrandAverageCapacityResponse{AverageCapacity: int32(m)}
+	return
+}
+
+// find is a method that returns the vehicle with the id, or a NotFound error
+func (s *FleetServer) find(ctx context.Context, id int64) (vh internal.Vehicle, err error) {
+	vh, err = s.sv.FindById(ctx, strconv.FormatInt(id, 10))
+	if err != nil {
+		err = statusError(ctx, err)
+		return
+	}
+	if vh.Id == 0 {
+		err = status.Error(codes.NotFound, "vehicle not found")
+	}
+	return
+}
+
+// sendVehicles is a function that streams the vehicles found by the service ordered by id,
+// nothing matching is an empty stream
+func sendVehicles(stream gogrpc.ServerStreamingServer[fleetpb.Vehicle], v map[int]internal.Vehicle, err error) error {
+	ctx := stream.Context()
+	if err != nil && !service.IsNotFound(err) {
+		return statusError(ctx, err)
+	}
+
+	for _, vh := range sorted(v) {
+		if err := ctx.Err(); err != nil {
+			return statusError(ctx, err)
+		}
+		if err := stream.Send(toProto(vh)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statusError is a function that maps an error of the service to a gRPC status error
+func statusError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request canceled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request timed out")
+	case service.IsNotFound(err):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, apperrors.ErrVehicleAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidVehicleData):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		logger.FromContext(ctx).Error("grpc: internal error", slog.String("error", err.Error()))
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// sorted is a function that returns the vehicles of v ordered by id
+func sorted(v map[int]internal.Vehicle) []internal.Vehicle {
+	s := make([]internal.Vehicle, 0, len(v))
+	for _, vh := range v {
+		s = append(s, vh)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Id < s[j].Id })
+	return s
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"app/internal"
+	"app/internal/catalog"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/pkg/fleetpb"
+	"app/pkg/tenant"
+	"context"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// TestFleetServer_CustomAttributes is a function that checks that the writes carry the tags and
+// custom attributes, a tenant requiring an attribute rejecting the vehicles without it
+func TestFleetServer_CustomAttributes(t *testing.T) {
+	rp := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Fiat", Model: "Uno", Registration: "ABC1D23", Color: "Red", FabricationYear: 2020,
+			Capacity: 5, MaxSpeed: 150, FuelType: "gasoline", Transmission: "manual", Weight: 900,
+			Dimensions: internal.Dimensions{Length: 3.8, Width: 1.6, Height: 1.5},
+		}},
+	})
+	ct, err := catalog.NewBrandMap(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := repository.NewAttributeMap([]internal.AttributeDefinition{
+		{Tenant: tenant.Default, Name: "cost_center", Type: internal.AttributeString, Required: true},
+	})
+	s := NewFleetServer(service.NewVehicleDefault(rp, ct, nil, attributes))
+	ctx := context.Background()
+	costCenter := map[string]*structpb.Value{"cost_center": structpb.NewStringValue("CC-12")}
+	attrs := func(registration string, custom map[string]*structpb.Value) *fleetpb.VehicleAttributes {
+		return &fleetpb.VehicleAttributes{
+			Brand: "Fiat", Model: "Uno", Registration: registration, Color: "Red", FabricationYear: 2020,
+			Capacity: 5, MaxSpeed: 150, FuelType: "gasoline", Transmission: "manual", Weight: 900,
+			Dimensions: &fleetpb.Dimensions{Height: 1.5, Length: 3.8, Width: 1.6},
+			Tags:       []string{"Airport", "night"},
+			Custom:     custom,
+		}
+	}
+
+	t.Run("create without the required attribute", func(t *testing.T) {
+		_, err := s.CreateVehicle(ctx, &fleetpb.CreateVehicleRequest{Attributes: attrs("NEW1A11", nil)})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got error %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("create with the required attribute", func(t *testing.T) {
+		v, err := s.CreateVehicle(ctx, &fleetpb.CreateVehicleRequest{Attributes: attrs("NEW1A12", costCenter)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.GetAttributes().GetTags(); !slices.Equal(got, []string{"airport", "night"}) {
+			t.Errorf("got tags %v, want [airport night]", got)
+		}
+		if got := v.GetAttributes().GetCustom()["cost_center"].GetStringValue(); got != "CC-12" {
+			t.Errorf("got cost_center %q, want CC-12", got)
+		}
+	})
+
+	t.Run("patch without the required attribute", func(t *testing.T) {
+		color := "Blue"
+		_, err := s.PatchVehicle(ctx, &fleetpb.PatchVehicleRequest{Id: 1, Patch: &fleetpb.VehiclePatch{Color: &color}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("got error %v, want InvalidArgument", err)
+		}
+	})
+
+	t.Run("patch with the required attribute", func(t *testing.T) {
+		v, err := s.PatchVehicle(ctx, &fleetpb.PatchVehicleRequest{Id: 1, Patch: &fleetpb.VehiclePatch{
+			Tags:   &fleetpb.Tags{Values: []string{"depot"}},
+			Custom: costCenter,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v.GetAttributes().GetTags(); !slices.Equal(got, []string{"depot"}) {
+			t.Errorf("got tags %v, want [depot]", got)
+		}
+		if got := v.GetAttributes().GetCustom()["cost_center"].GetStringValue(); got != "CC-12" {
+			t.Errorf("got cost_center %q, want CC-12", got)
+		}
+	})
+}
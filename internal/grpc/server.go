@@ -0,0 +1,205 @@
+package grpc
+
+import (
+	"app/internal"
+	"app/internal/metrics"
+	"app/internal/tracing"
+	"app/pkg/fleetpb"
+	"app/pkg/logger"
+	"app/pkg/tenant"
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// metadata keys read from the calls, the gRPC counterparts of the HTTP headers
+const (
+	// MetadataRequestID is the metadata key of the request id
+	MetadataRequestID = "x-request-id"
+	// MetadataTenant is the metadata key of the tenant id
+	MetadataTenant = "x-tenant-id"
+)
+
+// NewServer is a function that returns a gRPC server with the FleetService backed by sv,
+// the health service and the reflection service.
+// The interceptors follow the order of the HTTP middlewares: request id and tenant,
+// tracing, logging, metrics and recovery.
+func NewServer(sv internal.VehicleService, l *slog.Logger, reg prometheus.Registerer) *gogrpc.Server {
+	metricsUnary, metricsStream := metrics.NewGRPCInterceptors(reg)
+
+	srv := gogrpc.NewServer(
+		gogrpc.ChainUnaryInterceptor(
+			unaryRequestContext,
+			tracing.UnaryServerInterceptor(),
+			unaryLogging(l),
+			metricsUnary,
+			unaryRecovery,
+		),
+		gogrpc.ChainStreamInterceptor(
+			streamRequestContext,
+			tracing.StreamServerInterceptor(),
+			streamLogging(l),
+			metricsStream,
+			streamRecovery,
+		),
+	)
+
+	fleetpb.RegisterFleetServiceServer(srv, NewFleetServer(sv))
+
+	hs := health.NewServer()
+	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	hs.SetServingStatus(fleetpb.FleetService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(srv, hs)
+
+	reflection.Register(srv)
+	return srv
+}
+
+// requestContext is a function that stores in ctx the request id and the tenant id of
+// the metadata, generating the request id when it is absent
+func requestContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := first(md, MetadataRequestID)
+	if requestID == "" {
+		requestID = fmt.Sprintf("grpc-%06d", middleware.NextRequestID())
+	}
+	tenantID := first(md, MetadataTenant)
+	if tenantID == "" {
+		tenantID = tenant.Default
+	}
+
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, requestID)
+	return tenant.WithContext(ctx, tenantID)
+}
+
+// unaryRequestContext is an interceptor that applies requestContext to the unary calls
+// and returns the request id in the header metadata
+func unaryRequestContext(ctx context.Context, req any, _ *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (any, error) {
+	ctx = requestContext(ctx)
+	_ = gogrpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, middleware.GetReqID(ctx)))
+	return handler(ctx, req)
+}
+
+// streamRequestContext is an interceptor that applies requestContext to the streaming calls
+// and returns the request id in the header metadata
+func streamRequestContext(srv any, ss gogrpc.ServerStream, _ *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) error {
+	ctx := requestContext(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(MetadataRequestID, middleware.GetReqID(ctx)))
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// unaryLogging is a function that returns an interceptor storing in the context a logger
+// tagged with the request id and writing an access log line per unary call
+func unaryLogging(l *slog.Logger) gogrpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		rl := requestLogger(ctx, l)
+
+		resp, err = handler(logger.WithContext(ctx, rl), req)
+		logCall(ctx, rl, info.FullMethod, start, err)
+		return
+	}
+}
+
+// streamLogging is a function that returns an interceptor storing in the context a logger
+// tagged with the request id and writing an access log line per streaming call
+func streamLogging(l *slog.Logger) gogrpc.StreamServerInterceptor {
+	return func(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) (err error) {
+		start := time.Now()
+		ctx := ss.Context()
+		rl := requestLogger(ctx, l)
+
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: logger.WithContext(ctx, rl)})
+		logCall(ctx, rl, info.FullMethod, start, err)
+		return
+	}
+}
+
+// requestLogger is a function that returns l tagged with the request id, the tenant and
+// the trace id of ctx
+func requestLogger(ctx context.Context, l *slog.Logger) *slog.Logger {
+	rl := l.With(
+		slog.String("request_id", middleware.GetReqID(ctx)),
+		slog.String("tenant", tenant.FromContext(ctx)),
+	)
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		rl = rl.With(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return rl
+}
+
+// logCall is a function that writes the access log line of a call
+func logCall(ctx context.Context, rl *slog.Logger, method string, start time.Time, err error) {
+	rl.LogAttrs(ctx, slog.LevelInfo, "rpc",
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	)
+}
+
+// unaryRecovery is an interceptor that turns the panics of the unary calls into Internal errors
+func unaryRecovery(ctx context.Context, req any, info *gogrpc.UnaryServerInfo, handler gogrpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(ctx, info.FullMethod, p)
+		}
+	}()
+	resp, err = handler(ctx, req)
+	return
+}
+
+// streamRecovery is an interceptor that turns the panics of the streaming calls into Internal errors
+func streamRecovery(srv any, ss gogrpc.ServerStream, info *gogrpc.StreamServerInfo, handler gogrpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(ss.Context(), info.FullMethod, p)
+		}
+	}()
+	err = handler(srv, ss)
+	return
+}
+
+// recovered is a function that logs the panic p of a call and returns its error
+func recovered(ctx context.Context, method string, p any) error {
+	logger.FromContext(ctx).Error("grpc: panic",
+		slog.String("method", method),
+		slog.Any("panic", p),
+		slog.String("stack", string(debug.Stack())),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// first is a function that returns the first value of key in md
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// serverStream is a struct that replaces the context of a grpc.ServerStream
+type serverStream struct {
+	gogrpc.ServerStream
+	// ctx is the context of the stream
+	ctx context.Context
+}
+
+// Context is a method that returns the context of the stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// NewAttributeV2 is a function that returns a new instance of AttributeV2
+func NewAttributeV2(sv internal.AttributeService) *AttributeV2 {
+	return &AttributeV2{sv: sv}
+}
+
+// AttributeV2 is a struct with methods that represent the handlers of the custom attributes of the
+// vehicles of the tenant of the request, /v2/attributes
+type AttributeV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.AttributeService
+}
+
+// List is a method that returns a handler for the route GET /v2/attributes
+func (h *AttributeV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, err := h.sv.FindAll(r.Context())
+		if err != nil {
+			attributeErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.AttributesToList(d))
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/attributes/{name}
+func (h *AttributeV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, err := h.sv.FindByName(r.Context(), chi.URLParam(r, "name"))
+		if err != nil {
+			attributeErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.AttributeResponse]{Data: v2.AttributeToResponse(d)})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/attributes
+func (h *AttributeV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.AttributeRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		d := reqBody.ToDomain()
+
+		d, err := h.sv.Save(r.Context(), &d)
+		if err != nil {
+			attributeErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", "/v2/attributes/"+d.Name)
+		response.JSON(w, http.StatusCreated, v2.Data[v2.AttributeResponse]{Data: v2.AttributeToResponse(d)})
+	}
+}
+
+// Replace is a method that returns a handler for the route PUT /v2/attributes/{name}
+func (h *AttributeV2) Replace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.AttributeRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		name := chi.URLParam(r, "name")
+		if reqBody.Name != "" && reqBody.Name != name {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "name of the body must be the one of the route")
+			return
+		}
+		d := reqBody.ToDomain()
+		d.Name = name
+
+		d, err := h.sv.Update(r.Context(), &d)
+		if err != nil {
+			attributeErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.AttributeResponse]{Data: v2.AttributeToResponse(d)})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/attributes/{name}
+func (h *AttributeV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := h.sv.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+			attributeErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// attributeErrorV2 is a function that writes the v2 error response of the errors of the custom attributes,
+// the other errors as serviceErrorV2
+func attributeErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrAttributeNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrAttributeAlreadyExists):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidAttributeData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidAttribute, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
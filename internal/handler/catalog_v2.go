@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// NewCatalogV2 is a function that returns a new instance of CatalogV2
+func NewCatalogV2(ct internal.BrandCatalog) *CatalogV2 {
+	return &CatalogV2{ct: ct}
+}
+
+// CatalogV2 is a struct with methods that represent the handlers of the /v2/catalog routes,
+// administering the canonical brands and their aliases
+type CatalogV2 struct {
+	// ct is the catalog that will be used by the handler
+	ct internal.BrandCatalog
+}
+
+// Brands is a method that returns a handler for the route GET /v2/catalog/brands
+func (h *CatalogV2) Brands() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, v2.BrandsToList(h.ct.FindAll()))
+	}
+}
+
+// SaveBrand is a method that returns a handler for the route PUT /v2/catalog/brands/{brand},
+// creating the brand unless its name is already a brand or an alias
+func (h *CatalogV2) SaveBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, created, err := h.ct.SaveBrand(chi.URLParam(r, "brand"))
+		if err != nil {
+			catalogErrorV2(w, r, err)
+			return
+		}
+
+		status := http.StatusOK
+		if created {
+			status = http.StatusCreated
+		}
+		response.JSON(w, status, v2.Data[v2.BrandResponse]{Data: v2.BrandToResponse(b)})
+	}
+}
+
+// AddAlias is a method that returns a handler for the route PUT /v2/catalog/brands/{brand}/aliases/{alias}
+func (h *CatalogV2) AddAlias() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := h.ct.AddAlias(chi.URLParam(r, "brand"), chi.URLParam(r, "alias"))
+		if err != nil {
+			catalogErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.BrandResponse]{Data: v2.BrandToResponse(b)})
+	}
+}
+
+// RemoveAlias is a method that returns a handler for the route DELETE /v2/catalog/brands/{brand}/aliases/{alias}
+func (h *CatalogV2) RemoveAlias() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, err := h.ct.RemoveAlias(chi.URLParam(r, "brand"), chi.URLParam(r, "alias")); err != nil {
+			catalogErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// UnknownBrands is a method that returns a handler for the route GET /v2/catalog/unknown_brands,
+// the brands missing from the catalog found when the vehicles were loaded
+func (h *CatalogV2) UnknownBrands() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, v2.UnknownBrandsToList(h.ct.Unknown()))
+	}
+}
+
+// catalogErrorV2 is a function that writes the v2 error response of the errors of the catalog of the brands,
+// the other errors as serviceErrorV2
+func catalogErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrBrandNotFound), errors.Is(err, apperrors.ErrBrandAliasNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrBrandAliasConflict):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"app/pkg/logger"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// contextError is a function that writes the response for an error caused by the
+// request context and reports whether err was one
+func contextError(w http.ResponseWriter, r *http.Request, err error) bool {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		logger.FromContext(r.Context()).Warn("handler: request timed out")
+		response.JSON(w, http.StatusGatewayTimeout, map[string]any{
+			"message": "Tempo limite da requisição excedido.",
+			"data":    nil,
+		})
+		return true
+	case errors.Is(err, context.Canceled):
+		// the client is gone, nobody will read the response
+		logger.FromContext(r.Context()).Info("handler: request canceled by the client", slog.String("error", err.Error()))
+		return true
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"app/internal/service"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestContextError is a function that checks the responses written for the errors of the
+// request context
+func TestContextError(t *testing.T) {
+	cases := map[string]struct {
+		err        error
+		handled    bool
+		wantStatus int
+		wantBody   bool
+	}{
+		"deadline exceeded": {
+			err:        context.DeadlineExceeded,
+			handled:    true,
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   true,
+		},
+		"deadline exceeded wrapped": {
+			err:        fmt.Errorf("repository: %w", context.DeadlineExceeded),
+			handled:    true,
+			wantStatus: http.StatusGatewayTimeout,
+			wantBody:   true,
+		},
+		"canceled": {
+			err:     context.Canceled,
+			handled: true,
+		},
+		"other error": {
+			err:     errors.New("boom"),
+			handled: false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/vehicles", nil)
+
+			if got := contextError(w, r, c.err); got != c.handled {
+				t.Fatalf("got handled %v, want %v", got, c.handled)
+			}
+			if !c.wantBody {
+				// nothing written, for a client gone or for the caller to answer
+				if w.Body.Len() != 0 || len(w.Header()) != 0 {
+					t.Fatalf("got response %q with header %v, want nothing written", w.Body.String(), w.Header())
+				}
+				return
+			}
+			if w.Code != c.wantStatus {
+				t.Fatalf("got status %d, want %d", w.Code, c.wantStatus)
+			}
+			if w.Body.Len() == 0 {
+				t.Fatal("got an empty body, want the timeout message")
+			}
+		})
+	}
+}
+
+// TestVehicleDefault_GetAll_Deadline is a function that checks that a request whose deadline
+// expired before the scan is answered with 504 Gateway Timeout
+func TestVehicleDefault_GetAll_Deadline(t *testing.T) {
+	rp := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "ABC1D23"}},
+	})
+	hd := NewVehicleDefault(service.NewVehicleDefault(rp, nil, nil, nil))
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/vehicles", nil).WithContext(ctx)
+
+	hd.GetAll()(w, r)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("got status %d, want %d", w.Code, http.StatusGatewayTimeout)
+	}
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+const (
+	// DocumentMaxBodyBytes is the largest body of an upload, the file and the form around it
+	DocumentMaxBodyBytes = service.DocumentMaxBytes + 1<<20
+	// DocumentDefaultDays is the number of days ahead the expiring documents are reported
+	DocumentDefaultDays = 30
+)
+
+// NewDocumentV2 is a function that returns a new instance of DocumentV2
+func NewDocumentV2(sv internal.DocumentService) *DocumentV2 {
+	return &DocumentV2{sv: sv}
+}
+
+// DocumentV2 is a struct with methods that represent the handlers of the documents of the vehicles,
+// /v2/vehicles/{id}/documents and /v2/documents
+type DocumentV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.DocumentService
+}
+
+// List is a method that returns a handler for the route GET /v2/vehicles/{id}/documents
+func (h *DocumentV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		d, err := h.sv.FindAll(r.Context(), vehicleId)
+		if err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.DocumentsToList(d))
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/vehicles/{id}/documents/{document}
+func (h *DocumentV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "document")
+		if !ok {
+			return
+		}
+
+		d, err := h.sv.FindById(r.Context(), vehicleId, id)
+		if err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.DocumentResponse]{Data: v2.DocumentToResponse(d)})
+	}
+}
+
+// Upload is a method that returns a handler for the route POST /v2/vehicles/{id}/documents, a
+// multipart/form-data body with the file in the field file, its type in type and, optionally,
+// its expiry day in expiry
+func (h *DocumentV2) Upload() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, DocumentMaxBodyBytes)
+		// the body is bounded, the form is kept in memory
+		if err := r.ParseMultipartForm(DocumentMaxBodyBytes); err != nil {
+			if errors.Is(err, http.ErrNotMultipart) {
+				writeErrorV2(w, r, http.StatusUnsupportedMediaType, v2.CodeUnsupportedMediaType, "content type must be multipart/form-data")
+				return
+			}
+			bodyError(w, r, err)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "file is required")
+			return
+		}
+		defer file.Close()
+		d, err := v2.DocumentFromForm(vehicleId, r.FormValue("type"), header.Filename, r.FormValue("expiry"))
+		if err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidDocument, err.Error())
+			return
+		}
+
+		d, err = h.sv.Upload(r.Context(), &d, file)
+		if err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d/documents/%d", vehicleId, d.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.DocumentResponse]{Data: v2.DocumentToResponse(d)})
+	}
+}
+
+// Content is a method that returns a handler for the route GET /v2/vehicles/{id}/documents/{document}/content,
+// the file of the document as an attachment
+func (h *DocumentV2) Content() http.HandlerFunc {
+	return h.download(false)
+}
+
+// Thumbnail is a method that returns a handler for the route GET /v2/vehicles/{id}/documents/{document}/thumbnail,
+// the JPEG thumbnail of an image
+func (h *DocumentV2) Thumbnail() http.HandlerFunc {
+	return h.download(true)
+}
+
+// download is a method that returns a handler writing the file, or the thumbnail, of a document
+func (h *DocumentV2) download(thumbnail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "document")
+		if !ok {
+			return
+		}
+
+		d, rc, err := h.sv.Open(r.Context(), vehicleId, id, thumbnail)
+		if err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+		defer rc.Close()
+
+		// the content type was sniffed at the upload, browsers must not guess another
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if thumbnail {
+			w.Header().Set("Content-Type", "image/jpeg")
+		} else {
+			w.Header().Set("Content-Type", d.ContentType)
+			w.Header().Set("Content-Length", strconv.FormatInt(d.Size, 10))
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.Name}))
+		}
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, rc)
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/vehicles/{id}/documents/{document}
+func (h *DocumentV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "document")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.Delete(r.Context(), vehicleId, id); err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Expiring is a method that returns a handler for the route GET /v2/documents/expiring?at=&within_days=,
+// the documents of the fleet expired or expiring within the days after the day at, today by default,
+// the soonest first
+func (h *DocumentV2) Expiring() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		at := time.Now()
+		if s := q.Get("at"); s != "" {
+			var err error
+			if at, err = time.Parse(v2.DateLayout, s); err != nil {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "at must be a day as "+v2.DateLayout)
+				return
+			}
+		}
+		days := DocumentDefaultDays
+		if s := q.Get("within_days"); s != "" {
+			var err error
+			if days, err = strconv.Atoi(s); err != nil || days < 0 {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "within_days must be a non negative integer")
+				return
+			}
+		}
+
+		e, err := h.sv.Expiring(r.Context(), at, at.AddDate(0, 0, days))
+		if err != nil {
+			documentErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.ExpiringDocumentsToList(e))
+	}
+}
+
+// documentErrorV2 is a function that writes the v2 error response of the errors of the documents of the vehicles,
+// the other errors as serviceErrorV2
+func documentErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrDocumentNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidDocumentData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidDocument, err.Error())
+	case errors.Is(err, apperrors.ErrDocumentTooLarge):
+		writeErrorV2(w, r, http.StatusRequestEntityTooLarge, v2.CodeTooLarge, err.Error())
+	case errors.Is(err, apperrors.ErrUnsupportedContentType):
+		writeErrorV2(w, r, http.StatusUnsupportedMediaType, v2.CodeUnsupportedMediaType, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
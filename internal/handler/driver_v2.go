@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// NewDriverV2 is a function that returns a new instance of DriverV2
+func NewDriverV2(sv internal.DriverService) *DriverV2 {
+	return &DriverV2{sv: sv}
+}
+
+// DriverV2 is a struct with methods that represent the handlers of the drivers and their assignments,
+// /v2/drivers and /v2/vehicles/{id}/assignment
+type DriverV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.DriverService
+}
+
+// List is a method that returns a handler for the route GET /v2/drivers
+func (h *DriverV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d, err := h.sv.FindAll(r.Context())
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.DriversToList(d))
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/drivers/{driver}
+func (h *DriverV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "driver")
+		if !ok {
+			return
+		}
+
+		d, err := h.sv.FindById(r.Context(), id)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.DriverResponse]{Data: v2.DriverToResponse(d)})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/drivers
+func (h *DriverV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.DriverRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		d, err := reqBody.ToDomain()
+		if err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidDriver, err.Error())
+			return
+		}
+
+		d, err = h.sv.Save(r.Context(), &d)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/drivers/%d", d.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.DriverResponse]{Data: v2.DriverToResponse(d)})
+	}
+}
+
+// Patch is a method that returns a handler for the route PATCH /v2/drivers/{driver}
+func (h *DriverV2) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "driver")
+		if !ok {
+			return
+		}
+		d, err := h.sv.FindById(r.Context(), id)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		var reqBody v2.DriverPatchRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		if err := reqBody.ApplyTo(&d); err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidDriver, err.Error())
+			return
+		}
+
+		d, err = h.sv.Patch(r.Context(), &d)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.DriverResponse]{Data: v2.DriverToResponse(d)})
+	}
+}
+
+// DriverAssignments is a method that returns a handler for the route GET /v2/drivers/{driver}/assignments,
+// the history of the vehicles of the driver, the oldest first
+func (h *DriverV2) DriverAssignments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "driver")
+		if !ok {
+			return
+		}
+
+		a, err := h.sv.DriverAssignments(r.Context(), id)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.AssignmentsToList(a))
+	}
+}
+
+// DriverCurrent is a method that returns a handler for the route GET /v2/drivers/{driver}/assignment?at=,
+// the vehicle of the driver now or at the time at
+func (h *DriverV2) DriverCurrent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "driver")
+		if !ok {
+			return
+		}
+		at, ok := atParam(w, r)
+		if !ok {
+			return
+		}
+
+		a, err := h.sv.CurrentByDriver(r.Context(), id, at)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.AssignmentResponse]{Data: v2.AssignmentToResponse(a)})
+	}
+}
+
+// VehicleAssignments is a method that returns a handler for the route GET /v2/vehicles/{id}/assignments,
+// the history of the drivers of the vehicle, the oldest first
+func (h *DriverV2) VehicleAssignments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		a, err := h.sv.VehicleAssignments(r.Context(), vehicleId)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.AssignmentsToList(a))
+	}
+}
+
+// VehicleCurrent is a method that returns a handler for the route GET /v2/vehicles/{id}/assignment?at=,
+// the driver of the vehicle now or at the time at
+func (h *DriverV2) VehicleCurrent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		at, ok := atParam(w, r)
+		if !ok {
+			return
+		}
+
+		a, err := h.sv.CurrentByVehicle(r.Context(), vehicleId, at)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.AssignmentResponse]{Data: v2.AssignmentToResponse(a)})
+	}
+}
+
+// Assign is a method that returns a handler for the route POST /v2/vehicles/{id}/assignments
+func (h *DriverV2) Assign() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		var reqBody v2.AssignmentRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		a := reqBody.ToDomain(vehicleId, time.Now().UTC().Truncate(time.Second))
+		a, err := h.sv.Assign(r.Context(), &a)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, v2.Data[v2.AssignmentResponse]{Data: v2.AssignmentToResponse(a)})
+	}
+}
+
+// Unassign is a method that returns a handler for the route DELETE /v2/vehicles/{id}/assignment?at=,
+// ending the assignment of the vehicle now or at the time at, kept in the history
+func (h *DriverV2) Unassign() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		at, ok := atParam(w, r)
+		if !ok {
+			return
+		}
+
+		a, err := h.sv.Unassign(r.Context(), vehicleId, at)
+		if err != nil {
+			driverErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.AssignmentResponse]{Data: v2.AssignmentToResponse(a)})
+	}
+}
+
+// atParam is a function that parses the time of the query param at as RFC 3339, now by default,
+// writing the error response and returning false when it is malformed
+func atParam(w http.ResponseWriter, r *http.Request) (at time.Time, ok bool) {
+	at = time.Now().UTC().Truncate(time.Second)
+	if s := r.URL.Query().Get("at"); s != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, s); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "at must be a time as RFC 3339, e.g. 2026-01-02T15:04:05Z")
+			return
+		}
+	}
+
+	ok = true
+	return
+}
+
+// driverErrorV2 is a function that writes the v2 error response of the errors of the drivers and their assignments,
+// the other errors as serviceErrorV2
+func driverErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrDriverNotFound), errors.Is(err, apperrors.ErrAssignmentNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrDriverAlreadyExists), errors.Is(err, apperrors.ErrAssignmentConflict):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidDriverData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidDriver, err.Error())
+	case errors.Is(err, apperrors.ErrLicenseNotAllowed):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeLicenseNotAllowed, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// FuelDefaultTolerance is the share of the median consumption of a vehicle around it that is
+// its normal band, when the tolerance is absent
+const FuelDefaultTolerance = 0.25
+
+// NewFuelV2 is a function that returns a new instance of FuelV2
+func NewFuelV2(sv internal.FuelService) *FuelV2 {
+	return &FuelV2{sv: sv}
+}
+
+// FuelV2 is a struct with methods that represent the handlers of the fuel transactions and the
+// consumption of the vehicles, /v2/vehicles/{id}/fuel and /v2/fuel
+type FuelV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.FuelService
+}
+
+// Transactions is a method that returns a handler for the route GET /v2/vehicles/{id}/fuel,
+// the refuels and charges of the vehicle, the oldest first
+func (h *FuelV2) Transactions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		t, err := h.sv.FindAll(r.Context(), vehicleId)
+		if err != nil {
+			fuelErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.FuelTransactionsToList(t))
+	}
+}
+
+// Record is a method that returns a handler for the route POST /v2/vehicles/{id}/fuel
+func (h *FuelV2) Record() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		var reqBody v2.FuelTransactionRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		t := reqBody.ToDomain(vehicleId)
+		t, err := h.sv.Record(r.Context(), &t)
+		if err != nil {
+			fuelErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d/fuel", vehicleId))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.FuelTransactionResponse]{Data: v2.FuelTransactionToResponse(t)})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/vehicles/{id}/fuel/{transaction}
+func (h *FuelV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "transaction")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.Delete(r.Context(), vehicleId, id); err != nil {
+			fuelErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Consumption is a method that returns a handler for the route GET /v2/vehicles/{id}/fuel/consumption?tolerance=,
+// the consumption of the current fuel type of the vehicle, the ones outside its normal band flagged
+func (h *FuelV2) Consumption() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		tolerance, ok := toleranceParam(w, r)
+		if !ok {
+			return
+		}
+
+		c, err := h.sv.Consumption(r.Context(), vehicleId, tolerance)
+		if err != nil {
+			fuelErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ConsumptionReportResponse]{Data: v2.ConsumptionReportToResponse(c)})
+	}
+}
+
+// Anomalies is a method that returns a handler for the route GET /v2/fuel/anomalies?tolerance=,
+// the consumptions of the fleet outside the normal band of their vehicle, the most recent first
+func (h *FuelV2) Anomalies() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tolerance, ok := toleranceParam(w, r)
+		if !ok {
+			return
+		}
+
+		c, err := h.sv.Anomalies(r.Context(), tolerance)
+		if err != nil {
+			fuelErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.ConsumptionsToList(c))
+	}
+}
+
+// toleranceParam is a function that parses the query param tolerance, FuelDefaultTolerance by
+// default, writing the error response and returning false when it is malformed
+func toleranceParam(w http.ResponseWriter, r *http.Request) (tolerance float64, ok bool) {
+	tolerance = FuelDefaultTolerance
+	if s := r.URL.Query().Get("tolerance"); s != "" {
+		var err error
+		if tolerance, err = strconv.ParseFloat(s, 64); err != nil || tolerance <= 0 {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "tolerance must be a positive number, e.g. 0.25 for 25%")
+			return
+		}
+	}
+
+	ok = true
+	return
+}
+
+// fuelErrorV2 is a function that writes the v2 error response of the errors of the fuel transactions,
+// the other errors as serviceErrorV2
+func fuelErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrFuelTransactionNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrOdometerDecreased):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidFuelData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidFuel, err.Error())
+	case errors.Is(err, apperrors.ErrFuelTypeMismatch):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeFuelTypeMismatch, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// horizon of the due maintenance reports
+const (
+	// MaintenanceDefaultDays is the number of days before the due date a service is upcoming
+	MaintenanceDefaultDays = 30
+	// MaintenanceDefaultKm is the distance before the due odometer a service is upcoming
+	MaintenanceDefaultKm = 1000
+)
+
+// NewMaintenanceV2 is a function that returns a new instance of MaintenanceV2
+func NewMaintenanceV2(sv internal.MaintenanceService) *MaintenanceV2 {
+	return &MaintenanceV2{sv: sv}
+}
+
+// MaintenanceV2 is a struct with methods that represent the handlers of the maintenance routes,
+// /v2/vehicles/{id}/maintenance and /v2/maintenance
+type MaintenanceV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.MaintenanceService
+}
+
+// Events is a method that returns a handler for the route GET /v2/vehicles/{id}/maintenance,
+// the service history of the vehicle, the oldest first
+func (h *MaintenanceV2) Events() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		e, err := h.sv.FindEvents(r.Context(), vehicleId)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.MaintenanceEventsToList(e))
+	}
+}
+
+// RecordEvent is a method that returns a handler for the route POST /v2/vehicles/{id}/maintenance
+func (h *MaintenanceV2) RecordEvent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		var reqBody v2.MaintenanceEventRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		e, err := reqBody.ToDomain(vehicleId)
+		if err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, err.Error())
+			return
+		}
+
+		e, err = h.sv.RecordEvent(r.Context(), &e)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d/maintenance/%d", vehicleId, e.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.MaintenanceEventResponse]{Data: v2.MaintenanceEventToResponse(e)})
+	}
+}
+
+// DeleteEvent is a method that returns a handler for the route DELETE /v2/vehicles/{id}/maintenance/{event}
+func (h *MaintenanceV2) DeleteEvent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "event")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.DeleteEvent(r.Context(), vehicleId, id); err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Plans is a method that returns a handler for the route GET /v2/vehicles/{id}/maintenance/plans
+func (h *MaintenanceV2) Plans() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		p, err := h.sv.FindPlans(r.Context(), vehicleId)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.MaintenancePlansToList(p))
+	}
+}
+
+// SavePlan is a method that returns a handler for the route POST /v2/vehicles/{id}/maintenance/plans
+func (h *MaintenanceV2) SavePlan() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		var reqBody v2.MaintenancePlanRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		p, err := reqBody.ToDomain(vehicleId)
+		if err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, err.Error())
+			return
+		}
+
+		p, err = h.sv.SavePlan(r.Context(), &p)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d/maintenance/plans/%d", vehicleId, p.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.MaintenancePlanResponse]{Data: v2.MaintenancePlanToResponse(p)})
+	}
+}
+
+// DeletePlan is a method that returns a handler for the route DELETE /v2/vehicles/{id}/maintenance/plans/{plan}
+func (h *MaintenanceV2) DeletePlan() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "plan")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.DeletePlan(r.Context(), vehicleId, id); err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Due is a method that returns a handler for the route GET /v2/vehicles/{id}/maintenance/due?at=&within_days=&within_km=,
+// every plan of the vehicle with its status
+func (h *MaintenanceV2) Due() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		at, horizon, ok := dueParams(w, r)
+		if !ok {
+			return
+		}
+
+		d, err := h.sv.Due(r.Context(), vehicleId, at, horizon)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.MaintenanceDueToList(d))
+	}
+}
+
+// FleetDue is a method that returns a handler for the route GET /v2/maintenance/due?at=&within_days=&within_km=,
+// the plans of the fleet overdue or upcoming, the overdue first
+func (h *MaintenanceV2) FleetDue() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		at, horizon, ok := dueParams(w, r)
+		if !ok {
+			return
+		}
+
+		d, err := h.sv.FleetDue(r.Context(), at, horizon)
+		if err != nil {
+			maintenanceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.MaintenanceDueToList(d))
+	}
+}
+
+// dueParams is a function that parses the day of a due report, today by default, and its horizon,
+// writing the error response and returning false when they are malformed
+func dueParams(w http.ResponseWriter, r *http.Request) (at time.Time, h internal.MaintenanceHorizon, ok bool) {
+	q := r.URL.Query()
+
+	at = time.Now()
+	if s := q.Get("at"); s != "" {
+		var err error
+		if at, err = time.Parse(v2.DateLayout, s); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "at must be a day as "+v2.DateLayout)
+			return
+		}
+	}
+	h = internal.MaintenanceHorizon{Days: MaintenanceDefaultDays, Km: MaintenanceDefaultKm}
+	if s := q.Get("within_days"); s != "" {
+		days, err := strconv.Atoi(s)
+		if err != nil || days < 0 {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "within_days must be a non negative integer")
+			return
+		}
+		h.Days = days
+	}
+	if s := q.Get("within_km"); s != "" {
+		km, err := strconv.ParseFloat(s, 64)
+		if err != nil || km < 0 {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "within_km must be a non negative number")
+			return
+		}
+		h.Km = km
+	}
+
+	ok = true
+	return
+}
+
+// pathInt is a function that parses the route param name as an integer, writing the error
+// response and returning false when it is malformed
+func pathInt(w http.ResponseWriter, r *http.Request, name string) (n int, ok bool) {
+	n, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, name+" must be an integer")
+		return
+	}
+
+	ok = true
+	return
+}
+
+// maintenanceErrorV2 is a function that writes the v2 error response of the errors of the maintenance of the vehicles,
+// the other errors as serviceErrorV2
+func maintenanceErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrMaintenanceEventNotFound), errors.Is(err, apperrors.ErrMaintenancePlanNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidMaintenanceData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidMaintenance, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
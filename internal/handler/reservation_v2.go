@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"app/pkg/logger"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// NewReservationV2 is a function that returns a new instance of ReservationV2
+func NewReservationV2(rs internal.ReservationService, sv internal.VehicleService) *ReservationV2 {
+	return &ReservationV2{rs: rs, sv: sv}
+}
+
+// ReservationV2 is a struct with methods that represent the handlers of the reservations of the
+// pool vehicles, /v2/reservations
+type ReservationV2 struct {
+	// rs is the service of the reservations
+	rs internal.ReservationService
+	// sv is the service of the vehicles booked
+	sv internal.VehicleService
+}
+
+// List is a method that returns a handler for the route GET /v2/reservations?vehicle_id=&from=&to=&status=&format=,
+// the reservations by start, as JSON or, with format=ics, as an iCalendar
+func (h *ReservationV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		format := q.Get("format")
+		if format != "" && format != "json" && format != "ics" {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "format must be json or ics")
+			return
+		}
+		var f internal.ReservationFilter
+		if id, ok := optionalInt(q.Get("vehicle_id")); !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "vehicle_id must be an integer")
+			return
+		} else if id != nil {
+			f.VehicleId = *id
+		}
+		var ok bool
+		if f.From, ok = timeParam(w, r, "from", false); !ok {
+			return
+		}
+		if f.To, ok = timeParam(w, r, "to", false); !ok {
+			return
+		}
+		switch f.Status = q.Get("status"); f.Status {
+		case "", internal.ReservationBooked, internal.ReservationCheckedOut, internal.ReservationReturned, internal.ReservationCancelled:
+		default:
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "status must be booked, checked_out, returned or cancelled")
+			return
+		}
+
+		rs, err := h.rs.FindAll(r.Context(), f)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		if format == "ics" {
+			vehicles, err := h.sv.FindAll(r.Context())
+			if err != nil {
+				reservationErrorV2(w, r, err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="reservations.ics"`)
+			w.WriteHeader(http.StatusOK)
+			if _, err := v2.ReservationsToCalendar(rs, vehicles, time.Now().UTC().Truncate(time.Second)).WriteTo(w); err != nil {
+				logger.FromContext(r.Context()).Error("handler: writing the calendar", slog.String("error", err.Error()))
+			}
+			return
+		}
+		response.JSON(w, http.StatusOK, v2.ReservationsToList(rs))
+	}
+}
+
+// Availability is a method that returns a handler for the route GET /v2/reservations/availability?start=&end=,
+// the vehicles free during the interval, matching the query filters of GET /v2/vehicles
+func (h *ReservationV2) Availability() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start, ok := timeParam(w, r, "start", true)
+		if !ok {
+			return
+		}
+		end, ok := timeParam(w, r, "end", true)
+		if !ok {
+			return
+		}
+		filter, ok := vehicleFilter(w, r)
+		if !ok {
+			return
+		}
+
+		v, err := service.FindAvailable(r.Context(), h.sv, h.rs, filter, start, end)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.VehiclesToList(v))
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/reservations/{reservation}
+func (h *ReservationV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "reservation")
+		if !ok {
+			return
+		}
+
+		rs, err := h.rs.FindById(r.Context(), id)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ReservationResponse]{Data: v2.ReservationToResponse(rs)})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/reservations
+func (h *ReservationV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.ReservationRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		rs := reqBody.ToDomain()
+		rs, err := h.rs.Book(r.Context(), &rs)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/reservations/%d", rs.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.ReservationResponse]{Data: v2.ReservationToResponse(rs)})
+	}
+}
+
+// Cancel is a method that returns a handler for the route POST /v2/reservations/{reservation}/cancel
+func (h *ReservationV2) Cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "reservation")
+		if !ok {
+			return
+		}
+
+		rs, err := h.rs.Cancel(r.Context(), id)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ReservationResponse]{Data: v2.ReservationToResponse(rs)})
+	}
+}
+
+// CheckOut is a method that returns a handler for the route POST /v2/reservations/{reservation}/checkout?at=,
+// the vehicle taken now or at the time at
+func (h *ReservationV2) CheckOut() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "reservation")
+		if !ok {
+			return
+		}
+		at, ok := atParam(w, r)
+		if !ok {
+			return
+		}
+
+		rs, err := h.rs.CheckOut(r.Context(), id, at)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ReservationResponse]{Data: v2.ReservationToResponse(rs)})
+	}
+}
+
+// CheckIn is a method that returns a handler for the route POST /v2/reservations/{reservation}/checkin?at=,
+// the vehicle returned now or at the time at
+func (h *ReservationV2) CheckIn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "reservation")
+		if !ok {
+			return
+		}
+		at, ok := atParam(w, r)
+		if !ok {
+			return
+		}
+
+		rs, err := h.rs.CheckIn(r.Context(), id, at)
+		if err != nil {
+			reservationErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ReservationResponse]{Data: v2.ReservationToResponse(rs)})
+	}
+}
+
+// timeParam is a function that parses the time of the query param name as RFC 3339, the zero
+// time when it is absent and not required, writing the error response and returning false when
+// it is malformed or missing
+func timeParam(w http.ResponseWriter, r *http.Request, name string, required bool) (t time.Time, ok bool) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		if required {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, name+" is required")
+			return
+		}
+		ok = true
+		return
+	}
+	var err error
+	if t, err = time.Parse(time.RFC3339, s); err != nil {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, name+" must be a time as RFC 3339, e.g. 2026-01-02T15:04:05Z")
+		return
+	}
+
+	ok = true
+	return
+}
+
+// reservationErrorV2 is a function that writes the v2 error response of the errors of the reservations of the pool vehicles,
+// the other errors as serviceErrorV2
+func reservationErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrReservationNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrReservationConflict), errors.Is(err, apperrors.ErrReservationState):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidReservationData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidReservation, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
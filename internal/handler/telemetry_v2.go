@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"app/pkg/geo"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// limits of the telemetry ingestion
+const (
+	// TelemetryMaxBatch is the highest number of samples of a request
+	TelemetryMaxBatch = 10000
+	// TelemetryMaxBodyBytes is the largest body of a request
+	TelemetryMaxBodyBytes = 4 << 20
+	// TelemetryMaxLineBytes is the longest line of an NDJSON body
+	TelemetryMaxLineBytes = 4 << 10
+	// TelemetryDefaultTrack is the window of a track until now when from is absent
+	TelemetryDefaultTrack = time.Hour
+	// NearbyDefaultRadius and NearbyMaxRadius are the radius of a search of the nearby vehicles
+	// when absent and its highest value, in km
+	NearbyDefaultRadius = 10.0
+	NearbyMaxRadius     = 500.0
+	// NearbyDefaultLimit and NearbyMaxLimit are the number of nearby vehicles returned when
+	// absent and its highest value
+	NearbyDefaultLimit = 5
+	NearbyMaxLimit     = 100
+)
+
+// NewTelemetryV2 is a function that returns a new instance of TelemetryV2
+func NewTelemetryV2(sv internal.TelemetryService, vehicles internal.VehicleService, reservations internal.ReservationService) *TelemetryV2 {
+	return &TelemetryV2{sv: sv, vehicles: vehicles, reservations: reservations}
+}
+
+// TelemetryV2 is a struct with methods that represent the handlers of the telemetry of the vehicles,
+// /v2/telemetry, /v2/vehicles/{id}/position, /v2/vehicles/{id}/track and /v2/vehicles/nearby
+type TelemetryV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.TelemetryService
+	// vehicles is the service the nearby vehicles are filtered by
+	vehicles internal.VehicleService
+	// reservations is the service the availability of the nearby vehicles is checked by
+	reservations internal.ReservationService
+}
+
+// Ingest is a method that returns a handler for the route POST /v2/telemetry, a batch of samples
+// as NDJSON, one per line, with the content type application/x-ndjson, or as a JSON array.
+// The invalid samples, or NDJSON lines, are rejected by index without failing the others.
+func (h *TelemetryV2) Ingest() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, TelemetryMaxBodyBytes)
+
+		// index is the position in the body of each sample
+		var samples []internal.TelemetrySample
+		var index []int
+		var rejected []internal.IngestRejection
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "application/x-ndjson", "application/ndjson":
+			sc := bufio.NewScanner(r.Body)
+			sc.Buffer(make([]byte, 0, TelemetryMaxLineBytes), TelemetryMaxLineBytes)
+			for line := 0; sc.Scan(); line++ {
+				text := bytes.TrimSpace(sc.Bytes())
+				if len(text) == 0 {
+					continue
+				}
+				if len(samples)+len(rejected) == TelemetryMaxBatch {
+					writeErrorV2(w, r, http.StatusRequestEntityTooLarge, v2.CodeTooLarge, fmt.Sprintf("at most %d samples per request", TelemetryMaxBatch))
+					return
+				}
+				var reqBody v2.TelemetrySampleRequest
+				if err := v2.Decode(bytes.NewReader(text), &reqBody); err != nil {
+					rejected = append(rejected, internal.IngestRejection{Index: line, Reason: "malformed sample: " + err.Error()})
+					continue
+				}
+				samples, index = append(samples, reqBody.ToDomain()), append(index, line)
+			}
+			if err := sc.Err(); err != nil {
+				bodyError(w, r, err)
+				return
+			}
+		case "", "application/json":
+			var reqBody []v2.TelemetrySampleRequest
+			if err := v2.Decode(r.Body, &reqBody); err != nil {
+				bodyError(w, r, err)
+				return
+			}
+			if len(reqBody) > TelemetryMaxBatch {
+				writeErrorV2(w, r, http.StatusRequestEntityTooLarge, v2.CodeTooLarge, fmt.Sprintf("at most %d samples per request", TelemetryMaxBatch))
+				return
+			}
+			for i, value := range reqBody {
+				samples, index = append(samples, value.ToDomain()), append(index, i)
+			}
+		default:
+			writeErrorV2(w, r, http.StatusUnsupportedMediaType, v2.CodeUnsupportedMediaType, "content type must be application/x-ndjson or application/json")
+			return
+		}
+
+		res, err := h.sv.Ingest(r.Context(), samples)
+		if err != nil {
+			telemetryErrorV2(w, r, err)
+			return
+		}
+
+		// the indexes of the service are the ones of the samples parsed
+		for i := range res.Rejected {
+			res.Rejected[i].Index = index[res.Rejected[i].Index]
+		}
+		res.Rejected = append(res.Rejected, rejected...)
+		sort.Slice(res.Rejected, func(i, j int) bool { return res.Rejected[i].Index < res.Rejected[j].Index })
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.IngestResponse]{Data: v2.IngestToResponse(res)})
+	}
+}
+
+// Position is a method that returns a handler for the route GET /v2/vehicles/{id}/position,
+// the latest sample of the vehicle
+func (h *TelemetryV2) Position() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+
+		s, err := h.sv.Latest(r.Context(), vehicleId)
+		if err != nil {
+			telemetryErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.TelemetrySampleResponse]{Data: v2.TelemetrySampleToResponse(s)})
+	}
+}
+
+// Track is a method that returns a handler for the route GET /v2/vehicles/{id}/track?from=&to=,
+// the samples of the vehicle over the window, the last TelemetryDefaultTrack by default, the oldest first
+func (h *TelemetryV2) Track() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		from, ok := timeParam(w, r, "from", false)
+		if !ok {
+			return
+		}
+		to, ok := timeParam(w, r, "to", false)
+		if !ok {
+			return
+		}
+		if to.IsZero() {
+			to = time.Now().UTC()
+		}
+		if from.IsZero() {
+			from = to.Add(-TelemetryDefaultTrack)
+		}
+
+		s, err := h.sv.Track(r.Context(), vehicleId, from, to)
+		if err != nil {
+			telemetryErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.TelemetrySamplesToList(s))
+	}
+}
+
+// Nearby is a method that returns a handler for the route
+// GET /v2/vehicles/nearby?lat=&lon=&radius=&limit=&available_until=&..., the vehicles matching the
+// filters of GET /v2/vehicles nearest to the point by their latest position, the nearest first
+func (h *TelemetryV2) Nearby() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := service.NearbyQuery{Radius: NearbyDefaultRadius, Limit: NearbyDefaultLimit}
+		lat, ok := optionalFloat(r.URL.Query().Get("lat"))
+		if !ok || lat == nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "lat is required as a number")
+			return
+		}
+		lon, ok := optionalFloat(r.URL.Query().Get("lon"))
+		if !ok || lon == nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "lon is required as a number")
+			return
+		}
+		q.Center = geo.Point{Longitude: *lon, Latitude: *lat}
+		radius, ok := optionalFloat(r.URL.Query().Get("radius"))
+		if !ok || (radius != nil && (!(*radius > 0) || *radius > NearbyMaxRadius)) {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, fmt.Sprintf("radius must be a number of km above 0 up to %g", NearbyMaxRadius))
+			return
+		}
+		if radius != nil {
+			q.Radius = *radius
+		}
+		limit, ok := optionalInt(r.URL.Query().Get("limit"))
+		if !ok || (limit != nil && (*limit < 1 || *limit > NearbyMaxLimit)) {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, fmt.Sprintf("limit must be an integer from 1 to %d", NearbyMaxLimit))
+			return
+		}
+		if limit != nil {
+			q.Limit = *limit
+		}
+		if q.AvailableUntil, ok = timeParam(w, r, "available_until", false); !ok {
+			return
+		}
+		if !q.AvailableUntil.IsZero() && !q.AvailableUntil.After(time.Now()) {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "available_until must be in the future")
+			return
+		}
+		if q.Filter, ok = vehicleFilter(w, r); !ok {
+			return
+		}
+
+		n, err := service.FindNearby(r.Context(), h.vehicles, h.sv, h.reservations, q)
+		if err != nil {
+			telemetryErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.NearbyToList(n))
+	}
+}
+
+// Alerts is a method that returns a handler for the route GET /v2/telemetry/alerts?vehicle_id=&from=,
+// the readings above the max speed of their vehicle, the most recent first
+func (h *TelemetryV2) Alerts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var f internal.SpeedAlertFilter
+		id, ok := optionalInt(r.URL.Query().Get("vehicle_id"))
+		if !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "vehicle_id must be an integer")
+			return
+		}
+		if id != nil {
+			f.VehicleId = *id
+		}
+		if f.From, ok = timeParam(w, r, "from", false); !ok {
+			return
+		}
+
+		a, err := h.sv.Alerts(r.Context(), f)
+		if err != nil {
+			telemetryErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.SpeedAlertsToList(a))
+	}
+}
+
+// bodyError is a function that writes the error response of a body that cannot be read,
+// 413 Request Entity Too Large beyond its limit and 400 Bad Request otherwise
+func bodyError(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeErrorV2(w, r, http.StatusRequestEntityTooLarge, v2.CodeTooLarge, fmt.Sprintf("body larger than %d bytes", tooLarge.Limit))
+	case errors.Is(err, bufio.ErrTooLong):
+		writeErrorV2(w, r, http.StatusRequestEntityTooLarge, v2.CodeTooLarge, fmt.Sprintf("line longer than %d bytes", TelemetryMaxLineBytes))
+	default:
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+	}
+}
+
+// telemetryErrorV2 is a function that writes the v2 error response of the errors of the telemetry,
+// the other errors as serviceErrorV2
+func telemetryErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrTelemetryNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidTelemetryData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidTelemetry, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// defaults of the utilization reports
+const (
+	// UtilizationDefaultDays is the number of days of the period, until today, when from is absent
+	UtilizationDefaultDays = 30
+	// UnderusedDefaultUsage is the share of the days with a trip below which a vehicle is underused
+	UnderusedDefaultUsage = 0.2
+)
+
+// NewTripV2 is a function that returns a new instance of TripV2
+func NewTripV2(sv internal.TripService) *TripV2 {
+	return &TripV2{sv: sv}
+}
+
+// TripV2 is a struct with methods that represent the handlers of the trips and the utilization of
+// the vehicles, /v2/vehicles/{id}/trips and /v2/utilization
+type TripV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.TripService
+}
+
+// Trips is a method that returns a handler for the route GET /v2/vehicles/{id}/trips?from=&to=,
+// the trips of the vehicle by start
+func (h *TripV2) Trips() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		f := internal.TripFilter{VehicleId: vehicleId}
+		if f.From, ok = timeParam(w, r, "from", false); !ok {
+			return
+		}
+		if f.To, ok = timeParam(w, r, "to", false); !ok {
+			return
+		}
+
+		t, err := h.sv.FindAll(r.Context(), f)
+		if err != nil {
+			tripErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.TripsToList(t))
+	}
+}
+
+// Record is a method that returns a handler for the route POST /v2/vehicles/{id}/trips
+func (h *TripV2) Record() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		var reqBody v2.TripRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		t := reqBody.ToDomain(vehicleId)
+		t, err := h.sv.Record(r.Context(), &t)
+		if err != nil {
+			tripErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d/trips", vehicleId))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.TripResponse]{Data: v2.TripToResponse(t)})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/vehicles/{id}/trips/{trip}
+func (h *TripV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vehicleId, ok := pathInt(w, r, "id")
+		if !ok {
+			return
+		}
+		id, ok := pathInt(w, r, "trip")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.Delete(r.Context(), vehicleId, id); err != nil {
+			tripErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Utilization is a method that returns a handler for the route GET /v2/utilization?from=&to=,
+// the km, hours and idle days of each vehicle and brand over the period
+func (h *TripV2) Utilization() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, ok := periodParams(w, r)
+		if !ok {
+			return
+		}
+
+		u, err := h.sv.Utilization(r.Context(), from, to)
+		if err != nil {
+			tripErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.UtilizationResponse]{Data: v2.UtilizationToResponse(u)})
+	}
+}
+
+// Underused is a method that returns a handler for the route GET /v2/utilization/underused?from=&to=&min_km=&min_usage=,
+// the vehicles below the thresholds over the period, candidates for sale, the least used first
+func (h *TripV2) Underused() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, ok := periodParams(w, r)
+		if !ok {
+			return
+		}
+		q := r.URL.Query()
+		c := internal.UnderuseCriteria{MinUsage: UnderusedDefaultUsage}
+		if s := q.Get("min_km"); s != "" {
+			km, err := strconv.ParseFloat(s, 64)
+			if err != nil || km < 0 {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "min_km must be a non negative number")
+				return
+			}
+			c.MinDistance = km
+		}
+		if s := q.Get("min_usage"); s != "" {
+			usage, err := strconv.ParseFloat(s, 64)
+			if err != nil || usage < 0 || usage > 1 {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "min_usage must be a number from 0 to 1")
+				return
+			}
+			c.MinUsage = usage
+		}
+
+		u, err := h.sv.Underused(r.Context(), from, to, c)
+		if err != nil {
+			tripErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.UnderusedToList(u))
+	}
+}
+
+// periodParams is a function that parses the days of the query params from and to, by default
+// the UtilizationDefaultDays days until today, writing the error response and returning false
+// when one is malformed
+func periodParams(w http.ResponseWriter, r *http.Request) (from, to time.Time, ok bool) {
+	q := r.URL.Query()
+
+	to = time.Now().UTC()
+	if s := q.Get("to"); s != "" {
+		var err error
+		if to, err = time.Parse(v2.DateLayout, s); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "to must be a day as "+v2.DateLayout)
+			return
+		}
+	}
+	from = to.AddDate(0, 0, 1-UtilizationDefaultDays)
+	if s := q.Get("from"); s != "" {
+		var err error
+		if from, err = time.Parse(v2.DateLayout, s); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "from must be a day as "+v2.DateLayout)
+			return
+		}
+	}
+
+	ok = true
+	return
+}
+
+// tripErrorV2 is a function that writes the v2 error response of the errors of the trips, a driver of a trip included,
+// the other errors as serviceErrorV2
+func tripErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrTripNotFound), errors.Is(err, apperrors.ErrDriverNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrTripConflict), errors.Is(err, apperrors.ErrOdometerDecreased):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidTripData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidTrip, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
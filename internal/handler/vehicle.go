@@ -2,10 +2,11 @@ package handler
 
 import (
 	"app/internal"
+	"app/internal/dto/v1"
 	"app/pkg/apperrors"
-	"encoding/json"
+	"app/pkg/logger"
 	"errors"
-	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -13,24 +14,6 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-// VehicleJSON is a struct that represents a vehicle in JSON format
-type VehicleJSON struct {
-	ID              int     `json:"id"`
-	Brand           string  `json:"brand"`
-	Model           string  `json:"model"`
-	Registration    string  `json:"registration"`
-	Color           string  `json:"color"`
-	FabricationYear int     `json:"year"`
-	Capacity        int     `json:"passengers"`
-	MaxSpeed        float64 `json:"max_speed"`
-	FuelType        string  `json:"fuel_type"`
-	Transmission    string  `json:"transmission"`
-	Weight          float64 `json:"weight"`
-	Height          float64 `json:"height"`
-	Length          float64 `json:"length"`
-	Width           float64 `json:"width"`
-}
-
 // NewVehicleDefault is a function that returns a new instance of VehicleDefault
 func NewVehicleDefault(sv internal.VehicleService) *VehicleDefault {
 	return &VehicleDefault{sv: sv}
@@ -45,32 +28,18 @@ type VehicleDefault struct {
 // GetAll is a method that returns a handler for the route GET /vehicles
 func (h *VehicleDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		v, err := h.sv.FindAll()
+		v, err := h.sv.FindAll(r.Context())
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, nil)
 			return
 		}
 
 		// response
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
 			"data":    data,
@@ -82,11 +51,14 @@ func (h *VehicleDefault) GetTipoCombustivel() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		fuel_type := chi.URLParam(r, "type")
 
-		fmt.Println("Query parans", fuel_type)
+		logger.FromContext(r.Context()).Debug("handler: get by fuel type", slog.String("fuel_type", fuel_type))
 
-		v, err := h.sv.FindTipoCombustivel(fuel_type)
+		v, err := h.sv.FindTipoCombustivel(r.Context(), fuel_type)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Não foram encontrados veículos com esse tipo de combustível.",
@@ -95,6 +67,7 @@ func (h *VehicleDefault) GetTipoCombustivel() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -102,25 +75,7 @@ func (h *VehicleDefault) GetTipoCombustivel() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
@@ -133,11 +88,14 @@ func (h *VehicleDefault) GetTipoCombustivel() http.HandlerFunc {
 func (h *VehicleDefault) DeleteById() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
-		fmt.Println("Query parans", id)
+		logger.FromContext(r.Context()).Debug("handler: delete by id", slog.String("id", id))
 
-		err := h.sv.DeleteById(id)
+		err := h.sv.DeleteById(r.Context(), id)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Veiculo não encontrado",
@@ -172,9 +130,9 @@ func (h *VehicleDefault) UpdateFuel() http.HandlerFunc {
 			return
 		}
 
-		var reqBody internal.UpdateFuel
+		var reqBody v1.UpdateFuelRequest
 
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		err := v1.Decode(r.Body, &reqBody)
 
 		if err != nil {
 			response.JSON(w, http.StatusBadRequest, map[string]any{
@@ -184,9 +142,12 @@ func (h *VehicleDefault) UpdateFuel() http.HandlerFunc {
 			return
 		}
 
-		vh, err := h.sv.UpdateFuel(idInt, reqBody.FuelType)
+		vh, err := h.sv.UpdateFuel(r.Context(), idInt, reqBody.FuelType)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Veículo não encontrado.",
@@ -204,7 +165,7 @@ func (h *VehicleDefault) UpdateFuel() http.HandlerFunc {
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Tipo de combustivel do veículo atualizado",
-			"data":    vh,
+			"data":    v1.VehicleToResponse(vh),
 		})
 
 	}
@@ -215,11 +176,14 @@ func (h *VehicleDefault) GetTransmissionType() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		typeTransmission := chi.URLParam(r, "type")
 
-		fmt.Println("Query parans", typeTransmission)
+		logger.FromContext(r.Context()).Debug("handler: get by transmission", slog.String("transmission", typeTransmission))
 
-		v, err := h.sv.FindByTransmissionType(typeTransmission)
+		v, err := h.sv.FindByTransmissionType(r.Context(), typeTransmission)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrInvalidVehicleData) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhum veículo encontrado com esses critérios.",
@@ -236,6 +200,7 @@ func (h *VehicleDefault) GetTransmissionType() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -243,25 +208,7 @@ func (h *VehicleDefault) GetTransmissionType() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
 			"data":    data,
@@ -276,11 +223,14 @@ func (h *VehicleDefault) GetByColorAndYears() http.HandlerFunc {
 		color := r.URL.Query().Get("color")
 		year := r.URL.Query().Get("year")
 
-		fmt.Println("Query parans", color, year)
+		logger.FromContext(r.Context()).Debug("handler: get by color and year", slog.String("color", color), slog.String("year", year))
 
-		v, err := h.sv.FindByColorAndYears(color, year)
+		v, err := h.sv.FindByColorAndYears(r.Context(), color, year)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleWithCriteria) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhum veículo encontrado com esses critérios.",
@@ -289,6 +239,7 @@ func (h *VehicleDefault) GetByColorAndYears() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -296,26 +247,7 @@ func (h *VehicleDefault) GetByColorAndYears() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
 			"data":    data,
@@ -329,11 +261,14 @@ func (h *VehicleDefault) GetVelocidadeMediaMarca() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		brand := chi.URLParam(r, "brand")
 
-		fmt.Println("Query parans", brand)
+		logger.FromContext(r.Context()).Debug("handler: average speed by brand", slog.String("brand", brand))
 
-		m, err := h.sv.FindVelocidadeMediaMarca(brand)
+		m, err := h.sv.FindVelocidadeMediaMarca(r.Context(), brand)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleBrand) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhuma marca encontrado.",
@@ -342,6 +277,7 @@ func (h *VehicleDefault) GetVelocidadeMediaMarca() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -364,11 +300,18 @@ func (h *VehicleDefault) GetByMarcaAndYearInterval() http.HandlerFunc {
 		start_year := chi.URLParam(r, "start_year")
 		end_year := chi.URLParam(r, "end_year")
 
-		fmt.Println("Query parans", brand, start_year, end_year)
+		logger.FromContext(r.Context()).Debug("handler: get by brand and year interval",
+			slog.String("brand", brand),
+			slog.String("start_year", start_year),
+			slog.String("end_year", end_year),
+		)
 
-		v, err := h.sv.FindByMarcaAndYearInterval(brand, start_year, end_year)
+		v, err := h.sv.FindByMarcaAndYearInterval(r.Context(), brand, start_year, end_year)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleWithCriteria) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhum veículo encontrado com esses critérios.",
@@ -377,6 +320,7 @@ func (h *VehicleDefault) GetByMarcaAndYearInterval() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -384,25 +328,7 @@ func (h *VehicleDefault) GetByMarcaAndYearInterval() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
 			"data":    data,
@@ -414,9 +340,9 @@ func (h *VehicleDefault) GetByMarcaAndYearInterval() http.HandlerFunc {
 func (h *VehicleDefault) Save() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		var reqBody internal.VehicleAttributes
+		var reqBody v1.VehicleRequest
 
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		err := v1.Decode(r.Body, &reqBody)
 
 		if err != nil {
 			response.JSON(w, http.StatusBadRequest, map[string]any{
@@ -426,9 +352,14 @@ func (h *VehicleDefault) Save() http.HandlerFunc {
 			return
 		}
 
-		v, err := h.sv.Save(&reqBody)
+		attrs := reqBody.ToAttributes()
+		// v1 has no custom attributes, the definitions of the tenant are not checked
+		v, err := h.sv.Save(internal.WithoutCustomChecks(r.Context()), &attrs)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleAlreadyExists) {
 				response.JSON(w, http.StatusConflict, map[string]any{
 					"message": "Identificador do veículo já existente",
@@ -436,7 +367,15 @@ func (h *VehicleDefault) Save() http.HandlerFunc {
 				})
 				return
 			}
+			if errors.Is(err, apperrors.ErrInvalidVehicleData) {
+				response.JSON(w, http.StatusBadRequest, map[string]any{
+					"message": "bad request: " + err.Error(),
+					"data":    nil,
+				})
+				return
+			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -446,7 +385,7 @@ func (h *VehicleDefault) Save() http.HandlerFunc {
 
 		response.JSON(w, http.StatusCreated, map[string]any{
 			"message": "Veículo criado com sucesso.",
-			"data":    v,
+			"data":    v1.VehicleToResponse(v),
 		})
 	}
 }
@@ -454,9 +393,9 @@ func (h *VehicleDefault) Save() http.HandlerFunc {
 func (h *VehicleDefault) SaveMultipleVehicles() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		var reqBody []internal.VehicleAttributes
+		var reqBody []v1.VehicleRequest
 
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		err := v1.Decode(r.Body, &reqBody)
 
 		if err != nil {
 			response.JSON(w, http.StatusBadRequest, map[string]any{
@@ -466,8 +405,12 @@ func (h *VehicleDefault) SaveMultipleVehicles() http.HandlerFunc {
 			return
 		}
 
-		v, err := h.sv.SaveMultipleVehicles(&reqBody)
+		attrs := v1.VehicleRequestsToAttributes(reqBody)
+		v, err := h.sv.SaveMultipleVehicles(internal.WithoutCustomChecks(r.Context()), &attrs)
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleAlreadyExists) {
 				response.JSON(w, http.StatusConflict, map[string]any{
 					"message": "Identificador do veículo já existente",
@@ -475,7 +418,15 @@ func (h *VehicleDefault) SaveMultipleVehicles() http.HandlerFunc {
 				})
 				return
 			}
+			if errors.Is(err, apperrors.ErrInvalidVehicleData) {
+				response.JSON(w, http.StatusBadRequest, map[string]any{
+					"message": "bad request: " + err.Error(),
+					"data":    nil,
+				})
+				return
+			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -485,7 +436,7 @@ func (h *VehicleDefault) SaveMultipleVehicles() http.HandlerFunc {
 
 		response.JSON(w, http.StatusCreated, map[string]any{
 			"message": "Veículo criado com sucesso.",
-			"data":    v,
+			"data":    v1.VehiclesToResponse(v),
 		})
 	}
 }
@@ -503,9 +454,12 @@ func (h *VehicleDefault) Patch() http.HandlerFunc {
 			return
 		}
 
-		vehicle, ok := h.sv.FindById(vehicleId)
+		vehicle, ok := h.sv.FindById(r.Context(), vehicleId)
 
 		if ok != nil {
+			if contextError(w, r, ok) {
+				return
+			}
 			if errors.Is(ok, apperrors.ErrVehicleWithCriteria) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhum veículo encontrado com esses critérios.",
@@ -515,25 +469,17 @@ func (h *VehicleDefault) Patch() http.HandlerFunc {
 			}
 		}
 
-		reqBody := internal.VehicleAttributes{
-			Brand:        vehicle.Brand,
-			Model:        vehicle.Model,
-			Registration: vehicle.Registration,
-
-			Color:           vehicle.Color,
-			FabricationYear: vehicle.FabricationYear,
-			Capacity:        vehicle.Capacity,
-			MaxSpeed:        vehicle.MaxSpeed,
-			FuelType:        vehicle.FuelType,
-			Transmission:    vehicle.Transmission,
-			Weight:          vehicle.Weight,
-			Dimensions: internal.Dimensions{
-				Height: vehicle.Height,
-				Width:  vehicle.Weight,
-			},
+		if vehicle.Id == 0 {
+			response.JSON(w, http.StatusNotFound, map[string]any{
+				"message": "Veiculo não encontrado",
+				"data":    nil,
+			})
+			return
 		}
 
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		var reqBody v1.VehiclePatchRequest
+
+		err := v1.Decode(r.Body, &reqBody)
 
 		if err != nil {
 			response.JSON(w, http.StatusBadRequest, map[string]any{
@@ -543,22 +489,43 @@ func (h *VehicleDefault) Patch() http.HandlerFunc {
 			return
 		}
 
-		vh := reqBody.ToDomain()
+		attrs := vehicle.VehicleAttributes
+		reqBody.ApplyTo(&attrs)
+		vh := attrs.ToDomain()
 		vh.Id = vehicleIdInt
 
-		fmt.Println(vh)
+		logger.FromContext(r.Context()).Debug("handler: patch vehicle", slog.Int("id", vh.Id))
 
-		v, err := h.sv.Patch(vh)
+		// the custom attributes of the vehicle are kept as they are
+		v, err := h.sv.Patch(internal.WithoutCustomChecks(r.Context()), vh)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
-				response.JSON(w, http.StatusConflict, map[string]any{
+				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Veiculo não encontrado",
 					"data":    nil,
 				})
 				return
 			}
+			if errors.Is(err, apperrors.ErrVehicleAlreadyExists) {
+				response.JSON(w, http.StatusConflict, map[string]any{
+					"message": "Identificador do veículo já existente",
+					"data":    nil,
+				})
+				return
+			}
+			if errors.Is(err, apperrors.ErrInvalidVehicleData) {
+				response.JSON(w, http.StatusBadRequest, map[string]any{
+					"message": "bad request: " + err.Error(),
+					"data":    nil,
+				})
+				return
+			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -568,7 +535,7 @@ func (h *VehicleDefault) Patch() http.HandlerFunc {
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Velocidade do veículo atualizada com sucesso.",
-			"data":    v,
+			"data":    v1.VehicleToResponse(v),
 		})
 	}
 }
@@ -586,9 +553,12 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 			return
 		}
 
-		_, ok := h.sv.FindById(vehicleId)
+		_, ok := h.sv.FindById(r.Context(), vehicleId)
 
 		if ok != nil {
+			if contextError(w, r, ok) {
+				return
+			}
 			if errors.Is(ok, apperrors.ErrVehicleWithCriteria) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": "Nenhum veículo encontrado com esses critérios.",
@@ -598,9 +568,9 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 			}
 		}
 
-		var reqBody internal.UpdateMaxSpeedRequest
+		var reqBody v1.UpdateMaxSpeedRequest
 
-		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		err := v1.Decode(r.Body, &reqBody)
 
 		if err != nil {
 			response.JSON(w, http.StatusBadRequest, map[string]any{
@@ -610,9 +580,12 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 			return
 		}
 
-		v, err := h.sv.UpdateMaxSpeed(vehicleIdInt, reqBody.MaxSpeed)
+		v, err := h.sv.UpdateMaxSpeed(r.Context(), vehicleIdInt, reqBody.MaxSpeed)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 				response.JSON(w, http.StatusConflict, map[string]any{
 					"message": "Veiculo não encontrado",
@@ -621,6 +594,7 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 				return
 			}
 
+			logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
 			response.JSON(w, http.StatusInternalServerError, map[string]any{
 				"message": err.Error(),
 				"data":    nil,
@@ -630,7 +604,7 @@ func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Velocidade do veículo atualizada com sucesso.",
-			"data":    v,
+			"data":    v1.VehicleToResponse(v),
 		})
 	}
 }
@@ -639,9 +613,12 @@ func (h *VehicleDefault) GetMediaPessoaPorMarca() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		brand := chi.URLParam(r, "brand")
 
-		m, err := h.sv.FindMediaPessoaPorMarca(brand)
+		m, err := h.sv.FindMediaPessoaPorMarca(r.Context(), brand)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleBrand) {
 				response.JSON(w, http.StatusNotFound, map[string]any{
 					"message": " Não foram encontrados veículos dessa marca.",
@@ -669,11 +646,14 @@ func (h *VehicleDefault) GetByDimensions() http.HandlerFunc {
 		lengthParam := r.URL.Query().Get("length")
 		widthParam := r.URL.Query().Get("width")
 
-		fmt.Println(lengthParam, widthParam)
+		logger.FromContext(r.Context()).Debug("handler: get by dimensions", slog.String("length", lengthParam), slog.String("width", widthParam))
 
-		v, err := h.sv.FindByDimenssion(lengthParam, widthParam)
+		v, err := h.sv.FindByDimenssion(r.Context(), lengthParam, widthParam)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 				response.JSON(w, http.StatusBadRequest, map[string]any{
 					"message": "Nenhum veiculo encontrado com essas informacoes",
@@ -688,25 +668,7 @@ func (h *VehicleDefault) GetByDimensions() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Sucesso",
@@ -721,12 +683,15 @@ func (h *VehicleDefault) GetByPeso() http.HandlerFunc {
 		minWeight := r.URL.Query().Get("min")
 		maxWeight := r.URL.Query().Get("max")
 
-		fmt.Println(minWeight, maxWeight)
+		logger.FromContext(r.Context()).Debug("handler: get by weight", slog.String("min", minWeight), slog.String("max", maxWeight))
 
 		// map
-		v, err := h.sv.FindByPeso(minWeight, maxWeight)
+		v, err := h.sv.FindByPeso(r.Context(), minWeight, maxWeight)
 
 		if err != nil {
+			if contextError(w, r, err) {
+				return
+			}
 			if errors.Is(err, apperrors.ErrVehicleNotFound) {
 
 				response.JSON(w, http.StatusNotFound, map[string]any{
@@ -743,26 +708,7 @@ func (h *VehicleDefault) GetByPeso() http.HandlerFunc {
 			return
 		}
 
-		data := make(map[int]VehicleJSON)
-
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
-		}
+		data := v1.VehiclesToResponse(v)
 
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "Success",
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/catalog"
+	"app/internal/repository"
+	"app/internal/service"
+	"app/pkg/plate"
+	"app/pkg/tenant"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestVehicleDefault_Patch is a function that checks the statuses of the v1 patch, the client
+// errors of the registration answered as such and not as internal errors, and the message of
+// the success kept as v1 always answered it
+func TestVehicleDefault_Patch(t *testing.T) {
+	vehicle := func(id int, registration string) internal.Vehicle {
+		return internal.Vehicle{Id: id, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Fiat", Model: "Uno", Registration: registration, Color: "Red", FabricationYear: 2020,
+			Capacity: 5, MaxSpeed: 150, FuelType: "gasoline", Transmission: "manual", Weight: 900,
+			Dimensions: internal.Dimensions{Length: 3.8, Width: 1.6, Height: 1.5},
+		}}
+	}
+
+	cases := map[string]struct {
+		body       string
+		wantStatus int
+	}{
+		"registration kept":     {body: `{"color":"Blue"}`, wantStatus: http.StatusOK},
+		"registration taken":    {body: `{"registration":"ABC1D23"}`, wantStatus: http.StatusConflict},
+		"registration rejected": {body: `{"registration":"ABC1234"}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			// the vehicles 1 and 2 share a registration, as loaded from the seed file
+			rp := repository.NewVehicleMap(map[int]internal.Vehicle{
+				1: vehicle(1, "XYZ9K88"),
+				2: vehicle(2, "XYZ9K88"),
+				3: vehicle(3, "ABC1D23"),
+			})
+			ct, err := catalog.NewBrandMap(nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sv := service.NewVehicleDefault(rp, ct, plate.Policy{plate.Any: {plate.Mercosul}}, nil)
+			rt := chi.NewRouter()
+			rt.Patch("/vehicles/{id}", NewVehicleDefault(sv).Patch())
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, "/vehicles/1", strings.NewReader(c.body))
+			rt.ServeHTTP(w, r)
+
+			if w.Code != c.wantStatus {
+				t.Fatalf("got status %d with body %s, want %d", w.Code, w.Body.String(), c.wantStatus)
+			}
+			if c.wantStatus != http.StatusOK {
+				return
+			}
+			var res struct {
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			if want := "Velocidade do veículo atualizada com sucesso."; res.Message != want {
+				t.Errorf("got message %q, want %q", res.Message, want)
+			}
+		})
+	}
+}
+
+// TestVehicleDefault_CustomAttributes is a function that checks that the v1 writes ignore the
+// custom attributes required by the tenant, v2 rejecting the vehicles without them, and that the
+// v1 responses keep their shape
+func TestVehicleDefault_CustomAttributes(t *testing.T) {
+	const body = `{"brand":"Fiat","model":"Uno","registration":"NEW1A11","color":"Red","year":2020,` +
+		`"passengers":5,"max_speed":150,"fuel_type":"gasoline","transmission":"manual","weight":900,` +
+		`"height":1.5,"length":3.8,"width":1.6}`
+
+	rp := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Fiat", Model: "Uno", Registration: "ABC1D23", Color: "Red", FabricationYear: 2020,
+			Capacity: 5, MaxSpeed: 150, FuelType: "gasoline", Transmission: "manual", Weight: 900,
+			Dimensions: internal.Dimensions{Length: 3.8, Width: 1.6, Height: 1.5},
+			Tags:       []string{"airport"},
+			Custom:     map[string]any{"cost_center": "CC-12"},
+		}},
+	})
+	ct, err := catalog.NewBrandMap(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attributes := repository.NewAttributeMap([]internal.AttributeDefinition{
+		{Tenant: tenant.Default, Name: "cost_center", Type: internal.AttributeString, Required: true},
+	})
+	sv := service.NewVehicleDefault(rp, ct, nil, attributes)
+	rt := chi.NewRouter()
+	rt.Post("/vehicles", NewVehicleDefault(sv).Save())
+	rt.Patch("/vehicles/{id}", NewVehicleDefault(sv).Patch())
+	rt.Post("/v2/vehicles", NewVehicleV2(sv).Create())
+
+	cases := map[string]struct {
+		method, path, body string
+		wantStatus         int
+	}{
+		"v1 create":                {method: http.MethodPost, path: "/vehicles", body: body, wantStatus: http.StatusCreated},
+		"v1 patch":                 {method: http.MethodPatch, path: "/vehicles/1", body: `{"color":"Blue"}`, wantStatus: http.StatusOK},
+		"v2 create without custom": {method: http.MethodPost, path: "/v2/vehicles", body: body, wantStatus: http.StatusUnprocessableEntity},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(c.method, c.path, strings.NewReader(c.body))
+			rt.ServeHTTP(w, r)
+
+			if w.Code != c.wantStatus {
+				t.Fatalf("got status %d with body %s, want %d", w.Code, w.Body.String(), c.wantStatus)
+			}
+			if c.wantStatus >= 300 || !strings.HasPrefix(c.path, "/vehicles") {
+				return
+			}
+			var res struct {
+				Data map[string]any `json:"data"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := res.Data["tags"]; ok {
+				t.Errorf("got tags in %v, want the v1 fields only", res.Data)
+			}
+			if _, ok := res.Data["custom"]; ok {
+				t.Errorf("got custom in %v, want the v1 fields only", res.Data)
+			}
+		})
+	}
+
+	vh, _ := rp.FindById(t.Context(), "1")
+	if vh.Custom["cost_center"] != "CC-12" || len(vh.Tags) != 1 {
+		t.Errorf("got tags %v and custom %v, want the ones of the vehicle kept by the v1 patch", vh.Tags, vh.Custom)
+	}
+}
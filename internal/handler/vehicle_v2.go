@@ -0,0 +1,466 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/internal/service"
+	"app/pkg/apperrors"
+	"app/pkg/logger"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
+)
+
+// NewVehicleV2 is a function that returns a new instance of VehicleV2
+func NewVehicleV2(sv internal.VehicleService) *VehicleV2 {
+	return &VehicleV2{sv: sv}
+}
+
+// VehicleV2 is a struct with methods that represent the handlers of the /v2 vehicle routes
+type VehicleV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.VehicleService
+}
+
+// List is a method that returns a handler for the route GET /v2/vehicles?sort=,
+// the query filters are combined (color and year, brand with year_from and year_to,
+// fuel_type, transmission, length and width as min-max, weight_min and weight_max,
+// passengers_min, tags and custom.name), sorted by id or by the field of sort
+func (h *VehicleV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		order, err := service.ParseVehicleSort(r.URL.Query().Get("sort"))
+		if err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, err.Error())
+			return
+		}
+		filter, ok := vehicleFilter(w, r)
+		if !ok {
+			return
+		}
+
+		v, err := service.FindByFilter(r.Context(), h.sv, filter)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.OrderedVehiclesToList(service.SortVehicles(v, order)))
+	}
+}
+
+// search limits of GET /v2/vehicles/search
+const (
+	// SearchDefaultLimit is the number of results when the limit is absent
+	SearchDefaultLimit = 20
+	// SearchMaxLimit is the highest limit accepted
+	SearchMaxLimit = 100
+)
+
+// Search is a method that returns a handler for the route GET /v2/vehicles/search?q=&limit=,
+// the vehicles whose brand, model, color or registration match the words of q, tolerating
+// accents, unfinished words and typos, the most relevant first
+func (h *VehicleV2) Search() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		query := strings.TrimSpace(q.Get("q"))
+		if query == "" {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "q is required")
+			return
+		}
+		limit := SearchDefaultLimit
+		if s := q.Get("limit"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < 1 || n > SearchMaxLimit {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, fmt.Sprintf("limit must be an integer between 1 and %d", SearchMaxLimit))
+				return
+			}
+			limit = n
+		}
+
+		v, err := h.sv.Search(r.Context(), query, limit)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.MatchesToList(v))
+	}
+}
+
+// Compare is a method that returns a handler for the route GET /v2/vehicles/compare?ids=1,2,3&format=,
+// the vehicles side by side with the best and the worst of the ranked fields, as JSON or, with
+// format=csv, as a CSV with a line per field
+func (h *VehicleV2) Compare() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		format := q.Get("format")
+		if format != "" && format != "json" && format != "csv" {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "format must be json or csv")
+			return
+		}
+		if q.Get("ids") == "" {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "ids is required")
+			return
+		}
+		var ids []int
+		for _, s := range strings.Split(q.Get("ids"), ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "ids must be a comma separated list of integers")
+				return
+			}
+			ids = append(ids, id)
+		}
+
+		c, err := service.Compare(r.Context(), h.sv, ids)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			cw := csv.NewWriter(w)
+			if err := cw.WriteAll(v2.ComparisonToRecords(c)); err != nil {
+				logger.FromContext(r.Context()).Error("handler: writing the comparison", slog.String("error", err.Error()))
+			}
+			return
+		}
+		response.JSON(w, http.StatusOK, v2.Data[v2.ComparisonResponse]{Data: v2.ComparisonToResponse(c)})
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/vehicles/{id}
+func (h *VehicleV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vh, ok := h.find(w, r)
+		if !ok {
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.VehicleResponse]{Data: v2.VehicleToResponse(vh)})
+	}
+}
+
+// GetByRegistration is a method that returns a handler for the route GET /v2/vehicles/registration/{plate},
+// the plate matched without case and separators
+func (h *VehicleV2) GetByRegistration() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vh, err := h.sv.FindByRegistration(r.Context(), chi.URLParam(r, "plate"))
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.VehicleResponse]{Data: v2.VehicleToResponse(vh)})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/vehicles
+func (h *VehicleV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.VehicleRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		attrs := reqBody.ToAttributes()
+		if err := attrs.Validate(); err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalid, err.Error())
+			return
+		}
+
+		vh, err := h.sv.Save(r.Context(), &attrs)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/vehicles/%d", vh.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.VehicleResponse]{Data: v2.VehicleToResponse(vh)})
+	}
+}
+
+// CreateBatch is a method that returns a handler for the route POST /v2/vehicles/batch
+func (h *VehicleV2) CreateBatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody []v2.VehicleRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		attrs := make([]internal.VehicleAttributes, 0, len(reqBody))
+		for i, req := range reqBody {
+			a := req.ToAttributes()
+			if err := a.Validate(); err != nil {
+				writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalid, fmt.Sprintf("vehicle %d: %s", i, err.Error()))
+				return
+			}
+			attrs = append(attrs, a)
+		}
+
+		v, err := h.sv.SaveMultipleVehicles(r.Context(), &attrs)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusCreated, v2.VehiclesToList(v))
+	}
+}
+
+// Patch is a method that returns a handler for the route PATCH /v2/vehicles/{id}
+func (h *VehicleV2) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vh, ok := h.find(w, r)
+		if !ok {
+			return
+		}
+
+		var reqBody v2.VehiclePatchRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+
+		reqBody.ApplyTo(&vh.VehicleAttributes)
+		if err := vh.Validate(); err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalid, err.Error())
+			return
+		}
+
+		vh, err := h.sv.Patch(r.Context(), &vh)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.VehicleResponse]{Data: v2.VehicleToResponse(vh)})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/vehicles/{id}
+func (h *VehicleV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if !isInt(id) {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "id must be an integer")
+			return
+		}
+
+		if err := h.sv.DeleteById(r.Context(), id); err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// BrandStats is a method that returns a handler for the route GET /v2/brands/{brand}/stats
+func (h *VehicleV2) BrandStats() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		brand := chi.URLParam(r, "brand")
+
+		speed, err := h.sv.FindVelocidadeMediaMarca(r.Context(), brand)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+		capacity, err := h.sv.FindMediaPessoaPorMarca(r.Context(), brand)
+		if err != nil {
+			serviceErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.BrandStatsResponse]{Data: v2.BrandStatsResponse{
+			Brand:           brand,
+			AverageSpeed:    speed,
+			AverageCapacity: capacity,
+		}})
+	}
+}
+
+// find is a method that loads the vehicle of the {id} route param, writing the error response
+// and returning false when it is malformed or not found
+func (h *VehicleV2) find(w http.ResponseWriter, r *http.Request) (vh internal.Vehicle, ok bool) {
+	id := chi.URLParam(r, "id")
+	if !isInt(id) {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "id must be an integer")
+		return
+	}
+
+	vh, err := h.sv.FindById(r.Context(), id)
+	if err != nil {
+		serviceErrorV2(w, r, err)
+		return
+	}
+	if vh.Id == 0 {
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, "vehicle not found")
+		return
+	}
+
+	ok = true
+	return
+}
+
+// serviceErrorV2 is a function that writes the v2 response of an error of the vehicle service,
+// and of the errors every v2 route shares: the request context, the vehicles the routes are
+// nested under and the internal errors. Each subsystem maps its own errors before it.
+func serviceErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		// the client is gone, nobody will read the response
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		writeErrorV2(w, r, http.StatusGatewayTimeout, v2.CodeTimeout, "request timed out")
+	case service.IsNotFound(err):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrVehicleAlreadyExists):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidVehicleData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalid, err.Error())
+	default:
+		logger.FromContext(r.Context()).Error("handler: internal error", slog.String("error", err.Error()))
+		writeErrorV2(w, r, http.StatusInternalServerError, v2.CodeInternal, "internal error")
+	}
+}
+
+// writeErrorV2 is a function that writes a v2 error response
+func writeErrorV2(w http.ResponseWriter, _ *http.Request, status int, code, message string) {
+	response.JSON(w, status, v2.NewError(code, message))
+}
+
+// isInt is a function that reports whether s is an integer
+func isInt(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+// vehicleFilter is a function that parses the query filters of the vehicles, writing
+// 400 Bad Request when one is malformed
+func vehicleFilter(w http.ResponseWriter, r *http.Request) (filter service.VehicleFilter, ok bool) {
+	q := r.URL.Query()
+
+	if color := q.Get("color"); color != "" || q.Get("year") != "" {
+		year, err := strconv.Atoi(q.Get("year"))
+		if color == "" || err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "color and year must be informed together, year as an integer")
+			return
+		}
+		filter.Color, filter.Year = color, &year
+	}
+	if brand := q.Get("brand"); brand != "" {
+		filter.Brand = brand
+		if filter.YearFrom, ok = optionalInt(q.Get("year_from")); !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "year_from and year_to must be integers")
+			return
+		}
+		if filter.YearTo, ok = optionalInt(q.Get("year_to")); !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "year_from and year_to must be integers")
+			return
+		}
+	}
+	filter.FuelType = q.Get("fuel_type")
+	filter.Transmission = q.Get("transmission")
+	if length, width := q.Get("length"), q.Get("width"); length != "" || width != "" {
+		filter.Length, ok = parseRange(length)
+		if ok {
+			filter.Width, ok = parseRange(width)
+		}
+		if !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "length and width must be informed together as min-max")
+			return
+		}
+	}
+	if filter.WeightMin, ok = optionalFloat(q.Get("weight_min")); !ok {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "weight_min and weight_max must be numbers")
+		return
+	}
+	if filter.WeightMax, ok = optionalFloat(q.Get("weight_max")); !ok {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "weight_min and weight_max must be numbers")
+		return
+	}
+	if filter.PassengersMin, ok = optionalInt(q.Get("passengers_min")); !ok {
+		writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "passengers_min must be an integer")
+		return
+	}
+	if tags := q.Get("tags"); tags != "" {
+		filter.Tags = strings.Split(tags, ",")
+	}
+	// the custom attributes in the order of their names, so that the error of a malformed one is stable
+	var keys []string
+	for key := range q {
+		if strings.HasPrefix(key, service.CustomPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, service.CustomPrefix)
+		if !internal.AttributeName.MatchString(name) {
+			ok = false
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, fmt.Sprintf("%q is not a custom attribute name", name))
+			return
+		}
+		if filter.Custom == nil {
+			filter.Custom = make(map[string]string)
+		}
+		filter.Custom[name] = q.Get(key)
+	}
+
+	ok = true
+	return
+}
+
+// optionalInt is a function that parses s as an integer, nil when it is empty
+func optionalInt(s string) (n *int, ok bool) {
+	if s == "" {
+		return nil, true
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return
+	}
+	return &i, true
+}
+
+// optionalFloat is a function that parses s as a number, nil when it is empty
+func optionalFloat(s string) (f *float64, ok bool) {
+	if s == "" {
+		return nil, true
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return
+	}
+	return &v, true
+}
+
+// parseRange is a function that parses s as a min-max range of numbers
+func parseRange(s string) (r *service.Range, ok bool) {
+	lower, upper, found := strings.Cut(s, "-")
+	if !found {
+		return
+	}
+	lo, errLo := strconv.ParseFloat(lower, 64)
+	hi, errHi := strconv.ParseFloat(upper, 64)
+	if errLo != nil || errHi != nil {
+		return
+	}
+	return &service.Range{Min: lo, Max: hi}, true
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"app/internal"
+	"app/internal/dto/v2"
+	"app/pkg/apperrors"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+// NewZoneV2 is a function that returns a new instance of ZoneV2
+func NewZoneV2(sv internal.ZoneService) *ZoneV2 {
+	return &ZoneV2{sv: sv}
+}
+
+// ZoneV2 is a struct with methods that represent the handlers of the geofencing zones, /v2/zones
+type ZoneV2 struct {
+	// sv is the service that will be used by the handler
+	sv internal.ZoneService
+}
+
+// List is a method that returns a handler for the route GET /v2/zones
+func (h *ZoneV2) List() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		z, err := h.sv.FindAll(r.Context())
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.ZonesToList(z))
+	}
+}
+
+// Get is a method that returns a handler for the route GET /v2/zones/{zone}
+func (h *ZoneV2) Get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "zone")
+		if !ok {
+			return
+		}
+
+		z, err := h.sv.FindById(r.Context(), id)
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ZoneResponse]{Data: v2.ZoneToResponse(z)})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /v2/zones
+func (h *ZoneV2) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var reqBody v2.ZoneRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		z, err := reqBody.ToDomain()
+		if err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidZone, err.Error())
+			return
+		}
+
+		z, err = h.sv.Save(r.Context(), &z)
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v2/zones/%d", z.Id))
+		response.JSON(w, http.StatusCreated, v2.Data[v2.ZoneResponse]{Data: v2.ZoneToResponse(z)})
+	}
+}
+
+// Replace is a method that returns a handler for the route PUT /v2/zones/{zone}
+func (h *ZoneV2) Replace() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "zone")
+		if !ok {
+			return
+		}
+		var reqBody v2.ZoneRequest
+		if err := v2.Decode(r.Body, &reqBody); err != nil {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "malformed body: "+err.Error())
+			return
+		}
+		z, err := reqBody.ToDomain()
+		if err != nil {
+			writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidZone, err.Error())
+			return
+		}
+		z.Id = id
+
+		z, err = h.sv.Update(r.Context(), &z)
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.Data[v2.ZoneResponse]{Data: v2.ZoneToResponse(z)})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /v2/zones/{zone}
+func (h *ZoneV2) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "zone")
+		if !ok {
+			return
+		}
+
+		if err := h.sv.Delete(r.Context(), id); err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Occupants is a method that returns a handler for the route GET /v2/zones/{zone}/vehicles,
+// the vehicles inside the zone as of their latest sample
+func (h *ZoneV2) Occupants() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, ok := pathInt(w, r, "zone")
+		if !ok {
+			return
+		}
+
+		o, err := h.sv.Occupants(r.Context(), id)
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.ZoneOccupantsToList(o))
+	}
+}
+
+// Events is a method that returns a handler for the route GET /v2/zones/events?zone_id=&vehicle_id=&from=,
+// the entries and exits of the vehicles, the most recent first
+func (h *ZoneV2) Events() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var f internal.ZoneEventFilter
+		zoneId, ok := optionalInt(r.URL.Query().Get("zone_id"))
+		if !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "zone_id must be an integer")
+			return
+		}
+		if zoneId != nil {
+			f.ZoneId = *zoneId
+		}
+		vehicleId, ok := optionalInt(r.URL.Query().Get("vehicle_id"))
+		if !ok {
+			writeErrorV2(w, r, http.StatusBadRequest, v2.CodeBadRequest, "vehicle_id must be an integer")
+			return
+		}
+		if vehicleId != nil {
+			f.VehicleId = *vehicleId
+		}
+		if f.From, ok = timeParam(w, r, "from", false); !ok {
+			return
+		}
+
+		e, err := h.sv.Events(r.Context(), f)
+		if err != nil {
+			zoneErrorV2(w, r, err)
+			return
+		}
+
+		response.JSON(w, http.StatusOK, v2.ZoneEventsToList(e))
+	}
+}
+
+// zoneErrorV2 is a function that writes the v2 error response of the errors of the zones of the geofencing,
+// the other errors as serviceErrorV2
+func zoneErrorV2(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrZoneNotFound):
+		writeErrorV2(w, r, http.StatusNotFound, v2.CodeNotFound, err.Error())
+	case errors.Is(err, apperrors.ErrZoneAlreadyExists):
+		writeErrorV2(w, r, http.StatusConflict, v2.CodeConflict, err.Error())
+	case errors.Is(err, apperrors.ErrInvalidZoneData):
+		writeErrorV2(w, r, http.StatusUnprocessableEntity, v2.CodeInvalidZone, err.Error())
+	default:
+		serviceErrorV2(w, r, err)
+	}
+}
@@ -0,0 +1,70 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NewAttributeJSONFile is a function that returns a new instance of AttributeJSONFile
+func NewAttributeJSONFile(path string) *AttributeJSONFile {
+	return &AttributeJSONFile{
+		path: path,
+	}
+}
+
+// AttributeJSONFile is a struct that implements the AttributeLoader interface
+type AttributeJSONFile struct {
+	// path is the path to the file that contains the attribute definitions in JSON format
+	path string
+}
+
+// AttributeJSON is a struct that represents an attribute definition in JSON format
+type AttributeJSON struct {
+	Tenant   string `json:"tenant"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Allowed  []any  `json:"allowed"`
+}
+
+// Load is a method that loads the attribute definitions
+func (l *AttributeJSONFile) Load() (d []internal.AttributeDefinition, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var attributesJSON []AttributeJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&attributesJSON); err != nil {
+		return
+	}
+
+	// serialize attribute definitions
+	names := make(map[[2]string]struct{})
+	for _, aj := range attributesJSON {
+		def := internal.AttributeDefinition{Tenant: aj.Tenant, Name: aj.Name, Type: aj.Type, Required: aj.Required, Allowed: aj.Allowed}
+		if def.Tenant == "" {
+			err = fmt.Errorf("attribute %s: tenant is required", aj.Name)
+			return
+		}
+		if err = def.Validate(); err != nil {
+			err = fmt.Errorf("attribute %s of %s: %w", aj.Name, aj.Tenant, err)
+			return
+		}
+		key := [2]string{def.Tenant, def.Name}
+		if _, ok := names[key]; ok {
+			err = fmt.Errorf("attribute %s of %s: repeated", aj.Name, aj.Tenant)
+			return
+		}
+		names[key] = struct{}{}
+		d = append(d, def)
+	}
+	return
+}
@@ -0,0 +1,76 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"os"
+)
+
+// NewBrandJSONFile is a function that returns a new instance of BrandJSONFile
+func NewBrandJSONFile(path string) *BrandJSONFile {
+	return &BrandJSONFile{
+		path: path,
+	}
+}
+
+// BrandJSONFile is a struct that implements the BrandLoader interface
+type BrandJSONFile struct {
+	// path is the path to the file that contains the brands in JSON format
+	path string
+}
+
+// BrandJSON is a struct that represents a brand of the catalog in JSON format
+type BrandJSON struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Models  []string `json:"models"`
+}
+
+// Load is a method that loads the brands
+func (l *BrandJSONFile) Load() (b []internal.Brand, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var brandsJSON []BrandJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&brandsJSON); err != nil {
+		return
+	}
+
+	// serialize brands
+	b = make([]internal.Brand, 0, len(brandsJSON))
+	for _, br := range brandsJSON {
+		b = append(b, internal.Brand{Name: br.Name, Aliases: br.Aliases, Models: br.Models})
+	}
+	return
+}
+
+// Save is a method that writes the brands to the file in their order, one per line. The file is
+// replaced atomically, a failure keeps the previous one.
+func (l *BrandJSONFile) Save(b []internal.Brand) (err error) {
+	brandsJSON := make([]BrandJSON, 0, len(b))
+	for _, br := range b {
+		bj := BrandJSON{Name: br.Name, Aliases: br.Aliases, Models: br.Models}
+		// the brands without aliases or models are written with empty lists, as loaded
+		if bj.Aliases == nil {
+			bj.Aliases = []string{}
+		}
+		if bj.Models == nil {
+			bj.Models = []string{}
+		}
+		brandsJSON = append(brandsJSON, bj)
+	}
+
+	buf, err := jsonLines(brandsJSON)
+	if err != nil {
+		return
+	}
+	err = writeFile(l.path, buf)
+	return
+}
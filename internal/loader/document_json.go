@@ -0,0 +1,115 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// NewDocumentJSONFile is a function that returns a new instance of DocumentJSONFile
+func NewDocumentJSONFile(path string) *DocumentJSONFile {
+	return &DocumentJSONFile{
+		path: path,
+	}
+}
+
+// DocumentJSONFile is a struct that implements the DocumentLoader interface
+type DocumentJSONFile struct {
+	// path is the path to the file that contains the documents in JSON format
+	path string
+}
+
+// DocumentJSON is a struct that represents the metadata of a document in JSON format
+type DocumentJSON struct {
+	Id          int       `json:"id"`
+	VehicleId   int       `json:"vehicle_id"`
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	Expiry      *string   `json:"expiry,omitempty"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+	Thumbnail   bool      `json:"thumbnail"`
+}
+
+// Load is a method that loads the documents, none when the file was not written yet
+func (l *DocumentJSONFile) Load() (d []internal.Document, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var documentsJSON []DocumentJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&documentsJSON); err != nil {
+		return
+	}
+
+	// serialize documents
+	for _, dj := range documentsJSON {
+		doc := internal.Document{
+			Id:          dj.Id,
+			VehicleId:   dj.VehicleId,
+			Type:        dj.Type,
+			Name:        dj.Name,
+			ContentType: dj.ContentType,
+			Size:        dj.Size,
+			Checksum:    dj.Checksum,
+			UploadedAt:  dj.UploadedAt,
+			Thumbnail:   dj.Thumbnail,
+		}
+		if dj.Expiry != nil {
+			expiry, e := time.Parse(DateLayout, *dj.Expiry)
+			if e != nil {
+				err = e
+				return
+			}
+			doc.Expiry = &expiry
+		}
+		d = append(d, doc)
+	}
+	return
+}
+
+// Save is a method that writes the documents to the file in their order, one per line. The file is
+// replaced atomically, a failure keeps the previous one.
+func (l *DocumentJSONFile) Save(d []internal.Document) (err error) {
+	documentsJSON := make([]DocumentJSON, 0, len(d))
+	for _, doc := range d {
+		dj := DocumentJSON{
+			Id:          doc.Id,
+			VehicleId:   doc.VehicleId,
+			Type:        doc.Type,
+			Name:        doc.Name,
+			ContentType: doc.ContentType,
+			Size:        doc.Size,
+			Checksum:    doc.Checksum,
+			UploadedAt:  doc.UploadedAt,
+			Thumbnail:   doc.Thumbnail,
+		}
+		if doc.Expiry != nil {
+			expiry := doc.Expiry.Format(DateLayout)
+			dj.Expiry = &expiry
+		}
+		documentsJSON = append(documentsJSON, dj)
+	}
+
+	buf, err := jsonLines(documentsJSON)
+	if err != nil {
+		return
+	}
+	err = writeFile(l.path, buf)
+	return
+}
@@ -0,0 +1,96 @@
+package loader
+
+import (
+	"app/internal"
+	"app/pkg/license"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// NewDriverJSONFile is a function that returns a new instance of DriverJSONFile
+func NewDriverJSONFile(path string) *DriverJSONFile {
+	return &DriverJSONFile{
+		path: path,
+	}
+}
+
+// DriverJSONFile is a struct that implements the DriverLoader interface
+type DriverJSONFile struct {
+	// path is the path to the file that contains the drivers in JSON format
+	path string
+}
+
+// DriverRegistryJSON is a struct that represents the drivers and their assignments in JSON format
+type DriverRegistryJSON struct {
+	Drivers     []DriverJSON     `json:"drivers"`
+	Assignments []AssignmentJSON `json:"assignments"`
+}
+
+// DriverJSON is a struct that represents a driver in JSON format
+type DriverJSON struct {
+	Id                int    `json:"id"`
+	Name              string `json:"name"`
+	LicenseNumber     string `json:"license_number"`
+	LicenseCategories string `json:"license_categories"`
+	LicenseExpiry     string `json:"license_expiry"`
+}
+
+// AssignmentJSON is a struct that represents an assignment in JSON format, end null while it is open
+type AssignmentJSON struct {
+	Id        int        `json:"id"`
+	VehicleId int        `json:"vehicle_id"`
+	DriverId  int        `json:"driver_id"`
+	Start     time.Time  `json:"start"`
+	End       *time.Time `json:"end"`
+}
+
+// Load is a method that loads the drivers and their assignments
+func (l *DriverJSONFile) Load() (r internal.DriverRegistry, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var registryJSON DriverRegistryJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&registryJSON); err != nil {
+		return
+	}
+
+	// serialize drivers and assignments
+	for _, d := range registryJSON.Drivers {
+		categories, e := license.Parse(d.LicenseCategories)
+		if e != nil {
+			err = fmt.Errorf("driver %d: %w", d.Id, e)
+			return
+		}
+		expiry, e := time.Parse(DateLayout, d.LicenseExpiry)
+		if e != nil {
+			err = fmt.Errorf("driver %d: %w", d.Id, e)
+			return
+		}
+		r.Drivers = append(r.Drivers, internal.Driver{
+			Id:                d.Id,
+			Name:              d.Name,
+			LicenseNumber:     d.LicenseNumber,
+			LicenseCategories: categories,
+			LicenseExpiry:     expiry,
+		})
+	}
+	for _, a := range registryJSON.Assignments {
+		r.Assignments = append(r.Assignments, internal.Assignment{
+			Id:        a.Id,
+			VehicleId: a.VehicleId,
+			DriverId:  a.DriverId,
+			Start:     a.Start,
+			End:       a.End,
+		})
+	}
+	return
+}
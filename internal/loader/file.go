@@ -0,0 +1,47 @@
+package loader
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// jsonLines is a function that encodes values as a JSON array, one value per line
+func jsonLines[T any](values []T) (buf []byte, err error) {
+	buf = append(buf, '[')
+	for i, value := range values {
+		b, e := json.Marshal(value)
+		if e != nil {
+			err = e
+			return
+		}
+		if i > 0 {
+			buf = append(buf, ",\n"...)
+		}
+		buf = append(buf, b...)
+	}
+	buf = append(buf, "]\n"...)
+	return
+}
+
+// writeFile is a function that replaces the file of path by buf atomically, writing a sibling
+// temporary file and renaming it over the file, a failure keeps the previous one
+func writeFile(path string, buf []byte) (err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(buf); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	err = os.Rename(tmp.Name(), path)
+	return
+}
@@ -0,0 +1,66 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// NewFuelJSONFile is a function that returns a new instance of FuelJSONFile
+func NewFuelJSONFile(path string) *FuelJSONFile {
+	return &FuelJSONFile{
+		path: path,
+	}
+}
+
+// FuelJSONFile is a struct that implements the FuelLoader interface
+type FuelJSONFile struct {
+	// path is the path to the file that contains the fuel transactions in JSON format
+	path string
+}
+
+// FuelTransactionJSON is a struct that represents a fuel transaction in JSON format
+type FuelTransactionJSON struct {
+	Id        int       `json:"id"`
+	VehicleId int       `json:"vehicle_id"`
+	Time      time.Time `json:"time"`
+	FuelType  string    `json:"fuel_type"`
+	Quantity  float64   `json:"quantity"`
+	Cost      float64   `json:"cost"`
+	Station   string    `json:"station"`
+	Odometer  float64   `json:"odometer"`
+}
+
+// Load is a method that loads the fuel transactions
+func (l *FuelJSONFile) Load() (t []internal.FuelTransaction, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var transactionsJSON []FuelTransactionJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&transactionsJSON); err != nil {
+		return
+	}
+
+	// serialize transactions
+	for _, ft := range transactionsJSON {
+		t = append(t, internal.FuelTransaction{
+			Id:        ft.Id,
+			VehicleId: ft.VehicleId,
+			Time:      ft.Time,
+			FuelType:  ft.FuelType,
+			Quantity:  ft.Quantity,
+			Cost:      ft.Cost,
+			Station:   ft.Station,
+			Odometer:  ft.Odometer,
+		})
+	}
+	return
+}
@@ -0,0 +1,106 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DateLayout is the layout of the days in the JSON files
+const DateLayout = time.DateOnly
+
+// NewMaintenanceJSONFile is a function that returns a new instance of MaintenanceJSONFile
+func NewMaintenanceJSONFile(path string) *MaintenanceJSONFile {
+	return &MaintenanceJSONFile{
+		path: path,
+	}
+}
+
+// MaintenanceJSONFile is a struct that implements the MaintenanceLoader interface
+type MaintenanceJSONFile struct {
+	// path is the path to the file that contains the maintenance in JSON format
+	path string
+}
+
+// MaintenanceJSON is a struct that represents the maintenance of the fleet in JSON format
+type MaintenanceJSON struct {
+	Events []MaintenanceEventJSON `json:"events"`
+	Plans  []MaintenancePlanJSON  `json:"plans"`
+}
+
+// MaintenanceEventJSON is a struct that represents a maintenance event in JSON format
+type MaintenanceEventJSON struct {
+	Id        int     `json:"id"`
+	VehicleId int     `json:"vehicle_id"`
+	Date      string  `json:"date"`
+	Odometer  float64 `json:"odometer"`
+	Cost      float64 `json:"cost"`
+	Type      string  `json:"type"`
+	Workshop  string  `json:"workshop"`
+}
+
+// MaintenancePlanJSON is a struct that represents a maintenance plan in JSON format
+type MaintenancePlanJSON struct {
+	Id            int     `json:"id"`
+	VehicleId     int     `json:"vehicle_id"`
+	Type          string  `json:"type"`
+	IntervalDays  int     `json:"interval_days"`
+	IntervalKm    float64 `json:"interval_km"`
+	StartDate     string  `json:"start_date"`
+	StartOdometer float64 `json:"start_odometer"`
+}
+
+// Load is a method that loads the maintenance events and plans
+func (l *MaintenanceJSONFile) Load() (m internal.MaintenanceLog, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var maintenanceJSON MaintenanceJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&maintenanceJSON); err != nil {
+		return
+	}
+
+	// serialize events and plans
+	for _, e := range maintenanceJSON.Events {
+		date, e2 := time.Parse(DateLayout, e.Date)
+		if e2 != nil {
+			err = fmt.Errorf("maintenance event %d: %w", e.Id, e2)
+			return
+		}
+		m.Events = append(m.Events, internal.MaintenanceEvent{
+			Id:        e.Id,
+			VehicleId: e.VehicleId,
+			Date:      date,
+			Odometer:  e.Odometer,
+			Cost:      e.Cost,
+			Type:      e.Type,
+			Workshop:  e.Workshop,
+		})
+	}
+	for _, p := range maintenanceJSON.Plans {
+		start, e := time.Parse(DateLayout, p.StartDate)
+		if e != nil {
+			err = fmt.Errorf("maintenance plan %d: %w", p.Id, e)
+			return
+		}
+		m.Plans = append(m.Plans, internal.MaintenancePlan{
+			Id:            p.Id,
+			VehicleId:     p.VehicleId,
+			Type:          p.Type,
+			IntervalDays:  p.IntervalDays,
+			IntervalKm:    p.IntervalKm,
+			StartDate:     start,
+			StartOdometer: p.StartOdometer,
+		})
+	}
+	return
+}
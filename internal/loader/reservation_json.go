@@ -0,0 +1,68 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// NewReservationJSONFile is a function that returns a new instance of ReservationJSONFile
+func NewReservationJSONFile(path string) *ReservationJSONFile {
+	return &ReservationJSONFile{
+		path: path,
+	}
+}
+
+// ReservationJSONFile is a struct that implements the ReservationLoader interface
+type ReservationJSONFile struct {
+	// path is the path to the file that contains the reservations in JSON format
+	path string
+}
+
+// ReservationJSON is a struct that represents a reservation in JSON format
+type ReservationJSON struct {
+	Id           int        `json:"id"`
+	VehicleId    int        `json:"vehicle_id"`
+	Requester    string     `json:"requester"`
+	Purpose      string     `json:"purpose"`
+	Start        time.Time  `json:"start"`
+	End          time.Time  `json:"end"`
+	Status       string     `json:"status"`
+	CheckedOutAt *time.Time `json:"checked_out_at"`
+	CheckedInAt  *time.Time `json:"checked_in_at"`
+}
+
+// Load is a method that loads the reservations
+func (l *ReservationJSONFile) Load() (r []internal.Reservation, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var reservationsJSON []ReservationJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&reservationsJSON); err != nil {
+		return
+	}
+
+	// serialize reservations
+	for _, rs := range reservationsJSON {
+		r = append(r, internal.Reservation{
+			Id:           rs.Id,
+			VehicleId:    rs.VehicleId,
+			Requester:    rs.Requester,
+			Purpose:      rs.Purpose,
+			Start:        rs.Start,
+			End:          rs.End,
+			Status:       rs.Status,
+			CheckedOutAt: rs.CheckedOutAt,
+			CheckedInAt:  rs.CheckedInAt,
+		})
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package loader
+
+import (
+	"app/internal"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// NewTripJSONFile is a function that returns a new instance of TripJSONFile
+func NewTripJSONFile(path string) *TripJSONFile {
+	return &TripJSONFile{
+		path: path,
+	}
+}
+
+// TripJSONFile is a struct that implements the TripLoader interface
+type TripJSONFile struct {
+	// path is the path to the file that contains the trips in JSON format
+	path string
+}
+
+// TripJSON is a struct that represents a trip in JSON format, driver_id 0 when unknown
+type TripJSON struct {
+	Id            int       `json:"id"`
+	VehicleId     int       `json:"vehicle_id"`
+	DriverId      int       `json:"driver_id"`
+	Purpose       string    `json:"purpose"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	StartOdometer float64   `json:"start_odometer"`
+	EndOdometer   float64   `json:"end_odometer"`
+}
+
+// Load is a method that loads the trips
+func (l *TripJSONFile) Load() (t []internal.Trip, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var tripsJSON []TripJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&tripsJSON); err != nil {
+		return
+	}
+
+	// serialize trips
+	for _, tr := range tripsJSON {
+		t = append(t, internal.Trip{
+			Id:            tr.Id,
+			VehicleId:     tr.VehicleId,
+			DriverId:      tr.DriverId,
+			Purpose:       tr.Purpose,
+			Start:         tr.Start.UTC(),
+			End:           tr.End.UTC(),
+			StartOdometer: tr.StartOdometer,
+			EndOdometer:   tr.EndOdometer,
+		})
+	}
+	return
+}
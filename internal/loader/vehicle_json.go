@@ -2,8 +2,11 @@ package loader
 
 import (
 	"app/internal"
+	"bytes"
 	"encoding/json"
 	"os"
+	"path/filepath"
+	"sort"
 )
 
 // NewVehicleJSONFile is a function that returns a new instance of VehicleJSONFile
@@ -21,20 +24,22 @@ type VehicleJSONFile struct {
 
 // VehicleJSON is a struct that represents a vehicle in JSON format
 type VehicleJSON struct {
-	Id              int     `json:"id"`
-	Brand           string  `json:"brand"`
-	Model           string  `json:"model"`
-	Registration    string  `json:"registration"`
-	Color           string  `json:"color"`
-	FabricationYear int     `json:"year"`
-	Capacity        int     `json:"passengers"`
-	MaxSpeed        float64 `json:"max_speed"`
-	FuelType        string  `json:"fuel_type"`
-	Transmission    string  `json:"transmission"`
-	Weight          float64 `json:"weight"`
-	Height          float64 `json:"height"`
-	Length          float64 `json:"length"`
-	Width           float64 `json:"width"`
+	Id              int            `json:"id"`
+	Brand           string         `json:"brand"`
+	Model           string         `json:"model"`
+	Registration    string         `json:"registration"`
+	Color           string         `json:"color"`
+	FabricationYear int            `json:"year"`
+	Capacity        int            `json:"passengers"`
+	MaxSpeed        float64        `json:"max_speed"`
+	FuelType        string         `json:"fuel_type"`
+	Transmission    string         `json:"transmission"`
+	Weight          float64        `json:"weight"`
+	Height          float64        `json:"height"`
+	Length          float64        `json:"length"`
+	Width           float64        `json:"width"`
+	Tags            []string       `json:"tags,omitempty"`
+	Custom          map[string]any `json:"custom,omitempty"`
 }
 
 // Load is a method that loads the vehicles
@@ -56,27 +61,97 @@ func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
 	// serialize vehicles
 	v = make(map[int]internal.Vehicle)
 	for _, vh := range vehiclesJSON {
-		v[vh.Id] = internal.Vehicle{
-			Id: vh.Id,
-			VehicleAttributes: internal.VehicleAttributes{
-				Brand:           vh.Brand,
-				Model:           vh.Model,
-				Registration:    vh.Registration,
-				Color:           vh.Color,
-				FabricationYear: vh.FabricationYear,
-				Capacity:        vh.Capacity,
-				MaxSpeed:        vh.MaxSpeed,
-				FuelType:        vh.FuelType,
-				Transmission:    vh.Transmission,
-				Weight:          vh.Weight,
-				Dimensions: internal.Dimensions{
-					Height: vh.Height,
-					Length: vh.Length,
-					Width:  vh.Width,
-				},
-			},
+		v[vh.Id] = vh.ToDomain()
+	}
+
+	return
+}
+
+// Save is a method that writes the vehicles to the file sorted by id, one per line as
+// in the data files. The file is replaced atomically, a failure keeps the previous one.
+func (l *VehicleJSONFile) Save(v map[int]internal.Vehicle) (err error) {
+	ids := make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, id := range ids {
+		b, e := json.Marshal(VehicleJSONFromDomain(v[id]))
+		if e != nil {
+			err = e
+			return
+		}
+		if i > 0 {
+			buf.WriteString(",\n")
 		}
+		buf.Write(b)
 	}
+	buf.WriteString("]\n")
 
+	// write a sibling temporary file and rename it over the file
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	err = os.Rename(tmp.Name(), l.path)
 	return
 }
+
+// ToDomain is a method that maps the vehicle in JSON format to a vehicle
+func (vh VehicleJSON) ToDomain() internal.Vehicle {
+	return internal.Vehicle{
+		Id: vh.Id,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           vh.Brand,
+			Model:           vh.Model,
+			Registration:    vh.Registration,
+			Color:           vh.Color,
+			FabricationYear: vh.FabricationYear,
+			Capacity:        vh.Capacity,
+			MaxSpeed:        vh.MaxSpeed,
+			FuelType:        vh.FuelType,
+			Transmission:    vh.Transmission,
+			Weight:          vh.Weight,
+			Dimensions: internal.Dimensions{
+				Height: vh.Height,
+				Length: vh.Length,
+				Width:  vh.Width,
+			},
+			Tags:   internal.NormalizeTags(vh.Tags),
+			Custom: vh.Custom,
+		},
+	}
+}
+
+// VehicleJSONFromDomain is a function that maps a vehicle to its JSON format
+func VehicleJSONFromDomain(v internal.Vehicle) VehicleJSON {
+	return VehicleJSON{
+		Id:              v.Id,
+		Brand:           v.Brand,
+		Model:           v.Model,
+		Registration:    v.Registration,
+		Color:           v.Color,
+		FabricationYear: v.FabricationYear,
+		Capacity:        v.Capacity,
+		MaxSpeed:        v.MaxSpeed,
+		FuelType:        v.FuelType,
+		Transmission:    v.Transmission,
+		Weight:          v.Weight,
+		Height:          v.Height,
+		Length:          v.Length,
+		Width:           v.Width,
+		Tags:            v.Tags,
+		Custom:          v.Custom,
+	}
+}
@@ -0,0 +1,62 @@
+package loader
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NewZoneJSONFile is a function that returns a new instance of ZoneJSONFile
+func NewZoneJSONFile(path string) *ZoneJSONFile {
+	return &ZoneJSONFile{
+		path: path,
+	}
+}
+
+// ZoneJSONFile is a struct that implements the ZoneLoader interface
+type ZoneJSONFile struct {
+	// path is the path to the file that contains the zones in JSON format
+	path string
+}
+
+// ZoneJSON is a struct that represents a zone in JSON format, its area a GeoJSON polygon
+type ZoneJSON struct {
+	Id   int          `json:"id"`
+	Name string       `json:"name"`
+	Kind string       `json:"kind"`
+	Area geo.Geometry `json:"area"`
+}
+
+// Load is a method that loads the zones
+func (l *ZoneJSONFile) Load() (z []internal.Zone, err error) {
+	// open file
+	file, err := os.Open(l.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// decode file
+	var zonesJSON []ZoneJSON
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&zonesJSON); err != nil {
+		return
+	}
+
+	// serialize zones
+	for _, zj := range zonesJSON {
+		zone := internal.Zone{Id: zj.Id, Name: zj.Name, Kind: zj.Kind}
+		if zone.Area, err = zj.Area.Polygon(); err == nil {
+			err = zone.Validate()
+		}
+		if err != nil {
+			err = fmt.Errorf("zone %d: %w", zj.Id, err)
+			return
+		}
+		z = append(z, zone)
+	}
+	return
+}
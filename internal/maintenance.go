@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaintenanceEvent is a struct that represents a service performed on a vehicle
+type MaintenanceEvent struct {
+	// Id is the unique identifier of the event
+	Id int
+	// VehicleId is the identifier of the vehicle serviced
+	VehicleId int
+	// Date is the day of the service
+	Date time.Time
+	// Odometer is the distance traveled by the vehicle at the service, in km
+	Odometer float64
+	// Cost is the cost of the service
+	Cost float64
+	// Type is the kind of service, e.g. oil change, matched against the plans without case
+	Type string
+	// Workshop is the workshop that performed the service
+	Workshop string
+}
+
+// MaintenancePlan is a struct that represents a recurring service of a vehicle, due every
+// IntervalDays or every IntervalKm, whichever comes first
+type MaintenancePlan struct {
+	// Id is the unique identifier of the plan
+	Id int
+	// VehicleId is the identifier of the vehicle of the plan
+	VehicleId int
+	// Type is the kind of service, the events of this type restart the interval
+	Type string
+	// IntervalDays is the number of days between services, 0 for none
+	IntervalDays int
+	// IntervalKm is the distance between services, 0 for none
+	IntervalKm float64
+	// StartDate and StartOdometer are the start of the first interval, before any event
+	StartDate     time.Time
+	StartOdometer float64
+}
+
+// MaintenanceLog is a struct that represents the maintenance events and plans of the fleet
+type MaintenanceLog struct {
+	// Events are the services performed
+	Events []MaintenanceEvent
+	// Plans are the recurring services
+	Plans []MaintenancePlan
+}
+
+// maintenance statuses
+const (
+	// MaintenanceOverdue is a plan past its due date or distance, the due day itself not overdue
+	MaintenanceOverdue = "overdue"
+	// MaintenanceUpcoming is a plan due within the horizon of the report, today included
+	MaintenanceUpcoming = "upcoming"
+	// MaintenanceScheduled is a plan due after the horizon of the report
+	MaintenanceScheduled = "scheduled"
+)
+
+// MaintenanceDue is a struct that represents when the next service of a plan is due
+type MaintenanceDue struct {
+	// Plan is the recurring service
+	Plan MaintenancePlan
+	// Last is the last event of the type of the plan, nil when there is none
+	Last *MaintenanceEvent
+	// Odometer is the current distance of the vehicle, the highest of its events
+	Odometer float64
+	// DueDate is the day the service is due, nil for a plan without IntervalDays
+	DueDate *time.Time
+	// DueOdometer is the distance the service is due, nil for a plan without IntervalKm
+	DueOdometer *float64
+	// Status is MaintenanceOverdue, MaintenanceUpcoming or MaintenanceScheduled
+	Status string
+}
+
+// MaintenanceHorizon is a struct that represents how early a service is reported as upcoming
+type MaintenanceHorizon struct {
+	// Days before the due date
+	Days int
+	// Km before the due distance
+	Km float64
+}
+
+// MaintenanceTypeKey is a function that returns the key the types of service are matched by
+func MaintenanceTypeKey(t string) string {
+	return strings.ToLower(strings.Join(strings.Fields(t), " "))
+}
+
+// Validate is a method that validates an event to be recorded
+func (e *MaintenanceEvent) Validate() error {
+	if e.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if strings.TrimSpace(e.Type) == "" {
+		return errors.New("type is required")
+	}
+	if e.Odometer < 0 {
+		return errors.New("odometer must not be negative")
+	}
+	if e.Cost < 0 {
+		return errors.New("cost must not be negative")
+	}
+	return nil
+}
+
+// Validate is a method that validates a plan to be saved
+func (p *MaintenancePlan) Validate() error {
+	if strings.TrimSpace(p.Type) == "" {
+		return errors.New("type is required")
+	}
+	if p.IntervalDays < 0 || p.IntervalKm < 0 {
+		return errors.New("intervals must not be negative")
+	}
+	if p.IntervalDays == 0 && p.IntervalKm == 0 {
+		return errors.New("interval_days or interval_km is required")
+	}
+	if p.StartOdometer < 0 {
+		return errors.New("start_odometer must not be negative")
+	}
+	return nil
+}
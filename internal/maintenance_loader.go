@@ -0,0 +1,7 @@
+package internal
+
+// MaintenanceLoader is an interface that represents the loader for the maintenance of the fleet
+type MaintenanceLoader interface {
+	// Load is a method that loads the maintenance events and plans
+	Load() (l MaintenanceLog, err error)
+}
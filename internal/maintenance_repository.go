@@ -0,0 +1,21 @@
+package internal
+
+import "context"
+
+// MaintenanceRepository is an interface that represents a repository of the maintenance events and plans
+type MaintenanceRepository interface {
+	// FindEvents is a method that returns the events of a vehicle, the oldest first
+	FindEvents(ctx context.Context, vehicleId int) (e []MaintenanceEvent, err error)
+	// SaveEvent is a method that records an event, assigning its id
+	SaveEvent(ctx context.Context, e *MaintenanceEvent) (v MaintenanceEvent, err error)
+	// DeleteEvent is a method that removes an event of a vehicle, ErrMaintenanceEventNotFound when there is none
+	DeleteEvent(ctx context.Context, vehicleId, id int) (err error)
+	// FindPlans is a method that returns the plans of a vehicle by id
+	FindPlans(ctx context.Context, vehicleId int) (p []MaintenancePlan, err error)
+	// FindAllPlans is a method that returns the plans of every vehicle, by vehicle and id
+	FindAllPlans(ctx context.Context) (p []MaintenancePlan, err error)
+	// SavePlan is a method that adds a plan, assigning its id
+	SavePlan(ctx context.Context, p *MaintenancePlan) (v MaintenancePlan, err error)
+	// DeletePlan is a method that removes a plan of a vehicle, ErrMaintenancePlanNotFound when there is none
+	DeletePlan(ctx context.Context, vehicleId, id int) (err error)
+}
@@ -0,0 +1,28 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// MaintenanceService is an interface that represents the service of the maintenance of the vehicles,
+// the vehicle of each operation must exist
+type MaintenanceService interface {
+	// FindEvents is a method that returns the service history of a vehicle, the oldest first
+	FindEvents(ctx context.Context, vehicleId int) (e []MaintenanceEvent, err error)
+	// RecordEvent is a method that records a service performed on a vehicle
+	RecordEvent(ctx context.Context, e *MaintenanceEvent) (v MaintenanceEvent, err error)
+	// DeleteEvent is a method that removes an event of a vehicle
+	DeleteEvent(ctx context.Context, vehicleId, id int) (err error)
+	// FindPlans is a method that returns the recurring services of a vehicle
+	FindPlans(ctx context.Context, vehicleId int) (p []MaintenancePlan, err error)
+	// SavePlan is a method that adds a recurring service to a vehicle
+	SavePlan(ctx context.Context, p *MaintenancePlan) (v MaintenancePlan, err error)
+	// DeletePlan is a method that removes a recurring service of a vehicle
+	DeletePlan(ctx context.Context, vehicleId, id int) (err error)
+	// Due is a method that returns when each plan of a vehicle is due at the time at
+	Due(ctx context.Context, vehicleId int, at time.Time, h MaintenanceHorizon) (d []MaintenanceDue, err error)
+	// FleetDue is a method that returns the plans of the fleet overdue or upcoming at the time at,
+	// the overdue first and then by due date
+	FleetDue(ctx context.Context, at time.Time, h MaintenanceHorizon) (d []MaintenanceDue, err error)
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"app/internal"
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewFleetCollector is a function that returns a collector of the fleet size gauges
+func NewFleetCollector(rp internal.VehicleRepository) *FleetCollector {
+	return &FleetCollector{
+		rp: rp,
+		byBrand: prometheus.NewDesc(
+			"fleet_vehicles_by_brand",
+			"Number of vehicles in the fleet by brand.",
+			[]string{"brand"}, nil,
+		),
+		byFuelType: prometheus.NewDesc(
+			"fleet_vehicles_by_fuel_type",
+			"Number of vehicles in the fleet by fuel type.",
+			[]string{"fuel_type"}, nil,
+		),
+		byTransmission: prometheus.NewDesc(
+			"fleet_vehicles_by_transmission",
+			"Number of vehicles in the fleet by transmission.",
+			[]string{"transmission"}, nil,
+		),
+	}
+}
+
+// FleetCollector is a struct that computes the fleet gauges from the repository on each scrape
+type FleetCollector struct {
+	// rp is the repository the fleet is read from
+	rp internal.VehicleRepository
+	// byBrand, byFuelType and byTransmission describe the gauges
+	byBrand        *prometheus.Desc
+	byFuelType     *prometheus.Desc
+	byTransmission *prometheus.Desc
+}
+
+// Describe is a method that sends the descriptors of the gauges
+func (c *FleetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.byBrand
+	ch <- c.byFuelType
+	ch <- c.byTransmission
+}
+
+// Collect is a method that sends the current values of the gauges
+func (c *FleetCollector) Collect(ch chan<- prometheus.Metric) {
+	v, err := c.rp.FindAll(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.byBrand, err)
+		return
+	}
+
+	brands := make(map[string]int)
+	fuelTypes := make(map[string]int)
+	transmissions := make(map[string]int)
+	for _, value := range v {
+		brands[value.Brand]++
+		fuelTypes[value.FuelType]++
+		transmissions[value.Transmission]++
+	}
+
+	for label, count := range brands {
+		ch <- prometheus.MustNewConstMetric(c.byBrand, prometheus.GaugeValue, float64(count), label)
+	}
+	for label, count := range fuelTypes {
+		ch <- prometheus.MustNewConstMetric(c.byFuelType, prometheus.GaugeValue, float64(count), label)
+	}
+	for label, count := range transmissions {
+		ch <- prometheus.MustNewConstMetric(c.byTransmission, prometheus.GaugeValue, float64(count), label)
+	}
+}
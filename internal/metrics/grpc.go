@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// NewGRPCInterceptors is a function that returns the interceptors counting the gRPC calls
+// and observing their latency per method
+func NewGRPCInterceptors(reg prometheus.Registerer) (unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) {
+	handled := promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total of gRPC calls by method and status code.",
+	}, []string{"method", "code"})
+	duration := promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of gRPC calls by method, until the last message of the streams.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	observe := func(method string, start time.Time, err error) {
+		handled.WithLabelValues(method, status.Code(err).String()).Inc()
+		duration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	}
+
+	unary = func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		resp, err = handler(ctx, req)
+		observe(info.FullMethod, start, err)
+		return
+	}
+	stream = func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		start := time.Now()
+		err = handler(srv, ss)
+		observe(info.FullMethod, start, err)
+		return
+	}
+	return
+}
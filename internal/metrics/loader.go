@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"app/internal"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// NewVehicleLoader is a function that returns a loader decorator recording the load duration and records
+func NewVehicleLoader(ld internal.VehicleLoader, reg prometheus.Registerer) *VehicleLoader {
+	return &VehicleLoader{
+		ld: ld,
+		duration: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "vehicle_loader_duration_seconds",
+			Help: "Duration of the last load of vehicles.",
+		}),
+		records: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "vehicle_loader_records",
+			Help: "Number of vehicles read by the last load.",
+		}),
+		errors: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "vehicle_loader_errors_total",
+			Help: "Total of failed loads of vehicles.",
+		}),
+	}
+}
+
+// VehicleLoader is a struct that decorates a VehicleLoader with metrics
+type VehicleLoader struct {
+	// ld is the decorated loader
+	ld internal.VehicleLoader
+	// duration, records and errors are the collected metrics
+	duration prometheus.Gauge
+	records  prometheus.Gauge
+	errors   prometheus.Counter
+}
+
+// Load is a method that loads the vehicles
+func (l *VehicleLoader) Load() (v map[int]internal.Vehicle, err error) {
+	start := time.Now()
+	v, err = l.ld.Load()
+	l.duration.Set(time.Since(start).Seconds())
+	if err != nil {
+		l.errors.Inc()
+		return
+	}
+	l.records.Set(float64(len(v)))
+	return
+}
@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"app/internal"
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// NewVehicleRepository is a function that returns a repository decorator recording the duration of each operation
+func NewVehicleRepository(rp internal.VehicleRepository, reg prometheus.Registerer) *VehicleRepository {
+	return &VehicleRepository{
+		rp: rp,
+		duration: promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vehicle_repository_operation_duration_seconds",
+			Help:    "Duration of the vehicle repository operations by method and outcome.",
+			Buckets: []float64{.00001, .00005, .0001, .0005, .001, .005, .01, .05, .1},
+		}, []string{"method", "outcome"}),
+	}
+}
+
+// VehicleRepository is a struct that decorates a VehicleRepository with metrics
+type VehicleRepository struct {
+	// rp is the decorated repository
+	rp internal.VehicleRepository
+	// duration is the histogram of the operations
+	duration *prometheus.HistogramVec
+}
+
+// observe is a method that records the duration of the operation started at start
+func (r *VehicleRepository) observe(method string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	r.duration.WithLabelValues(method, outcome).Observe(time.Since(start).Seconds())
+}
+
+// FindAll is a method that decorates the repository FindAll
+func (r *VehicleRepository) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindAll", start, err) }(time.Now())
+	return r.rp.FindAll(ctx)
+}
+
+// Save is a method that decorates the repository Save
+func (r *VehicleRepository) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("Save", start, err) }(time.Now())
+	return r.rp.Save(ctx, vh)
+}
+
+// SaveBatch is a method that decorates the repository SaveBatch
+func (r *VehicleRepository) SaveBatch(ctx context.Context, vh []internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("SaveBatch", start, err) }(time.Now())
+	return r.rp.SaveBatch(ctx, vh)
+}
+
+// FindByColorAndYears is a method that decorates the repository FindByColorAndYears
+func (r *VehicleRepository) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByColorAndYears", start, err) }(time.Now())
+	return r.rp.FindByColorAndYears(ctx, color, year)
+}
+
+// FindByMarcaAndYearInterval is a method that decorates the repository FindByMarcaAndYearInterval
+func (r *VehicleRepository) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByMarcaAndYearInterval", start, err) }(time.Now())
+	return r.rp.FindByMarcaAndYearInterval(ctx, brand, start_year, end_year)
+}
+
+// FindVelocidadeMediaMarca is a method that decorates the repository FindVelocidadeMediaMarca
+func (r *VehicleRepository) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	defer func(start time.Time) { r.observe("FindVelocidadeMediaMarca", start, err) }(time.Now())
+	return r.rp.FindVelocidadeMediaMarca(ctx, brand)
+}
+
+// FindByTransmissionType is a method that decorates the repository FindByTransmissionType
+func (r *VehicleRepository) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByTransmissionType", start, err) }(time.Now())
+	return r.rp.FindByTransmissionType(ctx, typeTransmission)
+}
+
+// FindByDimenssion is a method that decorates the repository FindByDimenssion
+func (r *VehicleRepository) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByDimenssion", start, err) }(time.Now())
+	return r.rp.FindByDimenssion(ctx, lengthParam, widthParam)
+}
+
+// FindByPeso is a method that decorates the repository FindByPeso
+func (r *VehicleRepository) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByPeso", start, err) }(time.Now())
+	return r.rp.FindByPeso(ctx, min, max)
+}
+
+// FindMediaPessoaPorMarca is a method that decorates the repository FindMediaPessoaPorMarca
+func (r *VehicleRepository) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	defer func(start time.Time) { r.observe("FindMediaPessoaPorMarca", start, err) }(time.Now())
+	return r.rp.FindMediaPessoaPorMarca(ctx, brand)
+}
+
+// FindTipoCombustivel is a method that decorates the repository FindTipoCombustivel
+func (r *VehicleRepository) FindTipoCombustivel(ctx context.Context, typeFuel string) (v map[int]internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindTipoCombustivel", start, err) }(time.Now())
+	return r.rp.FindTipoCombustivel(ctx, typeFuel)
+}
+
+// FindById is a method that decorates the repository FindById
+func (r *VehicleRepository) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindById", start, err) }(time.Now())
+	return r.rp.FindById(ctx, id)
+}
+
+// Patch is a method that decorates the repository Patch
+func (r *VehicleRepository) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("Patch", start, err) }(time.Now())
+	return r.rp.Patch(ctx, vh)
+}
+
+// UpdateMaxSpeed is a method that decorates the repository UpdateMaxSpeed
+func (r *VehicleRepository) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("UpdateMaxSpeed", start, err) }(time.Now())
+	return r.rp.UpdateMaxSpeed(ctx, id, maxSpeed)
+}
+
+// UpdateFuel is a method that decorates the repository UpdateFuel
+func (r *VehicleRepository) UpdateFuel(ctx context.Context, id int, fuelType string) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("UpdateFuel", start, err) }(time.Now())
+	return r.rp.UpdateFuel(ctx, id, fuelType)
+}
+
+// DeleteById is a method that decorates the repository DeleteById
+func (r *VehicleRepository) DeleteById(ctx context.Context, id string) (err error) {
+	defer func(start time.Time) { r.observe("DeleteById", start, err) }(time.Now())
+	return r.rp.DeleteById(ctx, id)
+}
+
+// Search is a method that decorates the repository Search
+func (r *VehicleRepository) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	defer func(start time.Time) { r.observe("Search", start, err) }(time.Now())
+	return r.rp.Search(ctx, query, limit)
+}
+
+// FindByRegistration is a method that decorates the repository FindByRegistration
+func (r *VehicleRepository) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	defer func(start time.Time) { r.observe("FindByRegistration", start, err) }(time.Now())
+	return r.rp.FindByRegistration(ctx, registration)
+}
+
+// Canonicalize is a method that decorates the repository Canonicalize
+func (r *VehicleRepository) Canonicalize(ctx context.Context, ct internal.BrandCatalog) (n int, err error) {
+	defer func(start time.Time) { r.observe("Canonicalize", start, err) }(time.Now())
+	return r.rp.Canonicalize(ctx, ct)
+}
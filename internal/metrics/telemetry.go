@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// NewTelemetryService is a function that returns a service decorator counting the samples ingested and the speed alerts
+func NewTelemetryService(sv internal.TelemetryService, reg prometheus.Registerer) *TelemetryService {
+	return &TelemetryService{
+		sv: sv,
+		samples: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "telemetry_samples_total",
+			Help: "Total of telemetry samples ingested by outcome.",
+		}, []string{"outcome"}),
+		alerts: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "telemetry_speed_alerts_total",
+			Help: "Total of telemetry samples above the max speed of their vehicle.",
+		}),
+	}
+}
+
+// TelemetryService is a struct that decorates a TelemetryService with metrics
+type TelemetryService struct {
+	// sv is the decorated service
+	sv internal.TelemetryService
+	// samples is the counter of the samples, alerts the one of the speed alerts
+	samples *prometheus.CounterVec
+	alerts  prometheus.Counter
+}
+
+// Ingest is a method that decorates the service Ingest
+func (s *TelemetryService) Ingest(ctx context.Context, samples []internal.TelemetrySample) (r internal.IngestResult, err error) {
+	r, err = s.sv.Ingest(ctx, samples)
+	if err != nil {
+		return
+	}
+	s.samples.WithLabelValues("accepted").Add(float64(r.Accepted))
+	s.samples.WithLabelValues("rejected").Add(float64(len(r.Rejected)))
+	s.alerts.Add(float64(len(r.Alerts)))
+	return
+}
+
+// Latest is a method that decorates the service Latest
+func (s *TelemetryService) Latest(ctx context.Context, vehicleId int) (v internal.TelemetrySample, err error) {
+	return s.sv.Latest(ctx, vehicleId)
+}
+
+// Track is a method that decorates the service Track
+func (s *TelemetryService) Track(ctx context.Context, vehicleId int, from, to time.Time) (v []internal.TelemetrySample, err error) {
+	return s.sv.Track(ctx, vehicleId, from, to)
+}
+
+// Nearby is a method that decorates the service Nearby
+func (s *TelemetryService) Nearby(ctx context.Context, center geo.Point, radius float64) (n []internal.NearbySample, err error) {
+	return s.sv.Nearby(ctx, center, radius)
+}
+
+// Alerts is a method that decorates the service Alerts
+func (s *TelemetryService) Alerts(ctx context.Context, f internal.SpeedAlertFilter) (a []internal.SpeedAlert, err error) {
+	return s.sv.Alerts(ctx, f)
+}
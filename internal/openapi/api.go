@@ -0,0 +1,1606 @@
+package openapi
+
+import (
+	"app/internal"
+	"app/internal/dto/v1"
+	"app/internal/dto/v2"
+	"app/internal/handler"
+	"app/internal/service"
+	"app/pkg/tenant"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NewAPIDocument is a function that returns the document describing every route of the application
+func NewAPIDocument() *Document {
+	doc := NewDocument("go-api-rest", "1.0.0", "Fleet of vehicles API.")
+
+	// schemas
+	doc.Schema("Vehicle", v1.VehicleResponse{})
+	doc.Schema("VehicleRequest", v1.VehicleRequest{})
+	doc.Schema("VehiclePatchRequest", v1.VehiclePatchRequest{})
+	doc.Schema("UpdateMaxSpeedRequest", v1.UpdateMaxSpeedRequest{})
+	doc.Schema("UpdateFuelRequest", v1.UpdateFuelRequest{})
+	doc.Components.Schemas["Error"] = Object(map[string]*Schema{
+		"message": {Type: "string"},
+		"data":    {Type: "null"},
+	})
+	doc.Schema("ErrorV2", v2.Error{})
+	doc.Schema("BrandStats", v2.BrandStatsResponse{})
+
+	// operations
+	describeOperations(doc)
+	describeV1(doc, "", "")
+	describeV1(doc, "/v1", "V1")
+	describeV2(doc)
+	describeCatalog(doc)
+	describeMaintenance(doc)
+	describeDrivers(doc)
+	describeReservations(doc)
+	describeTrips(doc)
+	describeFuel(doc)
+	describeTelemetry(doc)
+	describeZones(doc)
+	describeDocuments(doc)
+	describeAttributes(doc)
+	describeGraphQL(doc)
+
+	return doc
+}
+
+// describeOperations is a function that describes the probes and tooling routes
+func describeOperations(doc *Document) {
+	doc.Add(http.MethodGet, "/healthz", &Operation{
+		OperationID: "healthz",
+		Summary:     "Liveness probe",
+		Tags:        []string{"operations"},
+		Responses: Responses(map[int]Response{
+			http.StatusOK: JSON("The process is alive", Object(map[string]*Schema{"status": {Type: "string"}})),
+		}),
+	})
+	doc.Add(http.MethodGet, "/readyz", &Operation{
+		OperationID: "readyz",
+		Summary:     "Readiness probe, ready once the vehicles are loaded and until the shutdown starts",
+		Tags:        []string{"operations"},
+		Responses: Responses(map[int]Response{
+			http.StatusOK:                 JSON("Ready", Object(map[string]*Schema{"status": {Type: "string"}})),
+			http.StatusServiceUnavailable: JSON("Loading, or draining on shutdown", Object(map[string]*Schema{"status": {Type: "string"}})),
+		}),
+	})
+	doc.Add(http.MethodGet, "/metrics", &Operation{
+		OperationID: "metrics",
+		Summary:     "Prometheus metrics",
+		Tags:        []string{"operations"},
+		Responses: Responses(map[int]Response{
+			http.StatusOK: {Description: "Metrics in the Prometheus text format", Content: map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}}},
+		}),
+	})
+	doc.Add(http.MethodGet, "/openapi.json", &Operation{
+		OperationID: "openapi",
+		Summary:     "This document",
+		Tags:        []string{"operations"},
+		Responses: Responses(map[int]Response{
+			http.StatusOK: JSON("OpenAPI document", &Schema{Type: "object"}),
+		}),
+	})
+	doc.Add(http.MethodGet, "/docs", &Operation{
+		OperationID: "docs",
+		Summary:     "Docs UI of this document",
+		Tags:        []string{"operations"},
+		Responses: Responses(map[int]Response{
+			http.StatusOK: {Description: "HTML page", Content: map[string]MediaType{"text/html": {Schema: &Schema{Type: "string"}}}},
+		}),
+	})
+}
+
+// describeV1 is a function that describes the deprecated v1 routes under prefix,
+// suffixing their operation ids
+func describeV1(doc *Document, prefix, suffix string) {
+	vehicle := Ref("Vehicle")
+	vehicleRequest := Ref("VehicleRequest")
+	vehiclePatch := Ref("VehiclePatchRequest")
+	updateMaxSpeed := Ref("UpdateMaxSpeedRequest")
+	updateFuel := Ref("UpdateFuelRequest")
+	errorBody := Ref("Error")
+
+	// responses
+	envelope := func(data *Schema) *Schema {
+		return Object(map[string]*Schema{
+			"message": {Type: "string"},
+			"data":    data,
+		})
+	}
+	vehicles := JSON("Vehicles by id", envelope(&Schema{Type: "object", AdditionalProperties: vehicle}))
+	fail := func(description string) Response {
+		return JSON(description, errorBody)
+	}
+	// - every vehicle route may time out or be called while the loader runs
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	brand := PathParam("brand", "Brand of the vehicle, case insensitive")
+	rangeParam := func(name, description string) Parameter {
+		return QueryParam(name, description, true, &Schema{
+			Type:    "string",
+			Pattern: `^\d+(\.\d+)?-\d+(\.\d+)?$`,
+			Example: "100-250",
+		})
+	}
+
+	doc.Add(http.MethodGet, prefix+"/vehicles", &Operation{
+		OperationID: "getVehicles" + suffix,
+		Deprecated:  true,
+		Summary:     "List all the vehicles",
+		Tags:        []string{"vehicles"},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  vehicles,
+			http.StatusInternalServerError: {Description: "Internal error"},
+		}),
+	})
+	doc.Add(http.MethodPost, prefix+"/vehicles", &Operation{
+		OperationID: "createVehicle" + suffix,
+		Deprecated:  true,
+		Summary:     "Create a vehicle",
+		Tags:        []string{"vehicles"},
+		RequestBody: JSONBody(vehicleRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Vehicle created", envelope(vehicle)),
+			http.StatusBadRequest:          fail("Malformed body, unknown fields or registration not accepted"),
+			http.StatusConflict:            fail("Registration already exists"),
+			http.StatusInternalServerError: fail("Invalid vehicle or internal error"),
+		}),
+	})
+	doc.Add(http.MethodPost, prefix+"/vehicles/batch", &Operation{
+		OperationID: "createVehicles" + suffix,
+		Deprecated:  true,
+		Summary:     "Create many vehicles",
+		Tags:        []string{"vehicles"},
+		RequestBody: JSONBody(&Schema{Type: "array", Items: vehicleRequest}),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Vehicles created", envelope(&Schema{Type: "object", AdditionalProperties: vehicle})),
+			http.StatusBadRequest:          fail("Malformed body, unknown fields or registration not accepted"),
+			http.StatusConflict:            fail("Registration already exists"),
+			http.StatusInternalServerError: fail("Invalid vehicle or internal error"),
+		}),
+	})
+	doc.Add(http.MethodPatch, prefix+"/vehicles/{id}", &Operation{
+		OperationID: "patchVehicle" + suffix,
+		Deprecated:  true,
+		Summary:     "Update the attributes present in the body",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(vehiclePatch),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicle updated", envelope(vehicle)),
+			http.StatusBadRequest:          fail("Malformed body, unknown fields or registration not accepted"),
+			http.StatusNotFound:            fail("Malformed id or vehicle not found, also when deleted while saving"),
+			http.StatusConflict:            fail("Registration already exists"),
+			http.StatusInternalServerError: fail("Invalid vehicle or internal error"),
+		}),
+	})
+	doc.Add(http.MethodDelete, prefix+"/vehicles/{id}", &Operation{
+		OperationID: "deleteVehicle" + suffix,
+		Deprecated:  true,
+		Summary:     "Delete a vehicle",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Vehicle deleted", Object(map[string]*Schema{"message": {Type: "string"}})),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPatch, prefix+"/vehicles/{id}/update_speed", &Operation{
+		OperationID: "updateVehicleMaxSpeed" + suffix,
+		Deprecated:  true,
+		Summary:     "Update the maximum speed of a vehicle",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(updateMaxSpeed),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicle updated", envelope(vehicle)),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusNotFound:            fail("Malformed id"),
+			http.StatusConflict:            fail("Vehicle not found"),
+			http.StatusInternalServerError: fail("Internal error"),
+		}),
+	})
+	doc.Add(http.MethodPatch, prefix+"/vehicles/{id}/update_fuel", &Operation{
+		OperationID: "updateVehicleFuel" + suffix,
+		Deprecated:  true,
+		Summary:     "Update the fuel type of a vehicle",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(updateFuel),
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Vehicle updated", envelope(vehicle)),
+			http.StatusBadRequest: fail("Malformed id, body or unknown fields"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/brand/{brand}/between/{start_year}/{end_year}", &Operation{
+		OperationID: "getVehiclesByBrandAndYears" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles of a brand made between two years",
+		Tags:        []string{"vehicles"},
+		Parameters: []Parameter{
+			brand,
+			PathParam("start_year", "First fabrication year, inclusive"),
+			PathParam("end_year", "Last fabrication year, inclusive"),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  vehicles,
+			http.StatusNotFound:            fail("No vehicle found"),
+			http.StatusInternalServerError: fail("Malformed years or internal error"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/average_speed/brand/{brand}", &Operation{
+		OperationID: "getAverageSpeedByBrand" + suffix,
+		Deprecated:  true,
+		Summary:     "Average maximum speed of the vehicles of a brand",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{brand},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Average speed", envelope(&Schema{Type: "number"})),
+			http.StatusNotFound:            fail("Brand not found"),
+			http.StatusInternalServerError: fail("Internal error"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/average_capacity/brand/{brand}", &Operation{
+		OperationID: "getAverageCapacityByBrand" + suffix,
+		Deprecated:  true,
+		Summary:     "Average passenger capacity of the vehicles of a brand",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{brand},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Average capacity", envelope(&Schema{Type: "integer"})),
+			http.StatusNotFound:   fail("Brand not found"),
+			http.StatusBadRequest: fail("Internal error"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/fuel_type/{type}", &Operation{
+		OperationID: "getVehiclesByFuelType" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles of a fuel type",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{PathParam("type", "Fuel type, e.g. diesel")},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  vehicles,
+			http.StatusNotFound:            fail("No vehicle found"),
+			http.StatusInternalServerError: fail("Internal error"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/transmission/{type}", &Operation{
+		OperationID: "getVehiclesByTransmission" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles of a transmission type",
+		Tags:        []string{"vehicles"},
+		Parameters:  []Parameter{PathParam("type", "Transmission, e.g. automatic")},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  vehicles,
+			http.StatusNotFound:            fail("No vehicle found"),
+			http.StatusInternalServerError: fail("Internal error"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/dimensions", &Operation{
+		OperationID: "getVehiclesByDimensions" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles within a length and a width range",
+		Tags:        []string{"vehicles"},
+		Parameters: []Parameter{
+			rangeParam("length", "Length range as min-max"),
+			rangeParam("width", "Width range as min-max"),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         vehicles,
+			http.StatusBadRequest: fail("Malformed ranges or no vehicle found"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehicles/weight", &Operation{
+		OperationID: "getVehiclesByWeight" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles within a weight range",
+		Tags:        []string{"vehicles"},
+		Parameters: []Parameter{
+			QueryParam("min", "Minimum weight", true, &Schema{Type: "number"}),
+			QueryParam("max", "Maximum weight", true, &Schema{Type: "number"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         vehicles,
+			http.StatusNotFound:   fail("No vehicle found"),
+			http.StatusBadRequest: fail("Malformed weights"),
+		}),
+	})
+	doc.Add(http.MethodGet, prefix+"/vehiclesc", &Operation{
+		OperationID: "getVehiclesByColorAndYear" + suffix,
+		Deprecated:  true,
+		Summary:     "List the vehicles of a color made in a year",
+		Tags:        []string{"vehicles"},
+		Parameters: []Parameter{
+			QueryParam("color", "Color, case sensitive", true, &Schema{Type: "string"}),
+			QueryParam("year", "Fabrication year", true, &Schema{Type: "integer"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  vehicles,
+			http.StatusNotFound:            fail("No vehicle found"),
+			http.StatusInternalServerError: fail("Malformed year or internal error"),
+		}),
+	})
+}
+
+// vehicleFilterParams is a function that returns the query filters of the vehicles of v2
+func vehicleFilterParams() []Parameter {
+	query := func(name, description string, schema *Schema) Parameter {
+		return QueryParam(name, description, false, schema)
+	}
+	rangeSchema := &Schema{Type: "string", Pattern: `^\d+(\.\d+)?-\d+(\.\d+)?$`, Example: "100-250"}
+
+	return []Parameter{
+		query("color", "Color, requires year", &Schema{Type: "string"}),
+		query("year", "Fabrication year, requires color", &Schema{Type: "integer"}),
+		query("brand", "Brand, case insensitive", &Schema{Type: "string"}),
+		query("year_from", "First fabrication year of the brand filter", &Schema{Type: "integer"}),
+		query("year_to", "Last fabrication year of the brand filter", &Schema{Type: "integer"}),
+		query("fuel_type", "Fuel type", &Schema{Type: "string"}),
+		query("transmission", "Transmission", &Schema{Type: "string"}),
+		query("length", "Length range as min-max, requires width", rangeSchema),
+		query("width", "Width range as min-max, requires length", rangeSchema),
+		query("weight_min", "Minimum weight", &Schema{Type: "number"}),
+		query("weight_max", "Maximum weight", &Schema{Type: "number"}),
+		query("passengers_min", "Minimum capacity of passengers", &Schema{Type: "integer"}),
+		query("tags", "Comma separated tags the vehicles all have", &Schema{Type: "string", Example: "airport,night-shift"}),
+	}
+}
+
+// customFilterDescription is the description of the query filters of the custom attributes of the vehicles
+const customFilterDescription = "A custom attribute of the tenant is filtered as custom.name=value, the strings " +
+	"compared without case, e.g. custom.cost_center=CC-12."
+
+// describeV2 is a function that describes the v2 routes
+func describeV2(doc *Document) {
+	vehicleData := doc.Schema("VehicleData", v2.Data[v2.VehicleResponse]{})
+	vehicleList := doc.Schema("VehicleList", v2.List[v2.VehicleResponse]{})
+	brandStats := doc.Schema("BrandStatsData", v2.Data[v2.BrandStatsResponse]{})
+	matchList := doc.Schema("VehicleMatchList", v2.List[v2.MatchResponse]{})
+	comparisonData := doc.Schema("VehicleComparisonData", v2.Data[v2.ComparisonResponse]{})
+	vehicleRequest := doc.Schema("VehicleRequestV2", v2.VehicleRequest{})
+	vehiclePatch := doc.Schema("VehiclePatchRequestV2", v2.VehiclePatchRequest{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	query := func(name, description string, schema *Schema) Parameter {
+		return QueryParam(name, description, false, schema)
+	}
+	doc.Add(http.MethodGet, "/v2/vehicles", &Operation{
+		OperationID: "listVehiclesV2",
+		Summary:     "List the vehicles sorted by id, or by the field of sort, the filters are combined",
+		Description: customFilterDescription + " The vehicles without a value of the custom attribute sorted by " +
+			"come last, and the ties are sorted by id.",
+		Tags: []string{"vehicles v2"},
+		Parameters: append(vehicleFilterParams(),
+			query("sort", "Field to sort by, one of "+strings.Join(service.VehicleSortFields, ", ")+" or custom.name, "+
+				"- first for the descending order", &Schema{Type: "string", Example: "-custom.cost_center"}),
+		),
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Vehicles", vehicleList),
+			http.StatusBadRequest: fail("Malformed filters or sort"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/search", &Operation{
+		OperationID: "searchVehiclesV2",
+		Summary:     "Search the vehicles by brand, model, color and registration, the most relevant first",
+		Description: "The words of q are matched ignoring case and accents, as the start of a word, and with up to " +
+			"1 typo from 4 letters and 2 from 8. Every word must match, numbers without typos.",
+		Tags: []string{"vehicles v2"},
+		Parameters: []Parameter{
+			QueryParam("q", "Words to search", true, &Schema{Type: "string", Example: "chevy cavlier"}),
+			query("limit", "Maximum number of vehicles, between 1 and 100, 20 by default", &Schema{Type: "integer"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicles found", matchList),
+			http.StatusBadRequest:          fail("Missing q or malformed limit"),
+			http.StatusUnprocessableEntity: fail("The query has no words"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles", &Operation{
+		OperationID: "createVehicleV2",
+		Summary:     "Create a vehicle",
+		Tags:        []string{"vehicles v2"},
+		RequestBody: JSONBody(vehicleRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Vehicle created", vehicleData),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusConflict:            fail("Registration already exists"),
+			http.StatusUnprocessableEntity: fail("Invalid vehicle"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/batch", &Operation{
+		OperationID: "createVehiclesV2",
+		Summary:     "Create many vehicles",
+		Tags:        []string{"vehicles v2"},
+		RequestBody: JSONBody(&Schema{Type: "array", Items: vehicleRequest}),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Vehicles created", vehicleList),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusConflict:            fail("Registration already exists"),
+			http.StatusUnprocessableEntity: fail("Invalid vehicle"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}", &Operation{
+		OperationID: "getVehicleV2",
+		Summary:     "Get a vehicle",
+		Tags:        []string{"vehicles v2"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Vehicle", vehicleData),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/compare", &Operation{
+		OperationID: "compareVehiclesV2",
+		Summary:     "Compare vehicles side by side",
+		Description: "The attributes of the vehicles aligned per field, in the order of ids, with the derived " +
+			"volume of the dimensions and power_to_weight, the max speed by unit of weight. The best and the " +
+			"worst vehicles are given for passengers, max_speed, weight (the lighter the better) and the derived metrics.",
+		Tags: []string{"vehicles v2"},
+		Parameters: []Parameter{
+			QueryParam("ids", "Identifiers of 2 to 10 vehicles, comma separated", true, &Schema{Type: "string", Example: "1,2,3"}),
+			query("format", "json, by default, or csv with a line per field", &Schema{Type: "string", Enum: []any{"json", "csv"}}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK: {
+				Description: "Vehicles compared",
+				Content: map[string]MediaType{
+					"application/json": {Schema: comparisonData},
+					"text/csv":         {Schema: &Schema{Type: "string"}},
+				},
+			},
+			http.StatusBadRequest:          fail("Malformed ids or unknown format"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusUnprocessableEntity: fail("Fewer than 2 or more than 10 vehicles, or an id given twice"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/registration/{plate}", &Operation{
+		OperationID: "getVehicleByRegistrationV2",
+		Summary:     "Get a vehicle by its registration, matched without case and separators",
+		Tags:        []string{"vehicles v2"},
+		Parameters:  []Parameter{PathParam("plate", "Registration of the vehicle, e.g. abc-1d23")},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicle", vehicleData),
+			http.StatusNotFound:            fail("No vehicle with the registration"),
+			http.StatusUnprocessableEntity: fail("The registration has no letters or digits"),
+		}),
+	})
+	doc.Add(http.MethodPatch, "/v2/vehicles/{id}", &Operation{
+		OperationID: "patchVehicleV2",
+		Summary:     "Update the attributes present in the body, including max_speed and fuel_type",
+		Tags:        []string{"vehicles v2"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(vehiclePatch),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicle updated", vehicleData),
+			http.StatusBadRequest:          fail("Malformed id, body or unknown fields"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusConflict:            fail("Registration of another vehicle"),
+			http.StatusUnprocessableEntity: fail("Invalid vehicle"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}", &Operation{
+		OperationID: "deleteVehicleV2",
+		Summary:     "Delete a vehicle",
+		Tags:        []string{"vehicles v2"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Vehicle deleted"},
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/brands/{brand}/stats", &Operation{
+		OperationID: "getBrandStatsV2",
+		Summary:     "Average speed and capacity of the vehicles of a brand",
+		Tags:        []string{"vehicles v2"},
+		Parameters:  []Parameter{PathParam("brand", "Brand of the vehicle, or one of its aliases in the catalog, case insensitive")},
+		Responses: api(map[int]Response{
+			http.StatusOK:       JSON("Brand aggregates", brandStats),
+			http.StatusNotFound: fail("Brand not found"),
+		}),
+	})
+}
+
+// describeCatalog is a function that describes the /v2/catalog routes administering the brands
+func describeCatalog(doc *Document) {
+	brandData := doc.Schema("BrandData", v2.Data[v2.BrandResponse]{})
+	brandList := doc.Schema("BrandList", v2.List[v2.BrandResponse]{})
+	unknownList := doc.Schema("UnknownBrandList", v2.List[v2.UnknownBrandResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	brand := PathParam("brand", "Canonical name or alias of the brand, matched ignoring case, accents, spaces and punctuation")
+	alias := PathParam("alias", "Alias of the brand")
+
+	doc.Add(http.MethodGet, "/v2/catalog/brands", &Operation{
+		OperationID: "listCatalogBrands",
+		Summary:     "List the brands of the catalog with their aliases and models",
+		Tags:        []string{"catalog"},
+		Responses: api(map[int]Response{
+			http.StatusOK: JSON("Brands sorted by name", brandList),
+		}),
+	})
+	doc.Add(http.MethodPut, "/v2/catalog/brands/{brand}", &Operation{
+		OperationID: "saveCatalogBrand",
+		Summary:     "Add a brand to the catalog, unless its name is already a brand or an alias",
+		Tags:        []string{"catalog"},
+		Parameters:  []Parameter{PathParam("brand", "Canonical name of the brand")},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("The existing brand", brandData),
+			http.StatusCreated:             JSON("Brand added", brandData),
+			http.StatusUnprocessableEntity: fail("The name has no letters or digits"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/v2/catalog/brands/{brand}/aliases/{alias}", &Operation{
+		OperationID: "addCatalogBrandAlias",
+		Summary:     "Add an alias to a brand",
+		Tags:        []string{"catalog"},
+		Parameters:  []Parameter{brand, alias},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("The brand with the alias", brandData),
+			http.StatusNotFound:            fail("Brand not found"),
+			http.StatusConflict:            fail("The alias names another brand"),
+			http.StatusUnprocessableEntity: fail("The alias has no letters or digits"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/catalog/brands/{brand}/aliases/{alias}", &Operation{
+		OperationID: "removeCatalogBrandAlias",
+		Summary:     "Remove an alias of a brand",
+		Tags:        []string{"catalog"},
+		Parameters:  []Parameter{brand, alias},
+		Responses: api(map[int]Response{
+			http.StatusNoContent: {Description: "Alias removed"},
+			http.StatusNotFound:  fail("Brand or alias not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/catalog/unknown_brands", &Operation{
+		OperationID: "listUnknownBrands",
+		Summary:     "List the brands missing from the catalog found when the vehicles were loaded",
+		Tags:        []string{"catalog"},
+		Responses: api(map[int]Response{
+			http.StatusOK: JSON("Brands with their number of vehicles, the most frequent first", unknownList),
+		}),
+	})
+}
+
+// describeGraphQL is a function that describes the GraphQL endpoint, its schema is
+// available through introspection
+func describeGraphQL(doc *Document) {
+	result := Object(map[string]*Schema{
+		"data":   {Type: "object"},
+		"errors": {Type: "array", Items: &Schema{Type: "object"}},
+	})
+	result.Required = nil
+	request := Object(map[string]*Schema{
+		"query":         {Type: "string"},
+		"operationName": {Type: "string"},
+		"variables":     {Type: "object"},
+	})
+	request.Required = []string{"query"}
+	responses := Responses(map[int]Response{
+		http.StatusOK:                  JSON("Result of the execution, with the errors of the resolvers", result),
+		http.StatusBadRequest:          JSON("Malformed request or query beyond the depth and complexity limits", result),
+		http.StatusServiceUnavailable:  {Description: "The vehicles are still being loaded"},
+		http.StatusInternalServerError: {Description: "Internal error"},
+	})
+
+	doc.Add(http.MethodGet, "/graphql", &Operation{
+		OperationID: "graphqlQuery",
+		Summary:     "Execute a GraphQL query, mutations must be sent with POST",
+		Tags:        []string{"graphql"},
+		Parameters: []Parameter{
+			QueryParam("query", "GraphQL document", true, &Schema{Type: "string"}),
+			QueryParam("operationName", "Operation of the document to execute", false, &Schema{Type: "string"}),
+			QueryParam("variables", "Variables as a JSON object", false, &Schema{Type: "string"}),
+		},
+		Responses: responses,
+	})
+	doc.Add(http.MethodPost, "/graphql", &Operation{
+		OperationID: "graphql",
+		Summary:     "Execute a GraphQL query or mutation",
+		Tags:        []string{"graphql"},
+		RequestBody: JSONBody(request),
+		Responses:   responses,
+	})
+}
+
+// describeMaintenance is a function that describes the maintenance routes of v2
+func describeMaintenance(doc *Document) {
+	eventRequest := doc.Schema("MaintenanceEventRequest", v2.MaintenanceEventRequest{})
+	planRequest := doc.Schema("MaintenancePlanRequest", v2.MaintenancePlanRequest{})
+	eventData := doc.Schema("MaintenanceEventData", v2.Data[v2.MaintenanceEventResponse]{})
+	eventList := doc.Schema("MaintenanceEventList", v2.List[v2.MaintenanceEventResponse]{})
+	planData := doc.Schema("MaintenancePlanData", v2.Data[v2.MaintenancePlanResponse]{})
+	planList := doc.Schema("MaintenancePlanList", v2.List[v2.MaintenancePlanResponse]{})
+	dueList := doc.Schema("MaintenanceDueList", v2.List[v2.MaintenanceDueResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	due := []Parameter{
+		QueryParam("at", "Day of the report as YYYY-MM-DD, today by default", false, &Schema{Type: "string", Format: "date"}),
+		QueryParam("within_days", "Days before the due date a service is upcoming, 30 by default", false, &Schema{Type: "integer"}),
+		QueryParam("within_km", "Distance before the due odometer a service is upcoming, 1000 by default", false, &Schema{Type: "number"}),
+	}
+
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/maintenance", &Operation{
+		OperationID: "listMaintenanceEvents",
+		Summary:     "List the service history of a vehicle, the oldest first",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Maintenance events", eventList),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/maintenance", &Operation{
+		OperationID: "recordMaintenanceEvent",
+		Summary:     "Record a service performed on a vehicle",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(eventRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Maintenance event recorded", eventData),
+			http.StatusBadRequest:          fail("Malformed id, body, unknown fields or date"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusUnprocessableEntity: fail("Invalid maintenance event"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/maintenance/{event}", &Operation{
+		OperationID: "deleteMaintenanceEvent",
+		Summary:     "Remove a maintenance event of a vehicle",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id, PathParam("event", "Identifier of the maintenance event")},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Maintenance event removed"},
+			http.StatusBadRequest: fail("Malformed id or event"),
+			http.StatusNotFound:   fail("Vehicle or maintenance event not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/maintenance/plans", &Operation{
+		OperationID: "listMaintenancePlans",
+		Summary:     "List the recurring services of a vehicle",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Maintenance plans", planList),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/maintenance/plans", &Operation{
+		OperationID: "saveMaintenancePlan",
+		Summary:     "Add a recurring service to a vehicle, due every interval_days or interval_km, whichever comes first",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(planRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Maintenance plan added", planData),
+			http.StatusBadRequest:          fail("Malformed id, body, unknown fields or start_date"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusUnprocessableEntity: fail("Invalid maintenance plan"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/maintenance/plans/{plan}", &Operation{
+		OperationID: "deleteMaintenancePlan",
+		Summary:     "Remove a recurring service of a vehicle",
+		Tags:        []string{"maintenance"},
+		Parameters:  []Parameter{id, PathParam("plan", "Identifier of the maintenance plan")},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Maintenance plan removed"},
+			http.StatusBadRequest: fail("Malformed id or plan"),
+			http.StatusNotFound:   fail("Vehicle or maintenance plan not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/maintenance/due", &Operation{
+		OperationID: "getMaintenanceDue",
+		Summary:     "Get when each recurring service of a vehicle is due",
+		Description: "A plan is due after its interval from the last event of its type, or from its start without one. " +
+			"The odometer of the vehicle is the highest of its events. The status is overdue after the due day or " +
+			"past the due distance, upcoming within the horizon until then, or scheduled.",
+		Tags:       []string{"maintenance"},
+		Parameters: append([]Parameter{id}, due...),
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Maintenance plans with their due date and odometer", dueList),
+			http.StatusBadRequest: fail("Malformed id, at, within_days or within_km"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/maintenance/due", &Operation{
+		OperationID: "getFleetMaintenanceDue",
+		Summary:     "Report the services of the fleet overdue or upcoming, the overdue first and then by due date",
+		Tags:        []string{"maintenance"},
+		Parameters:  due,
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Maintenance plans overdue or upcoming", dueList),
+			http.StatusBadRequest: fail("Malformed at, within_days or within_km"),
+		}),
+	})
+}
+
+// describeDrivers is a function that describes the routes of the drivers and their assignments of v2
+func describeDrivers(doc *Document) {
+	driverRequest := doc.Schema("DriverRequest", v2.DriverRequest{})
+	driverPatch := doc.Schema("DriverPatchRequest", v2.DriverPatchRequest{})
+	assignmentRequest := doc.Schema("AssignmentRequest", v2.AssignmentRequest{})
+	driverData := doc.Schema("DriverData", v2.Data[v2.DriverResponse]{})
+	driverList := doc.Schema("DriverList", v2.List[v2.DriverResponse]{})
+	assignmentData := doc.Schema("AssignmentData", v2.Data[v2.AssignmentResponse]{})
+	assignmentList := doc.Schema("AssignmentList", v2.List[v2.AssignmentResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	driver := PathParam("driver", "Identifier of the driver")
+	at := QueryParam("at", "Time as RFC 3339, now by default", false, &Schema{Type: "string", Format: "date-time"})
+
+	doc.Add(http.MethodGet, "/v2/drivers", &Operation{
+		OperationID: "listDrivers",
+		Summary:     "List the drivers",
+		Tags:        []string{"drivers"},
+		Responses: api(map[int]Response{
+			http.StatusOK: JSON("Drivers by id", driverList),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/drivers", &Operation{
+		OperationID: "createDriver",
+		Summary:     "Add a driver",
+		Description: "license_categories are the letters of the categories of the CNH, e.g. AB. " +
+			"A drives up to 2 passengers and 400 of weight, B up to 8 passengers and 3500, C up to 8 passengers, " +
+			"D and E any vehicle.",
+		Tags:        []string{"drivers"},
+		RequestBody: JSONBody(driverRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Driver added", driverData),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusConflict:            fail("License number already exists"),
+			http.StatusUnprocessableEntity: fail("Invalid driver"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/drivers/{driver}", &Operation{
+		OperationID: "getDriver",
+		Summary:     "Get a driver",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{driver},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Driver", driverData),
+			http.StatusBadRequest: fail("Malformed driver"),
+			http.StatusNotFound:   fail("Driver not found"),
+		}),
+	})
+	doc.Add(http.MethodPatch, "/v2/drivers/{driver}", &Operation{
+		OperationID: "patchDriver",
+		Summary:     "Update the attributes of a driver present in the body, e.g. a renewed license",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{driver},
+		RequestBody: JSONBody(driverPatch),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Driver updated", driverData),
+			http.StatusBadRequest:          fail("Malformed driver, body or unknown fields"),
+			http.StatusNotFound:            fail("Driver not found"),
+			http.StatusConflict:            fail("License number of another driver"),
+			http.StatusUnprocessableEntity: fail("Invalid driver"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/drivers/{driver}/assignments", &Operation{
+		OperationID: "listDriverAssignments",
+		Summary:     "List the vehicles a driver was assigned to, the oldest first",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{driver},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Assignments of the driver", assignmentList),
+			http.StatusBadRequest: fail("Malformed driver"),
+			http.StatusNotFound:   fail("Driver not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/drivers/{driver}/assignment", &Operation{
+		OperationID: "getDriverAssignment",
+		Summary:     "Get the vehicle assigned to a driver",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{driver, at},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Assignment of the driver", assignmentData),
+			http.StatusBadRequest: fail("Malformed driver or at"),
+			http.StatusNotFound:   fail("Driver not found or without vehicle"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/assignments", &Operation{
+		OperationID: "listVehicleAssignments",
+		Summary:     "List the drivers a vehicle was assigned to, the oldest first",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Assignments of the vehicle", assignmentList),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/assignments", &Operation{
+		OperationID: "assignDriver",
+		Summary:     "Assign a driver to a vehicle",
+		Description: "The vehicle and the driver must be free during the assignment, and the license of the driver " +
+			"valid at its start and of a category fit for the passengers and the weight of the vehicle.",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(assignmentRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Assignment made", assignmentData),
+			http.StatusBadRequest:          fail("Malformed id, body or unknown fields"),
+			http.StatusNotFound:            fail("Vehicle or driver not found"),
+			http.StatusConflict:            fail("The vehicle or the driver is assigned during the interval"),
+			http.StatusUnprocessableEntity: fail("Invalid interval, or license expired or not fit for the vehicle"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/assignment", &Operation{
+		OperationID: "getVehicleAssignment",
+		Summary:     "Get the driver assigned to a vehicle",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{id, at},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Assignment of the vehicle", assignmentData),
+			http.StatusBadRequest: fail("Malformed id or at"),
+			http.StatusNotFound:   fail("Vehicle not found or without driver"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/assignment", &Operation{
+		OperationID: "unassignDriver",
+		Summary:     "End the assignment of a vehicle, kept in the history",
+		Tags:        []string{"drivers"},
+		Parameters:  []Parameter{id, at},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Assignment ended", assignmentData),
+			http.StatusBadRequest: fail("Malformed id or at"),
+			http.StatusNotFound:   fail("Vehicle not found or without driver"),
+		}),
+	})
+}
+
+// describeReservations is a function that describes the routes of the reservations of the pool vehicles of v2
+func describeReservations(doc *Document) {
+	reservationRequest := doc.Schema("ReservationRequest", v2.ReservationRequest{})
+	reservationData := doc.Schema("ReservationData", v2.Data[v2.ReservationResponse]{})
+	reservationList := doc.Schema("ReservationList", v2.List[v2.ReservationResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	reservation := PathParam("reservation", "Identifier of the reservation")
+	at := QueryParam("at", "Time as RFC 3339, now by default", false, &Schema{Type: "string", Format: "date-time"})
+	dateTime := &Schema{Type: "string", Format: "date-time"}
+	statuses := []any{internal.ReservationBooked, internal.ReservationCheckedOut, internal.ReservationReturned, internal.ReservationCancelled}
+
+	doc.Add(http.MethodGet, "/v2/reservations", &Operation{
+		OperationID: "listReservations",
+		Summary:     "List the reservations by start, as JSON or as an iCalendar to subscribe to",
+		Tags:        []string{"reservations"},
+		Parameters: []Parameter{
+			QueryParam("vehicle_id", "Identifier of the vehicle", false, &Schema{Type: "integer"}),
+			QueryParam("from", "Start of an interval the reservations overlap, as RFC 3339", false, dateTime),
+			QueryParam("to", "End of an interval the reservations overlap, as RFC 3339", false, dateTime),
+			QueryParam("status", "Status of the reservations", false, &Schema{Type: "string", Enum: statuses}),
+			QueryParam("format", "json, by default, or ics", false, &Schema{Type: "string", Enum: []any{"json", "ics"}}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK: {
+				Description: "Reservations",
+				Content: map[string]MediaType{
+					"application/json": {Schema: reservationList},
+					"text/calendar":    {Schema: &Schema{Type: "string"}},
+				},
+			},
+			http.StatusBadRequest: fail("Malformed filters or unknown format"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/reservations", &Operation{
+		OperationID: "bookVehicle",
+		Summary:     "Book a vehicle",
+		Description: "The interval is from start until end, excluded. The vehicle must not be booked nor checked out " +
+			"during the interval.",
+		Tags:        []string{"reservations"},
+		RequestBody: JSONBody(reservationRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Vehicle booked", reservationData),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusConflict:            fail("The vehicle is booked during the interval"),
+			http.StatusUnprocessableEntity: fail("Invalid reservation"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/reservations/availability", &Operation{
+		OperationID: "listAvailableVehicles",
+		Summary:     "List the vehicles free during an interval, the filters of the vehicles combined",
+		Description: customFilterDescription,
+		Tags:        []string{"reservations"},
+		Parameters: append([]Parameter{
+			QueryParam("start", "Start of the interval, as RFC 3339", true, dateTime),
+			QueryParam("end", "End of the interval, excluded, as RFC 3339", true, dateTime),
+		}, vehicleFilterParams()...),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicles free", Ref("VehicleList")),
+			http.StatusBadRequest:          fail("Malformed interval or filters"),
+			http.StatusUnprocessableEntity: fail("End not after start"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/reservations/{reservation}", &Operation{
+		OperationID: "getReservation",
+		Summary:     "Get a reservation",
+		Tags:        []string{"reservations"},
+		Parameters:  []Parameter{reservation},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Reservation", reservationData),
+			http.StatusBadRequest: fail("Malformed reservation"),
+			http.StatusNotFound:   fail("Reservation not found"),
+		}),
+	})
+	transition := func(action, operationID, summary string, rs map[int]Response, params ...Parameter) {
+		rs[http.StatusOK] = JSON("Reservation updated", reservationData)
+		rs[http.StatusBadRequest] = fail("Malformed reservation or at")
+		rs[http.StatusNotFound] = fail("Reservation not found")
+		doc.Add(http.MethodPost, "/v2/reservations/{reservation}/"+action, &Operation{
+			OperationID: operationID,
+			Summary:     summary,
+			Tags:        []string{"reservations"},
+			Parameters:  append([]Parameter{reservation}, params...),
+			Responses:   api(rs),
+		})
+	}
+	transition("cancel", "cancelReservation", "Cancel a reservation before the check-out", map[int]Response{
+		http.StatusConflict: fail("The reservation is not booked"),
+	})
+	transition("checkout", "checkOutReservation", "Record the vehicle of a reservation taken", map[int]Response{
+		http.StatusConflict: fail("The reservation is not booked or has ended"),
+	}, at)
+	transition("checkin", "checkInReservation", "Record the vehicle of a reservation returned", map[int]Response{
+		http.StatusConflict:            fail("The reservation is not checked out"),
+		http.StatusUnprocessableEntity: fail("Check-in before the check-out"),
+	}, at)
+}
+
+// describeTrips is a function that describes the routes of the trips and the utilization of the vehicles of v2
+func describeTrips(doc *Document) {
+	tripRequest := doc.Schema("TripRequest", v2.TripRequest{})
+	tripData := doc.Schema("TripData", v2.Data[v2.TripResponse]{})
+	tripList := doc.Schema("TripList", v2.List[v2.TripResponse]{})
+	utilizationData := doc.Schema("UtilizationData", v2.Data[v2.UtilizationResponse]{})
+	underusedList := doc.Schema("UnderusedList", v2.List[v2.UnderusedResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	dateTime := &Schema{Type: "string", Format: "date-time"}
+	date := &Schema{Type: "string", Format: "date"}
+	period := []Parameter{
+		QueryParam("from", "First day of the period, 29 days before to by default", false, date),
+		QueryParam("to", "Last day of the period, today by default", false, date),
+	}
+
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/trips", &Operation{
+		OperationID: "listTrips",
+		Summary:     "List the trips of a vehicle by start",
+		Tags:        []string{"trips"},
+		Parameters: []Parameter{
+			id,
+			QueryParam("from", "Start of an interval the trips overlap, as RFC 3339", false, dateTime),
+			QueryParam("to", "End of an interval the trips overlap, as RFC 3339", false, dateTime),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Trips of the vehicle", tripList),
+			http.StatusBadRequest: fail("Malformed id, from or to"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/trips", &Operation{
+		OperationID: "recordTrip",
+		Summary:     "Record a trip of a vehicle",
+		Description: "The odometer of a vehicle never decreases: the readings of a trip must not be lower than the " +
+			"ones of an earlier trip of the vehicle nor higher than the ones of a later trip.",
+		Tags:        []string{"trips"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(tripRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Trip recorded", tripData),
+			http.StatusBadRequest:          fail("Malformed id, body or unknown fields"),
+			http.StatusNotFound:            fail("Vehicle or driver not found"),
+			http.StatusConflict:            fail("The trip overlaps another of the vehicle or its odometer decreases"),
+			http.StatusUnprocessableEntity: fail("Invalid trip"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/trips/{trip}", &Operation{
+		OperationID: "deleteTrip",
+		Summary:     "Remove a trip of a vehicle",
+		Tags:        []string{"trips"},
+		Parameters:  []Parameter{id, PathParam("trip", "Identifier of the trip")},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Trip removed"},
+			http.StatusBadRequest: fail("Malformed id or trip"),
+			http.StatusNotFound:   fail("Vehicle or trip not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/utilization", &Operation{
+		OperationID: "getUtilization",
+		Summary:     "Report the km, hours and idle days of each vehicle and brand over a period",
+		Description: "The trips partly in the period are clipped to it, their distance prorated by time. " +
+			"usage is the share of the days of the period with a trip.",
+		Tags:       []string{"trips"},
+		Parameters: period,
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Utilization of the fleet", utilizationData),
+			http.StatusBadRequest:          fail("Malformed from or to"),
+			http.StatusUnprocessableEntity: fail("to before from"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/utilization/underused", &Operation{
+		OperationID: "listUnderusedVehicles",
+		Summary:     "List the vehicles underused over a period, candidates for sale, the least used first",
+		Tags:        []string{"trips"},
+		Parameters: append(period,
+			QueryParam("min_km", "Distance below which a vehicle is underused, ignored by default", false, &Schema{Type: "number"}),
+			QueryParam("min_usage", "Share of the days with a trip below which a vehicle is underused, 0.2 by default", false, &Schema{Type: "number"}),
+		),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Underused vehicles", underusedList),
+			http.StatusBadRequest:          fail("Malformed from, to, min_km or min_usage"),
+			http.StatusUnprocessableEntity: fail("to before from"),
+		}),
+	})
+}
+
+// describeFuel is a function that describes the routes of the fuel transactions and the consumption of the vehicles of v2
+func describeFuel(doc *Document) {
+	transactionRequest := doc.Schema("FuelTransactionRequest", v2.FuelTransactionRequest{})
+	transactionData := doc.Schema("FuelTransactionData", v2.Data[v2.FuelTransactionResponse]{})
+	transactionList := doc.Schema("FuelTransactionList", v2.List[v2.FuelTransactionResponse]{})
+	consumptionData := doc.Schema("ConsumptionReportData", v2.Data[v2.ConsumptionReportResponse]{})
+	consumptionList := doc.Schema("ConsumptionList", v2.List[v2.ConsumptionResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	tolerance := QueryParam("tolerance", "Share of the median consumption of a vehicle around it that is its normal band, 0.25 by default",
+		false, &Schema{Type: "number"})
+
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/fuel", &Operation{
+		OperationID: "listFuelTransactions",
+		Summary:     "List the refuels and charges of a vehicle, the oldest first",
+		Tags:        []string{"fuel"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Fuel transactions of the vehicle", transactionList),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/fuel", &Operation{
+		OperationID: "recordFuelTransaction",
+		Summary:     "Record a refuel or a charge of a vehicle, the tank or the battery filled up",
+		Description: "The quantity is in kWh for the electric vehicles and in liters otherwise. The fuel type, the current " +
+			"one of the vehicle when absent, must match it, and the odometer of the vehicle never decreases.",
+		Tags:        []string{"fuel"},
+		Parameters:  []Parameter{id},
+		RequestBody: JSONBody(transactionRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Fuel transaction recorded", transactionData),
+			http.StatusBadRequest:          fail("Malformed id, body or unknown fields"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusConflict:            fail("The odometer of the vehicle decreases"),
+			http.StatusUnprocessableEntity: fail("Invalid transaction or fuel type not the one of the vehicle"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/fuel/{transaction}", &Operation{
+		OperationID: "deleteFuelTransaction",
+		Summary:     "Remove a fuel transaction of a vehicle",
+		Tags:        []string{"fuel"},
+		Parameters:  []Parameter{id, PathParam("transaction", "Identifier of the fuel transaction")},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Fuel transaction removed"},
+			http.StatusBadRequest: fail("Malformed id or transaction"),
+			http.StatusNotFound:   fail("Vehicle or transaction not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/fuel/consumption", &Operation{
+		OperationID: "getFuelConsumption",
+		Summary:     "Report the consumption of the current fuel type of a vehicle",
+		Description: "Each transaction measures the consumption since the previous one, in km/l or, for the electric " +
+			"vehicles, kWh/100km. From 3 consumptions, the ones outside the normal band, tolerance around the median, " +
+			"are flagged high_consumption, a possible fuel fraud, or low_consumption.",
+		Tags:       []string{"fuel"},
+		Parameters: []Parameter{id, tolerance},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Consumption of the vehicle", consumptionData),
+			http.StatusBadRequest: fail("Malformed id or tolerance"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/fuel/anomalies", &Operation{
+		OperationID: "listFuelAnomalies",
+		Summary:     "List the consumptions of the fleet outside the normal band of their vehicle, the most recent first",
+		Tags:        []string{"fuel"},
+		Parameters:  []Parameter{tolerance},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Consumptions flagged", consumptionList),
+			http.StatusBadRequest: fail("Malformed tolerance"),
+		}),
+	})
+}
+
+// describeTelemetry is a function that describes the routes of the GPS positions and speeds of the vehicles of v2
+func describeTelemetry(doc *Document) {
+	sample := doc.Schema("TelemetrySampleRequest", v2.TelemetrySampleRequest{})
+	ingestData := doc.Schema("IngestData", v2.Data[v2.IngestResponse]{})
+	sampleData := doc.Schema("TelemetrySampleData", v2.Data[v2.TelemetrySampleResponse]{})
+	sampleList := doc.Schema("TelemetrySampleList", v2.List[v2.TelemetrySampleResponse]{})
+	alertList := doc.Schema("SpeedAlertList", v2.List[v2.SpeedAlertResponse]{})
+	nearbyList := doc.Schema("NearbyVehicleList", v2.List[v2.NearbyVehicleResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	from := QueryParam("from", "Start of the window, RFC 3339", false, &Schema{Type: "string", Format: "date-time"})
+
+	doc.Add(http.MethodPost, "/v2/telemetry", &Operation{
+		OperationID: "ingestTelemetry",
+		Summary:     "Ingest a batch of GPS positions and speeds of the vehicles",
+		Description: fmt.Sprintf("Up to %d samples, as NDJSON, one per line, or as a JSON array. The invalid samples, "+
+			"the ones of unknown vehicles, older than the retention or in the future are rejected by their index "+
+			"in the body without failing the others. The readings above the max speed of their vehicle raise speed alerts.",
+			handler.TelemetryMaxBatch),
+		Tags: []string{"telemetry"},
+		RequestBody: &RequestBody{
+			Required: true,
+			Content: map[string]MediaType{
+				"application/x-ndjson": {Schema: sample},
+				"application/json":     {Schema: &Schema{Type: "array", Items: sample}},
+			},
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:                    JSON("Samples accepted and rejected, and the speed alerts raised", ingestData),
+			http.StatusBadRequest:            fail("Malformed JSON array"),
+			http.StatusRequestEntityTooLarge: fail("Too many samples, body or line too large"),
+			http.StatusUnsupportedMediaType:  fail("Content type neither NDJSON nor JSON"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/telemetry/alerts", &Operation{
+		OperationID: "listSpeedAlerts",
+		Summary:     "List the readings above the max speed of their vehicle, the most recent first",
+		Tags:        []string{"telemetry"},
+		Parameters: []Parameter{
+			QueryParam("vehicle_id", "Only the alerts of this vehicle", false, &Schema{Type: "integer"}),
+			from,
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Speed alerts", alertList),
+			http.StatusBadRequest: fail("Malformed vehicle_id or from"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/nearby", &Operation{
+		OperationID: "listNearbyVehicles",
+		Summary:     "List the vehicles nearest to a point by their latest position, the nearest first",
+		Description: "The vehicles without a position within the retention of the telemetry are left out. The filters " +
+			"of the vehicles are combined, and available_until keeps the ones without a reservation from now until then. " +
+			customFilterDescription,
+		Tags: []string{"telemetry"},
+		Parameters: append([]Parameter{
+			QueryParam("lat", "Latitude of the point, in decimal degrees", true, &Schema{Type: "number"}),
+			QueryParam("lon", "Longitude of the point, in decimal degrees", true, &Schema{Type: "number"}),
+			QueryParam("radius", fmt.Sprintf("Greatest distance to the point, in km, %g by default and up to %g",
+				handler.NearbyDefaultRadius, handler.NearbyMaxRadius), false, &Schema{Type: "number"}),
+			QueryParam("limit", fmt.Sprintf("Highest number of vehicles, %d by default and up to %d",
+				handler.NearbyDefaultLimit, handler.NearbyMaxLimit), false, &Schema{Type: "integer"}),
+			QueryParam("available_until", "Only the vehicles without a reservation from now until this time, RFC 3339",
+				false, &Schema{Type: "string", Format: "date-time"}),
+		}, vehicleFilterParams()...),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Vehicles near the point", nearbyList),
+			http.StatusBadRequest:          fail("Malformed point, radius, limit, available_until or filters"),
+			http.StatusUnprocessableEntity: fail("Coordinates out of range or invalid filters"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/position", &Operation{
+		OperationID: "getVehiclePosition",
+		Summary:     "Get the latest position and speed of a vehicle",
+		Tags:        []string{"telemetry"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Latest sample of the vehicle", sampleData),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found or without telemetry"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/track", &Operation{
+		OperationID: "getVehicleTrack",
+		Summary:     "Get the samples of a vehicle over a window, the oldest first",
+		Tags:        []string{"telemetry"},
+		Parameters: []Parameter{
+			id,
+			QueryParam("from", "Start of the window, RFC 3339, an hour before to by default", false, &Schema{Type: "string", Format: "date-time"}),
+			QueryParam("to", "End of the window, RFC 3339, now by default", false, &Schema{Type: "string", Format: "date-time"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Track of the vehicle", sampleList),
+			http.StatusBadRequest:          fail("Malformed id, from or to"),
+			http.StatusNotFound:            fail("Vehicle not found"),
+			http.StatusUnprocessableEntity: fail("from after to"),
+		}),
+	})
+}
+
+// describeZones is a function that describes the routes of the geofencing zones of v2
+func describeZones(doc *Document) {
+	zoneRequest := doc.Schema("ZoneRequest", v2.ZoneRequest{})
+	zoneData := doc.Schema("ZoneData", v2.Data[v2.ZoneResponse]{})
+	zoneList := doc.Schema("ZoneList", v2.List[v2.ZoneResponse]{})
+	eventList := doc.Schema("ZoneEventList", v2.List[v2.ZoneEventResponse]{})
+	occupantList := doc.Schema("ZoneOccupantList", v2.List[v2.ZoneOccupantResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	zone := PathParam("zone", "Identifier of the zone")
+	area := "The area is a GeoJSON Polygon, its outer ring then its holes, each closed with at least 4 positions " +
+		"[longitude, latitude]. The kind is one of " + strings.Join(internal.ZoneKinds, ", ") + "."
+
+	doc.Add(http.MethodGet, "/v2/zones", &Operation{
+		OperationID: "listZones",
+		Summary:     "List the geofencing zones by id",
+		Tags:        []string{"zones"},
+		Responses: api(map[int]Response{
+			http.StatusOK: JSON("Zones", zoneList),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/zones", &Operation{
+		OperationID: "createZone",
+		Summary:     "Add a zone, the vehicles entering and leaving it detected from their next position",
+		Description: area,
+		Tags:        []string{"zones"},
+		RequestBody: JSONBody(zoneRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Zone added", zoneData),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusConflict:            fail("Name taken by another zone"),
+			http.StatusUnprocessableEntity: fail("Invalid zone"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/zones/events", &Operation{
+		OperationID: "listZoneEvents",
+		Summary:     "List the entries and exits of the vehicles, the most recent first",
+		Description: "An event is detected by the first position of a vehicle inside, or outside, a zone. The events of " +
+			"the zones deleted are kept, and the ones older than the retention of the telemetry dropped.",
+		Tags: []string{"zones"},
+		Parameters: []Parameter{
+			QueryParam("zone_id", "Only the events of this zone", false, &Schema{Type: "integer"}),
+			QueryParam("vehicle_id", "Only the events of this vehicle", false, &Schema{Type: "integer"}),
+			QueryParam("from", "Start of the window, RFC 3339", false, &Schema{Type: "string", Format: "date-time"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Zone events", eventList),
+			http.StatusBadRequest: fail("Malformed zone_id, vehicle_id or from"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/zones/{zone}", &Operation{
+		OperationID: "getZone",
+		Summary:     "Get a zone",
+		Tags:        []string{"zones"},
+		Parameters:  []Parameter{zone},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Zone", zoneData),
+			http.StatusBadRequest: fail("Malformed zone"),
+			http.StatusNotFound:   fail("Zone not found"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/v2/zones/{zone}", &Operation{
+		OperationID: "replaceZone",
+		Summary:     "Replace a zone, the vehicles inside it evaluated again at their next position",
+		Description: area,
+		Tags:        []string{"zones"},
+		Parameters:  []Parameter{zone},
+		RequestBody: JSONBody(zoneRequest),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Zone replaced", zoneData),
+			http.StatusBadRequest:          fail("Malformed zone, body or unknown fields"),
+			http.StatusNotFound:            fail("Zone not found"),
+			http.StatusConflict:            fail("Name taken by another zone"),
+			http.StatusUnprocessableEntity: fail("Invalid zone"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/zones/{zone}", &Operation{
+		OperationID: "deleteZone",
+		Summary:     "Remove a zone, its events kept",
+		Tags:        []string{"zones"},
+		Parameters:  []Parameter{zone},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Zone removed"},
+			http.StatusBadRequest: fail("Malformed zone"),
+			http.StatusNotFound:   fail("Zone not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/zones/{zone}/vehicles", &Operation{
+		OperationID: "listZoneVehicles",
+		Summary:     "List the vehicles inside a zone as of their latest position, by vehicle id",
+		Tags:        []string{"zones"},
+		Parameters:  []Parameter{zone},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Vehicles inside the zone", occupantList),
+			http.StatusBadRequest: fail("Malformed zone"),
+			http.StatusNotFound:   fail("Zone not found"),
+		}),
+	})
+}
+
+// describeDocuments is a function that describes the routes of the documents of the vehicles of v2
+func describeDocuments(doc *Document) {
+	documentData := doc.Schema("DocumentData", v2.Data[v2.DocumentResponse]{})
+	documentList := doc.Schema("DocumentList", v2.List[v2.DocumentResponse]{})
+	expiringList := doc.Schema("ExpiringDocumentList", v2.List[v2.ExpiringDocumentResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	id := PathParam("id", "Identifier of the vehicle")
+	document := PathParam("document", "Identifier of the document")
+	types := make([]any, 0, len(internal.DocumentTypes))
+	for _, t := range internal.DocumentTypes {
+		types = append(types, t)
+	}
+	files := make(map[string]MediaType, len(internal.DocumentContentTypes))
+	for _, t := range internal.DocumentContentTypes {
+		files[t] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	form := Object(map[string]*Schema{
+		"file":   {Type: "string", Format: "binary", Description: "The file, up to 10 MB"},
+		"type":   {Type: "string", Enum: types},
+		"expiry": {Type: "string", Format: "date", Description: "Day the document expires, as YYYY-MM-DD"},
+	})
+	form.Required = []string{"file", "type"}
+
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/documents", &Operation{
+		OperationID: "listVehicleDocuments",
+		Summary:     "List the documents of a vehicle by id",
+		Tags:        []string{"documents"},
+		Parameters:  []Parameter{id},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Documents", documentList),
+			http.StatusBadRequest: fail("Malformed id"),
+			http.StatusNotFound:   fail("Vehicle not found"),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/vehicles/{id}/documents", &Operation{
+		OperationID: "uploadVehicleDocument",
+		Summary:     "Attach a document to a vehicle, a thumbnail made for an image",
+		Description: "The content type is sniffed from the content of the file, whatever the one declared, and must be " +
+			"one of " + strings.Join(internal.DocumentContentTypes, ", ") + ".",
+		Tags:       []string{"documents"},
+		Parameters: []Parameter{id},
+		RequestBody: &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		},
+		Responses: api(map[int]Response{
+			http.StatusCreated:               JSON("Document attached", documentData),
+			http.StatusBadRequest:            fail("Malformed id or form, or no file"),
+			http.StatusNotFound:              fail("Vehicle not found"),
+			http.StatusRequestEntityTooLarge: fail("File larger than 10 MB"),
+			http.StatusUnsupportedMediaType:  fail("Body not multipart/form-data or file of a content type not accepted"),
+			http.StatusUnprocessableEntity:   fail("Invalid type, expiry or file"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/documents/{document}", &Operation{
+		OperationID: "getVehicleDocument",
+		Summary:     "Get the metadata of a document of a vehicle",
+		Tags:        []string{"documents"},
+		Parameters:  []Parameter{id, document},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Document", documentData),
+			http.StatusBadRequest: fail("Malformed id or document"),
+			http.StatusNotFound:   fail("Vehicle or document not found"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/vehicles/{id}/documents/{document}", &Operation{
+		OperationID: "deleteVehicleDocument",
+		Summary:     "Remove a document of a vehicle and its files",
+		Tags:        []string{"documents"},
+		Parameters:  []Parameter{id, document},
+		Responses: api(map[int]Response{
+			http.StatusNoContent:  {Description: "Document removed"},
+			http.StatusBadRequest: fail("Malformed id or document"),
+			http.StatusNotFound:   fail("Vehicle or document not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/documents/{document}/content", &Operation{
+		OperationID: "downloadVehicleDocument",
+		Summary:     "Download the file of a document of a vehicle",
+		Tags:        []string{"documents"},
+		Parameters:  []Parameter{id, document},
+		Responses: api(map[int]Response{
+			http.StatusOK:         {Description: "The file, as an attachment", Content: files},
+			http.StatusBadRequest: fail("Malformed id or document"),
+			http.StatusNotFound:   fail("Vehicle or document not found"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/vehicles/{id}/documents/{document}/thumbnail", &Operation{
+		OperationID: "getVehicleDocumentThumbnail",
+		Summary:     "Get the thumbnail of an image attached to a vehicle",
+		Description: fmt.Sprintf("The thumbnail is a JPEG fitting %d pixels.", service.ThumbnailSize),
+		Tags:        []string{"documents"},
+		Parameters:  []Parameter{id, document},
+		Responses: api(map[int]Response{
+			http.StatusOK: {
+				Description: "Thumbnail",
+				Content:     map[string]MediaType{"image/jpeg": {Schema: &Schema{Type: "string", Format: "binary"}}},
+			},
+			http.StatusBadRequest: fail("Malformed id or document"),
+			http.StatusNotFound:   fail("Vehicle or document not found, or a document without thumbnail"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/documents/expiring", &Operation{
+		OperationID: "listExpiringDocuments",
+		Summary:     "List the documents of the fleet expired or expiring soon, the soonest first",
+		Tags:        []string{"documents"},
+		Parameters: []Parameter{
+			QueryParam("at", "Day of the report as YYYY-MM-DD, today by default", false, &Schema{Type: "string", Format: "date"}),
+			QueryParam("within_days", fmt.Sprintf("Days after at the documents expire within, %d by default", handler.DocumentDefaultDays),
+				false, &Schema{Type: "integer"}),
+		},
+		Responses: api(map[int]Response{
+			http.StatusOK:         JSON("Documents expired or expiring", expiringList),
+			http.StatusBadRequest: fail("Malformed at or within_days"),
+		}),
+	})
+}
+
+// describeAttributes is a function that describes the routes of the custom attributes of the vehicles of v2
+func describeAttributes(doc *Document) {
+	attributeRequest := doc.Schema("AttributeRequest", v2.AttributeRequest{})
+	attributeData := doc.Schema("AttributeData", v2.Data[v2.AttributeResponse]{})
+	attributeList := doc.Schema("AttributeList", v2.List[v2.AttributeResponse]{})
+
+	fail := func(description string) Response {
+		return JSON(description, Ref("ErrorV2"))
+	}
+	api := func(rs map[int]Response) map[string]Response {
+		rs[http.StatusGatewayTimeout] = fail("The route deadline was exceeded")
+		rs[http.StatusServiceUnavailable] = Response{Description: "The vehicles are still being loaded"}
+		rs[http.StatusInternalServerError] = fail("Internal error")
+		return Responses(rs)
+	}
+	name := PathParam("name", "Name of the custom attribute")
+	definition := "The attributes are defined by tenant, the one of the " + tenant.Header + " header. The type is one of " +
+		strings.Join(internal.AttributeTypes, ", ") + ", and the allowed values, of the type, restrict the values of " +
+		"the vehicles. The vehicles are checked against the definitions at their next write."
+
+	doc.Add(http.MethodGet, "/v2/attributes", &Operation{
+		OperationID: "listAttributes",
+		Summary:     "List the custom attributes of the vehicles of the tenant by name",
+		Tags:        []string{"attributes"},
+		Responses: api(map[int]Response{
+			http.StatusOK: JSON("Custom attributes", attributeList),
+		}),
+	})
+	doc.Add(http.MethodPost, "/v2/attributes", &Operation{
+		OperationID: "createAttribute",
+		Summary:     "Define a custom attribute of the vehicles of the tenant",
+		Description: definition,
+		Tags:        []string{"attributes"},
+		RequestBody: JSONBody(attributeRequest),
+		Responses: api(map[int]Response{
+			http.StatusCreated:             JSON("Custom attribute defined", attributeData),
+			http.StatusBadRequest:          fail("Malformed body or unknown fields"),
+			http.StatusConflict:            fail("Name taken by another attribute of the tenant"),
+			http.StatusUnprocessableEntity: fail("Invalid attribute"),
+		}),
+	})
+	doc.Add(http.MethodGet, "/v2/attributes/{name}", &Operation{
+		OperationID: "getAttribute",
+		Summary:     "Get a custom attribute of the tenant",
+		Tags:        []string{"attributes"},
+		Parameters:  []Parameter{name},
+		Responses: api(map[int]Response{
+			http.StatusOK:       JSON("Custom attribute", attributeData),
+			http.StatusNotFound: fail("Attribute not found"),
+		}),
+	})
+	doc.Add(http.MethodPut, "/v2/attributes/{name}", &Operation{
+		OperationID: "replaceAttribute",
+		Summary:     "Replace a custom attribute of the tenant",
+		Description: definition + " The name of the body, optional, must be the one of the route.",
+		Tags:        []string{"attributes"},
+		Parameters:  []Parameter{name},
+		RequestBody: JSONBody(attributeRequest),
+		Responses: api(map[int]Response{
+			http.StatusOK:                  JSON("Custom attribute replaced", attributeData),
+			http.StatusBadRequest:          fail("Malformed body, unknown fields or another name"),
+			http.StatusNotFound:            fail("Attribute not found"),
+			http.StatusUnprocessableEntity: fail("Invalid attribute"),
+		}),
+	})
+	doc.Add(http.MethodDelete, "/v2/attributes/{name}", &Operation{
+		OperationID: "deleteAttribute",
+		Summary:     "Remove a custom attribute of the tenant",
+		Description: "The values of the vehicles are kept, and rejected at their next write unless set to null.",
+		Tags:        []string{"attributes"},
+		Parameters:  []Parameter{name},
+		Responses: api(map[int]Response{
+			http.StatusNoContent: {Description: "Custom attribute removed"},
+			http.StatusNotFound:  fail("Attribute not found"),
+		}),
+	})
+}
@@ -0,0 +1,106 @@
+package openapi_test
+
+import (
+	"app/internal/application"
+	"app/internal/openapi"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestAPIDocument_Contract is a function that checks the contract between the document and the
+// routes of the application: every route registered must be described and every operation
+// described must be routed
+func TestAPIDocument_Contract(t *testing.T) {
+	app := application.NewServerChi(&application.ConfigServerChi{
+		LoaderFilePath:  "../../docs/db/vehicles_100.json",
+		CatalogFilePath: "../../docs/db/brands.json",
+		DocumentDir:     t.TempDir(),
+		LogLevel:        "error",
+	})
+	routes, err := app.Routes()
+	if err != nil {
+		t.Fatalf("routes: %v", err)
+	}
+
+	if err := verify(openapi.NewAPIDocument(), routes...); err != nil {
+		t.Fatal(err)
+	}
+
+	// the routers are built once, their collectors not registered again
+	if _, err := app.Routes(); err != nil {
+		t.Fatalf("routes again: %v", err)
+	}
+}
+
+// TestVerify is a function that checks that the contract check reports the routes missing from the
+// document and the operations without route
+func TestVerify(t *testing.T) {
+	doc := openapi.NewAPIDocument()
+	rt := chi.NewRouter()
+	rt.Get("/undocumented/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	err := verify(doc, rt)
+	if err == nil {
+		t.Fatal("got no error, want the route and the operations reported")
+	}
+	if !strings.Contains(err.Error(), "GET /undocumented/{id}") {
+		t.Errorf("got %q, want the undocumented route reported", err)
+	}
+	if !strings.Contains(err.Error(), "operations without route") {
+		t.Errorf("got %q, want the operations without route reported", err)
+	}
+}
+
+// verify is a function that returns the routes of routers missing from the document and the
+// operations of the document without route. Mounted sub-trees ("/*") are skipped, their routers
+// are expected among routers.
+func verify(doc *openapi.Document, routers ...chi.Routes) (err error) {
+	routed := make(map[string]bool)
+	for _, rt := range routers {
+		err = chi.Walk(rt, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			if strings.HasSuffix(route, "/*") {
+				return nil
+			}
+			routed[method+" "+openapi.Path(route)] = true
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	described := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			described[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	var undescribed, unrouted []string
+	for r := range routed {
+		if !described[r] {
+			undescribed = append(undescribed, r)
+		}
+	}
+	for d := range described {
+		if !routed[d] {
+			unrouted = append(unrouted, d)
+		}
+	}
+	sort.Strings(undescribed)
+	sort.Strings(unrouted)
+
+	if len(undescribed) > 0 {
+		err = errors.Join(err, fmt.Errorf("openapi: routes missing from the document: %s", strings.Join(undescribed, ", ")))
+	}
+	if len(unrouted) > 0 {
+		err = errors.Join(err, fmt.Errorf("openapi: operations without route: %s", strings.Join(unrouted, ", ")))
+	}
+	return
+}
@@ -0,0 +1,150 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Document is a struct that represents an OpenAPI 3.1 document
+type Document struct {
+	// OpenAPI is the version of the specification
+	OpenAPI string `json:"openapi"`
+	// Info is the metadata of the API
+	Info Info `json:"info"`
+	// Paths are the operations by path and lowercase method
+	Paths map[string]PathItem `json:"paths"`
+	// Components are the reusable schemas
+	Components Components `json:"components"`
+}
+
+// Info is a struct that represents the metadata of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem is the set of operations of a path, keyed by lowercase method
+type PathItem map[string]*Operation
+
+// Components is a struct that represents the reusable objects of the document
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation is a struct that represents a single API operation on a path
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Deprecated  bool                `json:"deprecated,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a struct that represents a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is a struct that represents the body of a request
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a struct that represents a response of an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is a struct that represents the schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// NewDocument is a function that returns an empty document
+func NewDocument(title, version, description string) *Document {
+	return &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       title,
+			Description: description,
+			Version:     version,
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+		},
+	}
+}
+
+// Add is a method that describes the operation of method on the chi route pattern
+func (d *Document) Add(method, pattern string, op *Operation) {
+	path := Path(pattern)
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(PathItem)
+	}
+	d.Paths[path][strings.ToLower(method)] = op
+}
+
+// Schema is a method that registers the schema of v under name in the components
+// and returns a reference to it
+func (d *Document) Schema(name string, v any) *Schema {
+	d.Components.Schemas[name] = SchemaOf(v)
+	return Ref(name)
+}
+
+// Path is a function that converts a chi route pattern into an OpenAPI path
+func Path(pattern string) string {
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	return pattern
+}
+
+// PathParam is a function that returns a required string path parameter
+func PathParam(name, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "string"}}
+}
+
+// QueryParam is a function that returns a query parameter
+func QueryParam(name, description string, required bool, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Required: required, Schema: schema}
+}
+
+// JSONBody is a function that returns a required JSON request body
+func JSONBody(schema *Schema) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// JSON is a function that returns a JSON response
+func JSON(description string, schema *Schema) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// Responses is a function that builds the responses of an operation by status code
+func Responses(rs map[int]Response) map[string]Response {
+	m := make(map[string]Response, len(rs))
+	for status, r := range rs {
+		if r.Description == "" {
+			r.Description = http.StatusText(status)
+		}
+		m[strconv.Itoa(status)] = r
+	}
+	return m
+}
@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+
+	"github.com/bootcamp-go/web/response"
+)
+
+//go:embed ui.html
+var ui []byte
+
+// assets are the files of swagger-ui used by the docs page, pinned and served by the binary
+//
+//go:embed swagger-ui/*.js swagger-ui/*.css swagger-ui/*.png
+var assets embed.FS
+
+// Handler is a function that returns a handler for the route GET /openapi.json
+func Handler(doc *Document) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response.JSON(w, http.StatusOK, doc)
+	}
+}
+
+// UI is a function that returns a handler for the route GET /docs, the embedded docs page
+func UI() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(ui)
+	}
+}
+
+// Assets is a function that returns a handler for the route GET /docs/assets/*, the files of the
+// docs page
+func Assets() http.Handler {
+	dir, _ := fs.Sub(assets, "swagger-ui")
+	return http.StripPrefix("/docs/assets/", http.FileServer(http.FS(dir)))
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// NewAttributeMap is a function that returns a new instance of AttributeMap with the attribute definitions
+func NewAttributeMap(defs []internal.AttributeDefinition) *AttributeMap {
+	m := &AttributeMap{tenants: make(map[string]map[string]internal.AttributeDefinition)}
+	for _, d := range defs {
+		m.put(d)
+	}
+	return m
+}
+
+// AttributeMap is a struct that implements the AttributeRepository interface in memory,
+// safe for concurrent use
+type AttributeMap struct {
+	mu sync.RWMutex
+	// tenants are the attribute definitions by tenant and name
+	tenants map[string]map[string]internal.AttributeDefinition
+}
+
+// FindAll is a method that returns the attribute definitions of a tenant by name
+func (m *AttributeMap) FindAll(ctx context.Context, tenant string) (d []internal.AttributeDefinition, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d = make([]internal.AttributeDefinition, 0, len(m.tenants[tenant]))
+	for _, value := range m.tenants[tenant] {
+		d = append(d, value)
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Name < d[j].Name })
+	return
+}
+
+// FindByName is a method that returns an attribute definition of a tenant
+func (m *AttributeMap) FindByName(ctx context.Context, tenant, name string) (d internal.AttributeDefinition, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d, ok := m.tenants[tenant][name]
+	if !ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrAttributeNotFound, name)
+	}
+	return
+}
+
+// Save is a method that adds an attribute definition
+func (m *AttributeMap) Save(ctx context.Context, d *internal.AttributeDefinition) (v internal.AttributeDefinition, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[d.Tenant][d.Name]; ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrAttributeAlreadyExists, d.Name)
+		return
+	}
+
+	v = m.put(*d)
+	return
+}
+
+// Update is a method that replaces an attribute definition
+func (m *AttributeMap) Update(ctx context.Context, d *internal.AttributeDefinition) (v internal.AttributeDefinition, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[d.Tenant][d.Name]; !ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrAttributeNotFound, d.Name)
+		return
+	}
+
+	v = m.put(*d)
+	return
+}
+
+// Delete is a method that removes an attribute definition of a tenant
+func (m *AttributeMap) Delete(ctx context.Context, tenant, name string) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.tenants[tenant][name]; !ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrAttributeNotFound, name)
+		return
+	}
+	delete(m.tenants[tenant], name)
+	return
+}
+
+// put is a method that stores an attribute definition, its allowed values copied so that the
+// caller cannot change them
+func (m *AttributeMap) put(d internal.AttributeDefinition) internal.AttributeDefinition {
+	d.Allowed = slices.Clone(d.Allowed)
+	if m.tenants[d.Tenant] == nil {
+		m.tenants[d.Tenant] = make(map[string]internal.AttributeDefinition)
+	}
+	m.tenants[d.Tenant][d.Name] = d
+	return d
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// blobKey is the format of the keys of the blobs, slash separated names never . nor ..
+var blobKey = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*(/[A-Za-z0-9_-][A-Za-z0-9._-]*)*$`)
+
+// NewBlobFS is a function that returns a new instance of BlobFS storing the files under the directory root
+func NewBlobFS(root string) *BlobFS {
+	return &BlobFS{root: root}
+}
+
+// BlobFS is a struct that implements the BlobStore interface on the local filesystem, a file per key
+// under its root. A file is written aside and renamed, so that readers never see it partially written.
+type BlobFS struct {
+	// root is the directory of the files
+	root string
+}
+
+// Put is a method that writes the content of a file, replacing the one of key
+func (b *BlobFS) Put(ctx context.Context, key string, r io.Reader) (err error) {
+	path, err := b.path(key)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+	if _, err = io.Copy(tmp, r); err != nil {
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	err = os.Rename(tmp.Name(), path)
+	return
+}
+
+// Get is a method that opens a file for reading
+func (b *BlobFS) Get(ctx context.Context, key string) (rc io.ReadCloser, err error) {
+	path, err := b.path(key)
+	if err != nil {
+		return
+	}
+
+	rc, err = os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = fmt.Errorf("%w: %s", apperrors.ErrBlobNotFound, key)
+	}
+	return
+}
+
+// Delete is a method that removes a file
+func (b *BlobFS) Delete(ctx context.Context, key string) (err error) {
+	path, err := b.path(key)
+	if err != nil {
+		return
+	}
+
+	if err = os.Remove(path); errors.Is(err, fs.ErrNotExist) {
+		err = nil
+	}
+	return
+}
+
+// path is a method that returns the path of the file of key, rejecting the keys that could
+// escape the root
+func (b *BlobFS) path(key string) (path string, err error) {
+	if !blobKey.MatchString(key) {
+		err = fmt.Errorf("blob: invalid key %q", key)
+		return
+	}
+	path = filepath.Join(b.root, filepath.FromSlash(key))
+	return
+}
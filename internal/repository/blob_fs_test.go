@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBlobFS_Path is a function that checks that the keys that could escape the root are
+// rejected, by the stores and the reads alike
+func TestBlobFS_Path(t *testing.T) {
+	cases := map[string]struct {
+		key     string
+		wantErr bool
+	}{
+		"name":                 {key: "photo.jpg"},
+		"nested":               {key: "vehicles/1/documents/crlv-2026.pdf"},
+		"dotted name":          {key: "vehicles/1/.v2.pdf", wantErr: true},
+		"empty":                {key: "", wantErr: true},
+		"parent":               {key: "..", wantErr: true},
+		"parent first":         {key: "../secret", wantErr: true},
+		"parent inside":        {key: "vehicles/../../secret", wantErr: true},
+		"current":              {key: "vehicles/./1", wantErr: true},
+		"absolute":             {key: "/etc/passwd", wantErr: true},
+		"trailing slash":       {key: "vehicles/", wantErr: true},
+		"empty name":           {key: "vehicles//1", wantErr: true},
+		"backslash":            {key: `..\secret`, wantErr: true},
+		"windows drive":        {key: "C:/secret", wantErr: true},
+		"encoded parent":       {key: "%2e%2e/secret", wantErr: true},
+		"newline":              {key: "photo.jpg\n", wantErr: true},
+		"parent in a filename": {key: "vehicles/a..b"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			b := NewBlobFS(filepath.Join(root, "blobs"))
+
+			err := b.Put(context.Background(), c.key, strings.NewReader("content"))
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("got no error for key %q, want one", c.key)
+				}
+				if _, err := b.Get(context.Background(), c.key); err == nil {
+					t.Fatalf("got no error reading key %q, want one", c.key)
+				}
+				if entries, _ := os.ReadDir(root); len(entries) != 0 {
+					t.Fatalf("got %d entries written under the root, want none", len(entries))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			rc, err := b.Get(context.Background(), c.key)
+			if err != nil {
+				t.Fatalf("got error %v reading, want none", err)
+			}
+			defer rc.Close()
+			if content, _ := io.ReadAll(rc); string(content) != "content" {
+				t.Errorf("got content %q, want %q", content, "content")
+			}
+		})
+	}
+}
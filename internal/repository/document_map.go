@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// NewDocumentMap is a function that returns a new instance of DocumentMap with the documents, their ids kept,
+// writing every change with ld, kept in memory only when ld is nil
+func NewDocumentMap(documents []internal.Document, ld internal.DocumentLoader) *DocumentMap {
+	m := &DocumentMap{documents: make(map[int]internal.Document), ld: ld}
+	for _, d := range documents {
+		m.documents[d.Id] = d
+		m.lastId = max(m.lastId, d.Id)
+	}
+	return m
+}
+
+// DocumentMap is a struct that implements the DocumentRepository interface in memory,
+// safe for concurrent use
+type DocumentMap struct {
+	mu sync.RWMutex
+	// documents are the documents by id
+	documents map[int]internal.Document
+	// lastId is the highest id reserved
+	lastId int
+	// ld is the loader the documents are written with after every change, nil for none
+	ld internal.DocumentLoader
+}
+
+// FindAll is a method that returns the documents of a vehicle, of every vehicle for 0, by id
+func (m *DocumentMap) FindAll(ctx context.Context, vehicleId int) (d []internal.Document, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d = []internal.Document{}
+	for _, value := range m.documents {
+		if vehicleId == 0 || value.VehicleId == vehicleId {
+			d = append(d, value)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Id < d[j].Id })
+	return
+}
+
+// FindById is a method that returns a document of a vehicle
+func (m *DocumentMap) FindById(ctx context.Context, vehicleId, id int) (d internal.Document, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	d, ok := m.documents[id]
+	if !ok || d.VehicleId != vehicleId {
+		d = internal.Document{}
+		err = fmt.Errorf("%w: %d", apperrors.ErrDocumentNotFound, id)
+	}
+	return
+}
+
+// NextId is a method that reserves the id of a document to be saved
+func (m *DocumentMap) NextId(ctx context.Context) (id int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.lastId++
+	id = m.lastId
+	return
+}
+
+// Save is a method that adds a document with the id reserved for it
+func (m *DocumentMap) Save(ctx context.Context, d *internal.Document) (v internal.Document, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d.Id <= 0 || d.Id > m.lastId {
+		err = fmt.Errorf("document: id %d not reserved", d.Id)
+		return
+	}
+	v = *d
+	m.documents[v.Id] = v
+	if err = m.write(); err != nil {
+		delete(m.documents, v.Id)
+		v = internal.Document{}
+	}
+	return
+}
+
+// Delete is a method that removes a document of a vehicle
+func (m *DocumentMap) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d, ok := m.documents[id]
+	if !ok || d.VehicleId != vehicleId {
+		err = fmt.Errorf("%w: %d", apperrors.ErrDocumentNotFound, id)
+		return
+	}
+	delete(m.documents, id)
+	if err = m.write(); err != nil {
+		m.documents[id] = d
+	}
+	return
+}
+
+// Flush is a method that writes the documents with the loader, called on shutdown
+func (m *DocumentMap) Flush(ctx context.Context) (err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	err = m.write()
+	return
+}
+
+// write is a method that writes the documents by id with the loader, the lock held
+func (m *DocumentMap) write() (err error) {
+	if m.ld == nil {
+		return
+	}
+
+	d := make([]internal.Document, 0, len(m.documents))
+	for _, value := range m.documents {
+		d = append(d, value)
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Id < d[j].Id })
+	if err = m.ld.Save(d); err != nil {
+		err = fmt.Errorf("document: write: %w", err)
+	}
+	return
+}
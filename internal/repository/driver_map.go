@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// NewDriverMap is a function that returns a new instance of DriverMap with the registry,
+// the ids of the drivers and assignments kept
+func NewDriverMap(r internal.DriverRegistry) *DriverMap {
+	m := &DriverMap{
+		drivers:     make(map[int]internal.Driver),
+		licenses:    make(map[string]int),
+		assignments: make(map[int]internal.Assignment),
+	}
+	for _, d := range r.Drivers {
+		m.drivers[d.Id] = d
+		m.licenses[licenseKey(d.LicenseNumber)] = d.Id
+		m.lastDriverId = max(m.lastDriverId, d.Id)
+	}
+	for _, a := range r.Assignments {
+		m.assignments[a.Id] = a
+		m.lastAssignmentId = max(m.lastAssignmentId, a.Id)
+	}
+	return m
+}
+
+// DriverMap is a struct that implements the DriverRepository interface in memory, safe for concurrent use
+type DriverMap struct {
+	mu sync.RWMutex
+	// drivers are the drivers by id
+	drivers map[int]internal.Driver
+	// licenses are the ids of the drivers by the key of their license number
+	licenses map[string]int
+	// assignments are the assignments by id
+	assignments map[int]internal.Assignment
+	// lastDriverId and lastAssignmentId are the highest ids assigned
+	lastDriverId     int
+	lastAssignmentId int
+}
+
+// FindAll is a method that returns the drivers by id
+func (r *DriverMap) FindAll(ctx context.Context) (d []internal.Driver, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d = make([]internal.Driver, 0, len(r.drivers))
+	for _, value := range r.drivers {
+		d = append(d, value)
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i].Id < d[j].Id })
+	return
+}
+
+// FindById is a method that returns a driver
+func (r *DriverMap) FindById(ctx context.Context, id int) (d internal.Driver, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.drivers[id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrDriverNotFound, id)
+	}
+	return
+}
+
+// Save is a method that adds a driver, assigning its id
+func (r *DriverMap) Save(ctx context.Context, d *internal.Driver) (v internal.Driver, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := licenseKey(d.LicenseNumber)
+	if _, ok := r.licenses[key]; ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrDriverAlreadyExists, d.LicenseNumber)
+		return
+	}
+
+	r.lastDriverId++
+	v = *d
+	v.Id = r.lastDriverId
+	r.drivers[v.Id] = v
+	r.licenses[key] = v.Id
+	return
+}
+
+// Update is a method that replaces a driver
+func (r *DriverMap) Update(ctx context.Context, d *internal.Driver) (v internal.Driver, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	old, ok := r.drivers[d.Id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrDriverNotFound, d.Id)
+		return
+	}
+	key := licenseKey(d.LicenseNumber)
+	if id, ok := r.licenses[key]; ok && id != d.Id {
+		err = fmt.Errorf("%w: %s", apperrors.ErrDriverAlreadyExists, d.LicenseNumber)
+		return
+	}
+
+	delete(r.licenses, licenseKey(old.LicenseNumber))
+	v = *d
+	r.drivers[v.Id] = v
+	r.licenses[key] = v.Id
+	return
+}
+
+// FindAssignments is a method that returns the assignments matching the filter, the oldest first
+func (r *DriverMap) FindAssignments(ctx context.Context, f internal.AssignmentFilter) (a []internal.Assignment, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a = []internal.Assignment{}
+	for _, value := range r.assignments {
+		if (f.VehicleId == 0 || value.VehicleId == f.VehicleId) && (f.DriverId == 0 || value.DriverId == f.DriverId) {
+			a = append(a, value)
+		}
+	}
+	sort.Slice(a, func(i, j int) bool {
+		if !a[i].Start.Equal(a[j].Start) {
+			return a[i].Start.Before(a[j].Start)
+		}
+		return a[i].Id < a[j].Id
+	})
+	return
+}
+
+// SaveAssignment is a method that adds an assignment, assigning its id
+func (r *DriverMap) SaveAssignment(ctx context.Context, a *internal.Assignment) (v internal.Assignment, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, other := range r.assignments {
+		if (other.VehicleId == a.VehicleId || other.DriverId == a.DriverId) && a.Overlaps(other) {
+			err = fmt.Errorf("%w: assignment %d of vehicle %d and driver %d", apperrors.ErrAssignmentConflict, other.Id, other.VehicleId, other.DriverId)
+			return
+		}
+	}
+
+	r.lastAssignmentId++
+	v = *a
+	v.Id = r.lastAssignmentId
+	r.assignments[v.Id] = v
+	return
+}
+
+// EndAssignment is a method that ends an assignment active at end, earlier than planned when it has an end.
+// An assignment is not ended at its start, it would last no time.
+func (r *DriverMap) EndAssignment(ctx context.Context, id int, end time.Time) (v internal.Assignment, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	v, ok := r.assignments[id]
+	if !ok || !v.ActiveAt(end) || !end.After(v.Start) {
+		err = fmt.Errorf("%w: %d is not active at %s", apperrors.ErrAssignmentNotFound, id, end.Format(time.RFC3339))
+		return
+	}
+
+	v.End = &end
+	r.assignments[id] = v
+	return
+}
+
+// licenseKey is a function that returns the key a license number is unique by, in upper case
+// without separators
+func licenseKey(number string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, number)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestDriverMap_SaveAssignment is a function that checks that an assignment overlapping another
+// of its vehicle or of its driver is rejected
+func TestDriverMap_SaveAssignment(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC) }
+	until := func(day int) *time.Time { end := at(day); return &end }
+	registry := internal.DriverRegistry{Assignments: []internal.Assignment{
+		{Id: 1, VehicleId: 1, DriverId: 1, Start: at(1), End: until(5)},
+		{Id: 2, VehicleId: 2, DriverId: 2, Start: at(10)},
+	}}
+
+	cases := map[string]struct {
+		a       internal.Assignment
+		wantErr error
+	}{
+		"other vehicle and driver":      {a: internal.Assignment{VehicleId: 3, DriverId: 3, Start: at(2), End: until(4)}},
+		"same vehicle after":            {a: internal.Assignment{VehicleId: 1, DriverId: 3, Start: at(5), End: until(8)}},
+		"same driver before":            {a: internal.Assignment{VehicleId: 3, DriverId: 1, Start: at(1).Add(-time.Hour), End: until(1)}},
+		"same vehicle overlapping":      {a: internal.Assignment{VehicleId: 1, DriverId: 3, Start: at(4), End: until(6)}, wantErr: apperrors.ErrAssignmentConflict},
+		"same driver overlapping":       {a: internal.Assignment{VehicleId: 3, DriverId: 1, Start: at(2), End: until(3)}, wantErr: apperrors.ErrAssignmentConflict},
+		"open after an open one":        {a: internal.Assignment{VehicleId: 2, DriverId: 3, Start: at(20)}, wantErr: apperrors.ErrAssignmentConflict},
+		"closed before an open one":     {a: internal.Assignment{VehicleId: 2, DriverId: 3, Start: at(6), End: until(10)}},
+		"open before another of driver": {a: internal.Assignment{VehicleId: 3, DriverId: 2, Start: at(6)}, wantErr: apperrors.ErrAssignmentConflict},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := NewDriverMap(registry)
+
+			v, err := rp.SaveAssignment(context.Background(), &c.a)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("got error %v, want %v", err, c.wantErr)
+			}
+			if c.wantErr == nil && v.Id != 3 {
+				t.Errorf("got id %d, want 3, after the ones loaded", v.Id)
+			}
+		})
+	}
+}
+
+// TestDriverMap_EndAssignment is a function that checks that an assignment is ended only while
+// it is active and after its start
+func TestDriverMap_EndAssignment(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC) }
+	until := func(day int) *time.Time { end := at(day); return &end }
+	registry := internal.DriverRegistry{Assignments: []internal.Assignment{
+		{Id: 1, VehicleId: 1, DriverId: 1, Start: at(1), End: until(5)},
+		{Id: 2, VehicleId: 2, DriverId: 2, Start: at(10)},
+	}}
+
+	cases := map[string]struct {
+		id      int
+		end     time.Time
+		wantErr error
+	}{
+		"open one":           {id: 2, end: at(12)},
+		"closed one earlier": {id: 1, end: at(3)},
+		"at its start":       {id: 2, end: at(10), wantErr: apperrors.ErrAssignmentNotFound},
+		"before its start":   {id: 2, end: at(9), wantErr: apperrors.ErrAssignmentNotFound},
+		"at its planned end": {id: 1, end: at(5), wantErr: apperrors.ErrAssignmentNotFound},
+		"unknown assignment": {id: 9, end: at(3), wantErr: apperrors.ErrAssignmentNotFound},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := NewDriverMap(registry)
+
+			v, err := rp.EndAssignment(context.Background(), c.id, c.end)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("got error %v, want %v", err, c.wantErr)
+			}
+			if c.wantErr != nil {
+				return
+			}
+			if v.End == nil || !v.End.Equal(c.end) {
+				t.Fatalf("got end %v, want %s", v.End, c.end)
+			}
+			if err := v.Validate(); err != nil {
+				t.Errorf("got an invalid assignment stored: %v", err)
+			}
+		})
+	}
+}
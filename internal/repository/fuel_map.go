@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// NewFuelMap is a function that returns a new instance of FuelMap with the transactions, their ids kept
+func NewFuelMap(transactions []internal.FuelTransaction) *FuelMap {
+	m := &FuelMap{transactions: make(map[int]internal.FuelTransaction)}
+	for _, t := range transactions {
+		m.transactions[t.Id] = t
+		m.lastId = max(m.lastId, t.Id)
+	}
+	return m
+}
+
+// FuelMap is a struct that implements the FuelRepository interface in memory,
+// safe for concurrent use
+type FuelMap struct {
+	mu sync.RWMutex
+	// transactions are the fuel transactions by id
+	transactions map[int]internal.FuelTransaction
+	// lastId is the highest id assigned
+	lastId int
+}
+
+// FindAll is a method that returns the transactions of a vehicle, of every vehicle for 0,
+// the oldest first
+func (m *FuelMap) FindAll(ctx context.Context, vehicleId int) (t []internal.FuelTransaction, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t = []internal.FuelTransaction{}
+	for _, value := range m.transactions {
+		if vehicleId == 0 || value.VehicleId == vehicleId {
+			t = append(t, value)
+		}
+	}
+	sort.Slice(t, func(i, j int) bool {
+		if !t[i].Time.Equal(t[j].Time) {
+			return t[i].Time.Before(t[j].Time)
+		}
+		return t[i].Id < t[j].Id
+	})
+	return
+}
+
+// Save is a method that records a transaction, assigning its id, its odometer checked against
+// the transactions of the vehicle before and after it
+func (m *FuelMap) Save(ctx context.Context, t *internal.FuelTransaction) (v internal.FuelTransaction, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, value := range m.transactions {
+		if value.VehicleId != t.VehicleId {
+			continue
+		}
+		switch {
+		case !value.Time.After(t.Time) && value.Odometer > t.Odometer:
+			err = fmt.Errorf("%w: transaction %d at %g km", apperrors.ErrOdometerDecreased, value.Id, value.Odometer)
+			return
+		case value.Time.After(t.Time) && value.Odometer < t.Odometer:
+			err = fmt.Errorf("%w: later transaction %d at %g km", apperrors.ErrOdometerDecreased, value.Id, value.Odometer)
+			return
+		}
+	}
+
+	m.lastId++
+	v = *t
+	v.Id = m.lastId
+	m.transactions[v.Id] = v
+	return
+}
+
+// Delete is a method that removes a transaction of a vehicle
+func (m *FuelMap) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t, ok := m.transactions[id]; !ok || t.VehicleId != vehicleId {
+		err = fmt.Errorf("%w: %d", apperrors.ErrFuelTransactionNotFound, id)
+		return
+	}
+	delete(m.transactions, id)
+	return
+}
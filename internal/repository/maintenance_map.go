@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// NewMaintenanceMap is a function that returns a new instance of MaintenanceMap with the log,
+// the ids of the events and plans kept
+func NewMaintenanceMap(l internal.MaintenanceLog) *MaintenanceMap {
+	r := &MaintenanceMap{
+		events: make(map[int]internal.MaintenanceEvent),
+		plans:  make(map[int]internal.MaintenancePlan),
+	}
+	for _, e := range l.Events {
+		r.events[e.Id] = e
+		r.lastEventId = max(r.lastEventId, e.Id)
+	}
+	for _, p := range l.Plans {
+		r.plans[p.Id] = p
+		r.lastPlanId = max(r.lastPlanId, p.Id)
+	}
+	return r
+}
+
+// MaintenanceMap is a struct that implements the MaintenanceRepository interface in memory,
+// safe for concurrent use
+type MaintenanceMap struct {
+	mu sync.RWMutex
+	// events are the maintenance events by id
+	events map[int]internal.MaintenanceEvent
+	// plans are the maintenance plans by id
+	plans map[int]internal.MaintenancePlan
+	// lastEventId and lastPlanId are the highest ids assigned
+	lastEventId int
+	lastPlanId  int
+}
+
+// FindEvents is a method that returns the events of a vehicle, the oldest first
+func (r *MaintenanceMap) FindEvents(ctx context.Context, vehicleId int) (e []internal.MaintenanceEvent, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e = []internal.MaintenanceEvent{}
+	for _, value := range r.events {
+		if value.VehicleId == vehicleId {
+			e = append(e, value)
+		}
+	}
+	sort.Slice(e, func(i, j int) bool {
+		if !e[i].Date.Equal(e[j].Date) {
+			return e[i].Date.Before(e[j].Date)
+		}
+		return e[i].Id < e[j].Id
+	})
+	return
+}
+
+// SaveEvent is a method that records an event, assigning its id
+func (r *MaintenanceMap) SaveEvent(ctx context.Context, e *internal.MaintenanceEvent) (v internal.MaintenanceEvent, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastEventId++
+	v = *e
+	v.Id = r.lastEventId
+	r.events[v.Id] = v
+	return
+}
+
+// DeleteEvent is a method that removes an event of a vehicle
+func (r *MaintenanceMap) DeleteEvent(ctx context.Context, vehicleId, id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if e, ok := r.events[id]; !ok || e.VehicleId != vehicleId {
+		err = fmt.Errorf("%w: %d", apperrors.ErrMaintenanceEventNotFound, id)
+		return
+	}
+	delete(r.events, id)
+	return
+}
+
+// FindPlans is a method that returns the plans of a vehicle by id
+func (r *MaintenanceMap) FindPlans(ctx context.Context, vehicleId int) (p []internal.MaintenancePlan, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p = []internal.MaintenancePlan{}
+	for _, value := range r.plans {
+		if value.VehicleId == vehicleId {
+			p = append(p, value)
+		}
+	}
+	sort.Slice(p, func(i, j int) bool { return p[i].Id < p[j].Id })
+	return
+}
+
+// FindAllPlans is a method that returns the plans of every vehicle, by vehicle and id
+func (r *MaintenanceMap) FindAllPlans(ctx context.Context) (p []internal.MaintenancePlan, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p = make([]internal.MaintenancePlan, 0, len(r.plans))
+	for _, value := range r.plans {
+		p = append(p, value)
+	}
+	sort.Slice(p, func(i, j int) bool {
+		if p[i].VehicleId != p[j].VehicleId {
+			return p[i].VehicleId < p[j].VehicleId
+		}
+		return p[i].Id < p[j].Id
+	})
+	return
+}
+
+// SavePlan is a method that adds a plan, assigning its id
+func (r *MaintenanceMap) SavePlan(ctx context.Context, p *internal.MaintenancePlan) (v internal.MaintenancePlan, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastPlanId++
+	v = *p
+	v.Id = r.lastPlanId
+	r.plans[v.Id] = v
+	return
+}
+
+// DeletePlan is a method that removes a plan of a vehicle
+func (r *MaintenanceMap) DeletePlan(ctx context.Context, vehicleId, id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.plans[id]; !ok || p.VehicleId != vehicleId {
+		err = fmt.Errorf("%w: %d", apperrors.ErrMaintenancePlanNotFound, id)
+		return
+	}
+	delete(r.plans, id)
+	return
+}
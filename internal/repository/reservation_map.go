@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewReservationMap is a function that returns a new instance of ReservationMap with the
+// reservations, their ids kept
+func NewReservationMap(reservations []internal.Reservation) *ReservationMap {
+	m := &ReservationMap{reservations: make(map[int]internal.Reservation)}
+	for _, r := range reservations {
+		m.reservations[r.Id] = r
+		m.lastId = max(m.lastId, r.Id)
+	}
+	return m
+}
+
+// ReservationMap is a struct that implements the ReservationRepository interface in memory,
+// safe for concurrent use
+type ReservationMap struct {
+	mu sync.RWMutex
+	// reservations are the reservations by id
+	reservations map[int]internal.Reservation
+	// lastId is the highest id assigned
+	lastId int
+}
+
+// FindAll is a method that returns the reservations matching the filter, by start
+func (m *ReservationMap) FindAll(ctx context.Context, f internal.ReservationFilter) (r []internal.Reservation, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	from, to := f.From, f.To
+	if to.IsZero() {
+		to = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	r = []internal.Reservation{}
+	for _, value := range m.reservations {
+		if f.VehicleId != 0 && value.VehicleId != f.VehicleId {
+			continue
+		}
+		if f.Status != "" && value.Status != f.Status {
+			continue
+		}
+		if !value.Overlaps(from, to) {
+			continue
+		}
+		r = append(r, value)
+	}
+	sort.Slice(r, func(i, j int) bool {
+		if !r[i].Start.Equal(r[j].Start) {
+			return r[i].Start.Before(r[j].Start)
+		}
+		return r[i].Id < r[j].Id
+	})
+	return
+}
+
+// FindById is a method that returns a reservation
+func (m *ReservationMap) FindById(ctx context.Context, id int) (r internal.Reservation, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, ok := m.reservations[id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrReservationNotFound, id)
+	}
+	return
+}
+
+// Save is a method that books a reservation, assigning its id
+func (m *ReservationMap) Save(ctx context.Context, r *internal.Reservation) (v internal.Reservation, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, other := range m.reservations {
+		if other.VehicleId == r.VehicleId && other.Holds() && other.Overlaps(r.Start, r.End) {
+			err = fmt.Errorf("%w: reservation %d from %s to %s", apperrors.ErrReservationConflict, other.Id,
+				other.Start.Format(time.RFC3339), other.End.Format(time.RFC3339))
+			return
+		}
+	}
+
+	m.lastId++
+	v = *r
+	v.Id = m.lastId
+	m.reservations[v.Id] = v
+	return
+}
+
+// Update is a method that replaces a reservation still in the status
+func (m *ReservationMap) Update(ctx context.Context, r *internal.Reservation, status string) (v internal.Reservation, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.reservations[r.Id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrReservationNotFound, r.Id)
+		return
+	}
+	if old.Status != status {
+		err = fmt.Errorf("%w: reservation %d is %s", apperrors.ErrReservationState, r.Id, old.Status)
+		return
+	}
+
+	v = *r
+	m.reservations[v.Id] = v
+	return
+}
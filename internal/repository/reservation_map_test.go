@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestReservationMap_Save is a function that checks that a reservation overlapping another
+// holding its vehicle is rejected, the end of a reservation excluded from it
+func TestReservationMap_Save(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, time.October, 20, hour, 0, 0, 0, time.UTC) }
+	reservations := []internal.Reservation{
+		{Id: 1, VehicleId: 1, Requester: "ana", Start: at(8), End: at(12), Status: internal.ReservationBooked},
+		{Id: 2, VehicleId: 1, Requester: "bia", Start: at(14), End: at(16), Status: internal.ReservationCheckedOut},
+		{Id: 3, VehicleId: 1, Requester: "caio", Start: at(18), End: at(20), Status: internal.ReservationCancelled},
+		{Id: 4, VehicleId: 1, Requester: "davi", Start: at(20), End: at(22), Status: internal.ReservationReturned},
+	}
+
+	cases := map[string]struct {
+		r       internal.Reservation
+		wantErr error
+	}{
+		"other vehicle":            {r: internal.Reservation{VehicleId: 2, Start: at(9), End: at(10)}},
+		"between the reservations": {r: internal.Reservation{VehicleId: 1, Start: at(12), End: at(14)}},
+		"over a cancelled one":     {r: internal.Reservation{VehicleId: 1, Start: at(18), End: at(19)}},
+		"over a returned one":      {r: internal.Reservation{VehicleId: 1, Start: at(21), End: at(22)}},
+		"inside a booked one":      {r: internal.Reservation{VehicleId: 1, Start: at(9), End: at(10)}, wantErr: apperrors.ErrReservationConflict},
+		"across the end":           {r: internal.Reservation{VehicleId: 1, Start: at(11), End: at(13)}, wantErr: apperrors.ErrReservationConflict},
+		"around a checked out one": {r: internal.Reservation{VehicleId: 1, Start: at(13), End: at(17)}, wantErr: apperrors.ErrReservationConflict},
+		"across the start":         {r: internal.Reservation{VehicleId: 1, Start: at(15), End: at(18)}, wantErr: apperrors.ErrReservationConflict},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := NewReservationMap(reservations)
+
+			v, err := rp.Save(context.Background(), &c.r)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got error %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if v.Id != 5 {
+				t.Errorf("got id %d, want 5", v.Id)
+			}
+		})
+	}
+}
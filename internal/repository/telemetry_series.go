@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"app/pkg/geo"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// telemetryCellSize is the side of the cells of the index of the latest positions, in degrees,
+// about 11 km of latitude
+const telemetryCellSize = 0.1
+
+// NewTelemetrySeries is a function that returns a new instance of TelemetrySeries keeping the
+// samples and alerts of the last retention
+func NewTelemetrySeries(retention time.Duration) *TelemetrySeries {
+	return &TelemetrySeries{
+		retention: retention,
+		series:    make(map[int]*series),
+		positions: geo.NewGrid(telemetryCellSize),
+	}
+}
+
+// TelemetrySeries is a struct that implements the TelemetryRepository interface in memory, a
+// series of samples sorted by time per vehicle, each behind its own lock so that the trackers of
+// different vehicles are ingested concurrently. Safe for concurrent use.
+type TelemetrySeries struct {
+	// retention is how long the samples and alerts are kept
+	retention time.Duration
+
+	mu sync.RWMutex
+	// series are the samples by vehicle
+	series map[int]*series
+	// positions is the spatial index of the latest sample of each vehicle, kept by the lock of its series
+	positions *geo.Grid
+
+	alertsMu sync.Mutex
+	// alerts are the speed alerts, the oldest first
+	alerts []internal.SpeedAlert
+}
+
+// series is a struct that represents the samples of a vehicle, sorted by time
+type series struct {
+	mu      sync.RWMutex
+	samples []internal.TelemetrySample
+	// removed is set once the series is dropped by Sweep, its samples written to a new one
+	removed bool
+}
+
+// Append is a method that stores samples, a sample at the time of another of its vehicle
+// replacing it, and drops the samples of the vehicles past the retention
+func (r *TelemetrySeries) Append(ctx context.Context, s []internal.TelemetrySample) (err error) {
+	byVehicle := make(map[int][]internal.TelemetrySample)
+	for _, value := range s {
+		byVehicle[value.VehicleId] = append(byVehicle[value.VehicleId], value)
+	}
+
+	cutoff := r.cutoff()
+	for vehicleId, samples := range byVehicle {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		sr := r.seriesOf(vehicleId)
+		sr.mu.Lock()
+		for sr.removed {
+			sr.mu.Unlock()
+			sr = r.seriesOf(vehicleId)
+			sr.mu.Lock()
+		}
+		for _, value := range samples {
+			sr.insert(value)
+		}
+		sr.prune(cutoff)
+		if n := len(sr.samples); n > 0 {
+			r.positions.Set(vehicleId, geo.Point{Longitude: sr.samples[n-1].Longitude, Latitude: sr.samples[n-1].Latitude})
+		} else {
+			r.positions.Delete(vehicleId)
+		}
+		sr.mu.Unlock()
+	}
+	return
+}
+
+// Latest is a method that returns the most recent sample of a vehicle
+func (r *TelemetrySeries) Latest(ctx context.Context, vehicleId int) (s internal.TelemetrySample, err error) {
+	r.mu.RLock()
+	sr, ok := r.series[vehicleId]
+	r.mu.RUnlock()
+
+	if ok {
+		sr.mu.RLock()
+		if n := len(sr.samples); n > 0 && !sr.samples[n-1].Time.Before(r.cutoff()) {
+			s = sr.samples[n-1]
+		}
+		sr.mu.RUnlock()
+	}
+	if s.Time.IsZero() {
+		err = fmt.Errorf("%w: %d", apperrors.ErrTelemetryNotFound, vehicleId)
+	}
+	return
+}
+
+// Track is a method that returns the samples of a vehicle from from until to, both included, the oldest first
+func (r *TelemetrySeries) Track(ctx context.Context, vehicleId int, from, to time.Time) (s []internal.TelemetrySample, err error) {
+	if cutoff := r.cutoff(); from.Before(cutoff) {
+		from = cutoff
+	}
+	s = []internal.TelemetrySample{}
+
+	r.mu.RLock()
+	sr, ok := r.series[vehicleId]
+	r.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	sr.mu.RLock()
+	defer sr.mu.RUnlock()
+	i := sort.Search(len(sr.samples), func(i int) bool { return !sr.samples[i].Time.Before(from) })
+	for ; i < len(sr.samples) && !sr.samples[i].Time.After(to); i++ {
+		s = append(s, sr.samples[i])
+	}
+	return
+}
+
+// Nearby is a method that returns the latest samples of the vehicles at most radius km from center,
+// the nearest first, the vehicles without a sample within the retention left out
+func (r *TelemetrySeries) Nearby(ctx context.Context, center geo.Point, radius float64) (n []internal.NearbySample, err error) {
+	cutoff := r.cutoff()
+	n = []internal.NearbySample{}
+	for _, value := range r.positions.Within(center, radius) {
+		r.mu.RLock()
+		sr, ok := r.series[value.Id]
+		r.mu.RUnlock()
+		if !ok {
+			// swept since the search of the index
+			continue
+		}
+
+		sr.mu.RLock()
+		if k := len(sr.samples); k > 0 && !sr.samples[k-1].Time.Before(cutoff) {
+			// the vehicle may have moved since the search of the index
+			s := sr.samples[k-1]
+			if d := geo.Distance(center, geo.Point{Longitude: s.Longitude, Latitude: s.Latitude}); d <= radius {
+				n = append(n, internal.NearbySample{Sample: s, Distance: d})
+			}
+		}
+		sr.mu.RUnlock()
+	}
+	sort.SliceStable(n, func(i, j int) bool { return n[i].Distance < n[j].Distance })
+	return
+}
+
+// AppendAlerts is a method that stores speed alerts, dropping the ones past the retention
+func (r *TelemetrySeries) AppendAlerts(ctx context.Context, a []internal.SpeedAlert) (err error) {
+	r.alertsMu.Lock()
+	defer r.alertsMu.Unlock()
+
+	r.alerts = append(r.alerts, a...)
+	sort.SliceStable(r.alerts, func(i, j int) bool { return r.alerts[i].Sample.Time.Before(r.alerts[j].Sample.Time) })
+	r.pruneAlerts(r.cutoff())
+	return
+}
+
+// FindAlerts is a method that returns the speed alerts matching the filter, the most recent first
+func (r *TelemetrySeries) FindAlerts(ctx context.Context, f internal.SpeedAlertFilter) (a []internal.SpeedAlert, err error) {
+	r.alertsMu.Lock()
+	defer r.alertsMu.Unlock()
+
+	cutoff := r.cutoff()
+	a = []internal.SpeedAlert{}
+	for i := len(r.alerts) - 1; i >= 0; i-- {
+		value := r.alerts[i]
+		if value.Sample.Time.Before(cutoff) || value.Sample.Time.Before(f.From) {
+			break
+		}
+		if f.VehicleId == 0 || value.Sample.VehicleId == f.VehicleId {
+			a = append(a, value)
+		}
+	}
+	return
+}
+
+// Sweep is a method that drops the samples and alerts past the retention, the series of the
+// vehicles whose trackers went silent included, so that the memory is bounded by the retention
+// and not only by the vehicles still sending
+func (r *TelemetrySeries) Sweep(ctx context.Context) (err error) {
+	cutoff := r.cutoff()
+
+	r.mu.RLock()
+	ids := make([]int, 0, len(r.series))
+	for vehicleId := range r.series {
+		ids = append(ids, vehicleId)
+	}
+	r.mu.RUnlock()
+
+	for _, vehicleId := range ids {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		r.sweep(vehicleId, cutoff)
+	}
+
+	r.alertsMu.Lock()
+	r.pruneAlerts(cutoff)
+	r.alertsMu.Unlock()
+	return
+}
+
+// sweep is a method that drops the samples of a vehicle before cutoff, and its series once empty
+func (r *TelemetrySeries) sweep(vehicleId int, cutoff time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sr, ok := r.series[vehicleId]
+	if !ok {
+		return
+	}
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.prune(cutoff)
+	if len(sr.samples) > 0 {
+		return
+	}
+	// an Append holding the series writes to a new one
+	sr.removed = true
+	delete(r.series, vehicleId)
+	r.positions.Delete(vehicleId)
+}
+
+// pruneAlerts is a method that drops the alerts before cutoff, the lock of the alerts held
+func (r *TelemetrySeries) pruneAlerts(cutoff time.Time) {
+	i := sort.Search(len(r.alerts), func(i int) bool { return !r.alerts[i].Sample.Time.Before(cutoff) })
+	if i > 0 {
+		r.alerts = append([]internal.SpeedAlert(nil), r.alerts[i:]...)
+	}
+}
+
+// cutoff is a method that returns the time before which the samples and alerts are dropped
+func (r *TelemetrySeries) cutoff() time.Time {
+	return time.Now().Add(-r.retention)
+}
+
+// seriesOf is a method that returns the series of a vehicle, created when there is none
+func (r *TelemetrySeries) seriesOf(vehicleId int) *series {
+	r.mu.RLock()
+	sr, ok := r.series[vehicleId]
+	r.mu.RUnlock()
+	if ok {
+		return sr
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if sr, ok = r.series[vehicleId]; !ok {
+		sr = &series{}
+		r.series[vehicleId] = sr
+	}
+	return sr
+}
+
+// insert is a method that adds a sample in time order, replacing one at the same time,
+// appended without search when it is the most recent as the trackers mostly send in order
+func (sr *series) insert(s internal.TelemetrySample) {
+	n := len(sr.samples)
+	if n == 0 || s.Time.After(sr.samples[n-1].Time) {
+		sr.samples = append(sr.samples, s)
+		return
+	}
+	i := sort.Search(n, func(i int) bool { return !sr.samples[i].Time.Before(s.Time) })
+	if i < n && sr.samples[i].Time.Equal(s.Time) {
+		sr.samples[i] = s
+		return
+	}
+	sr.samples = append(sr.samples, internal.TelemetrySample{})
+	copy(sr.samples[i+1:], sr.samples[i:])
+	sr.samples[i] = s
+}
+
+// prune is a method that drops the samples before cutoff, the backing array reallocated once
+// most of it is dropped so that the memory is released
+func (sr *series) prune(cutoff time.Time) {
+	i := sort.Search(len(sr.samples), func(i int) bool { return !sr.samples[i].Time.Before(cutoff) })
+	if i == 0 {
+		return
+	}
+	if i > cap(sr.samples)/2 {
+		sr.samples = append([]internal.TelemetrySample(nil), sr.samples[i:]...)
+		return
+	}
+	sr.samples = sr.samples[i:]
+}
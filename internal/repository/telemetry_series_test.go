@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"app/pkg/geo"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestTelemetrySeries_Sweep is a function that checks that the samples and alerts past the
+// retention are dropped, the series of the vehicles no longer sending samples included
+func TestTelemetrySeries_Sweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	silent := internal.TelemetrySample{VehicleId: 1, Time: now.Add(-30 * time.Minute), Latitude: -23.55, Longitude: -46.63, Speed: 120}
+	active := internal.TelemetrySample{VehicleId: 2, Time: now, Latitude: -23.55, Longitude: -46.63, Speed: 40}
+	r := NewTelemetrySeries(time.Hour)
+	if err := r.Append(ctx, []internal.TelemetrySample{silent, active}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := r.AppendAlerts(ctx, []internal.SpeedAlert{{Sample: silent, MaxSpeed: 100}}); err != nil {
+		t.Fatalf("append alerts: %v", err)
+	}
+
+	r.retention = 10 * time.Minute
+	if err := r.Sweep(ctx); err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+
+	if _, ok := r.series[silent.VehicleId]; ok {
+		t.Error("series of the silent vehicle kept")
+	}
+	if len(r.alerts) != 0 {
+		t.Errorf("alerts = %v, want none", r.alerts)
+	}
+	if _, err := r.Latest(ctx, silent.VehicleId); !errors.Is(err, apperrors.ErrTelemetryNotFound) {
+		t.Errorf("latest of the silent vehicle: err = %v, want %v", err, apperrors.ErrTelemetryNotFound)
+	}
+	if s, err := r.Latest(ctx, active.VehicleId); err != nil || !s.Time.Equal(active.Time) {
+		t.Errorf("latest of the active vehicle = %v, %v, want %v", s, err, active)
+	}
+	n, err := r.Nearby(ctx, geo.Point{Longitude: -46.63, Latitude: -23.55}, 1000)
+	if err != nil || len(n) != 1 || n[0].Sample.VehicleId != active.VehicleId {
+		t.Errorf("nearby = %v, %v, want only vehicle %d", n, err, active.VehicleId)
+	}
+
+	// a sample of the swept vehicle starts a new series
+	back := internal.TelemetrySample{VehicleId: 1, Time: now, Latitude: -23.55, Longitude: -46.63}
+	if err := r.Append(ctx, []internal.TelemetrySample{back}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if s, err := r.Latest(ctx, back.VehicleId); err != nil || !s.Time.Equal(back.Time) {
+		t.Errorf("latest of the vehicle back = %v, %v, want %v", s, err, back)
+	}
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewTripMap is a function that returns a new instance of TripMap with the trips, their ids kept
+func NewTripMap(trips []internal.Trip) *TripMap {
+	m := &TripMap{trips: make(map[int]internal.Trip)}
+	for _, t := range trips {
+		m.trips[t.Id] = t
+		m.lastId = max(m.lastId, t.Id)
+	}
+	return m
+}
+
+// TripMap is a struct that implements the TripRepository interface in memory,
+// safe for concurrent use
+type TripMap struct {
+	mu sync.RWMutex
+	// trips are the trips by id
+	trips map[int]internal.Trip
+	// lastId is the highest id assigned
+	lastId int
+}
+
+// FindAll is a method that returns the trips matching the filter, by start
+func (m *TripMap) FindAll(ctx context.Context, f internal.TripFilter) (t []internal.Trip, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	from, to := f.From, f.To
+	if to.IsZero() {
+		to = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	t = []internal.Trip{}
+	for _, value := range m.trips {
+		if f.VehicleId != 0 && value.VehicleId != f.VehicleId {
+			continue
+		}
+		if !value.Overlaps(from, to) {
+			continue
+		}
+		t = append(t, value)
+	}
+	sort.Slice(t, func(i, j int) bool {
+		if !t[i].Start.Equal(t[j].Start) {
+			return t[i].Start.Before(t[j].Start)
+		}
+		return t[i].Id < t[j].Id
+	})
+	return
+}
+
+// Save is a method that records a trip, assigning its id, its readings checked against the
+// trips of the vehicle before and after it
+func (m *TripMap) Save(ctx context.Context, t *internal.Trip) (v internal.Trip, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, value := range m.trips {
+		if value.VehicleId != t.VehicleId {
+			continue
+		}
+		switch {
+		case value.Overlaps(t.Start, t.End):
+			err = fmt.Errorf("%w: trip %d from %s to %s", apperrors.ErrTripConflict,
+				value.Id, value.Start.Format(time.RFC3339), value.End.Format(time.RFC3339))
+			return
+		case !value.End.After(t.Start) && value.EndOdometer > t.StartOdometer:
+			err = fmt.Errorf("%w: trip %d ended at %g km", apperrors.ErrOdometerDecreased, value.Id, value.EndOdometer)
+			return
+		case !t.End.After(value.Start) && t.EndOdometer > value.StartOdometer:
+			err = fmt.Errorf("%w: trip %d started at %g km", apperrors.ErrOdometerDecreased, value.Id, value.StartOdometer)
+			return
+		}
+	}
+
+	m.lastId++
+	v = *t
+	v.Id = m.lastId
+	m.trips[v.Id] = v
+	return
+}
+
+// Delete is a method that removes a trip of a vehicle
+func (m *TripMap) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if t, ok := m.trips[id]; !ok || t.VehicleId != vehicleId {
+		err = fmt.Errorf("%w: %d", apperrors.ErrTripNotFound, id)
+		return
+	}
+	delete(m.trips, id)
+	return
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestTripMap_Save is a function that checks that a trip overlapping another of its vehicle, or
+// whose odometer readings would make the odometer decrease, is rejected
+func TestTripMap_Save(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, time.October, 20, hour, 0, 0, 0, time.UTC) }
+	trips := []internal.Trip{
+		{Id: 1, VehicleId: 1, Start: at(8), End: at(10), StartOdometer: 1000, EndOdometer: 1100},
+		{Id: 2, VehicleId: 1, Start: at(14), End: at(16), StartOdometer: 1200, EndOdometer: 1300},
+	}
+
+	cases := map[string]struct {
+		trip    internal.Trip
+		wantErr error
+	}{
+		"between the trips": {trip: internal.Trip{VehicleId: 1, Start: at(10), End: at(12), StartOdometer: 1100, EndOdometer: 1200}},
+		"after the trips":   {trip: internal.Trip{VehicleId: 1, Start: at(17), End: at(18), StartOdometer: 1300, EndOdometer: 1350}},
+		"other vehicle":     {trip: internal.Trip{VehicleId: 2, Start: at(9), End: at(10), StartOdometer: 10, EndOdometer: 20}},
+		"overlapping":       {trip: internal.Trip{VehicleId: 1, Start: at(9), End: at(11), StartOdometer: 1100, EndOdometer: 1150}, wantErr: apperrors.ErrTripConflict},
+		"starting below the trip before": {
+			trip:    internal.Trip{VehicleId: 1, Start: at(11), End: at(12), StartOdometer: 1050, EndOdometer: 1150},
+			wantErr: apperrors.ErrOdometerDecreased,
+		},
+		"ending above the trip after": {
+			trip:    internal.Trip{VehicleId: 1, Start: at(11), End: at(12), StartOdometer: 1100, EndOdometer: 1250},
+			wantErr: apperrors.ErrOdometerDecreased,
+		},
+		"before the trips above them": {
+			trip:    internal.Trip{VehicleId: 1, Start: at(6), End: at(7), StartOdometer: 1400, EndOdometer: 1450},
+			wantErr: apperrors.ErrOdometerDecreased,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := NewTripMap(trips)
+
+			v, err := rp.Save(context.Background(), &c.trip)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got error %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if v.Id != 3 {
+				t.Errorf("got id %d, want 3", v.Id)
+			}
+		})
+	}
+}
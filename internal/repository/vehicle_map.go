@@ -2,12 +2,20 @@ package repository
 
 import (
 	"app/internal"
+	"app/internal/search"
 	"app/pkg/apperrors"
-	"app/pkg/utils"
+	"app/pkg/logger"
+	"app/pkg/plate"
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // NewVehicleMap is a function that returns a new instance of VehicleMap
@@ -17,28 +25,55 @@ func NewVehicleMap(db map[int]internal.Vehicle) *VehicleMap {
 	if db != nil {
 		defaultDb = db
 	}
-	return &VehicleMap{db: defaultDb}
+	r := &VehicleMap{
+		db:            defaultDb,
+		index:         search.NewIndex(),
+		registrations: make(map[string]map[int]struct{}),
+	}
+	for _, value := range defaultDb {
+		r.index.Put(value.Id, searchFields(value)...)
+		r.register(value)
+		r.lastId = max(r.lastId, value.Id)
+	}
+	return r
 }
 
-// VehicleMap is a struct that represents a vehicle repository
+// VehicleMap is a struct that represents a vehicle repository, safe for concurrent use
 type VehicleMap struct {
+	mu sync.RWMutex
 	db map[int]internal.Vehicle
+	// index is the full-text index of the vehicles, updated on every change of db
+	index *search.Index
+	// registrations are the ids of the vehicles by normalized registration, one per registration
+	// but for the duplicates already present in the loaded vehicles
+	registrations map[string]map[int]struct{}
+	// lastId is the highest id assigned, never reused after a delete
+	lastId int
 }
 
 // FindAll is a method that returns a map of all vehicles
-func (r *VehicleMap) FindAll() (v map[int]internal.Vehicle, err error) {
+func (r *VehicleMap) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	// copy db
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		v[key] = value
 	}
 
 	return
 }
 
-func (r *VehicleMap) FindById(id string) (v internal.Vehicle, err error) {
-	fmt.Println("Query parans", id)
+func (r *VehicleMap) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger.FromContext(ctx).Debug("repository: find by id", slog.String("id", id))
 	idInt, err := strconv.Atoi(id)
 
 	if err != nil {
@@ -46,6 +81,9 @@ func (r *VehicleMap) FindById(id string) (v internal.Vehicle, err error) {
 	}
 
 	for _, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.Id == idInt {
 			v = value
 		}
@@ -53,7 +91,13 @@ func (r *VehicleMap) FindById(id string) (v internal.Vehicle, err error) {
 	return
 }
 
-func (r *VehicleMap) DeleteById(id string) (err error) {
+func (r *VehicleMap) DeleteById(ctx context.Context, id string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
 	idInt, err := strconv.Atoi(id)
 
 	if err != nil {
@@ -67,16 +111,24 @@ func (r *VehicleMap) DeleteById(id string) (err error) {
 		return
 	}
 
+	r.unregister(r.db[idInt])
 	delete(r.db, idInt)
+	r.index.Delete(idInt)
 
 	return
 
 }
 
-func (r *VehicleMap) FindByTransmissionType(typeTransmission string) (v map[int]internal.Vehicle, err error) {
+func (r *VehicleMap) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.VehicleAttributes.Transmission == typeTransmission {
 			v[key] = value
 		}
@@ -85,26 +137,41 @@ func (r *VehicleMap) FindByTransmissionType(typeTransmission string) (v map[int]
 
 }
 
-func (r *VehicleMap) UpdateFuel(id int, fuel string) (v internal.Vehicle, err error) {
+func (r *VehicleMap) UpdateFuel(ctx context.Context, id int, fuel string) (v internal.Vehicle, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	_, ok := r.db[id]
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	vehicle, ok := r.db[id]
 
 	if !ok {
 		err = apperrors.ErrVehicleNotFound
+		return
 	}
 
-	v = r.db[id]
-	v.FuelType = fuel
+	vehicle.FuelType = fuel
+	r.db[id] = vehicle
+	r.index.Put(id, searchFields(vehicle)...)
+	v = vehicle
 
 	return
 
 }
-func (r *VehicleMap) FindTipoCombustivel(FuelType string) (v map[int]internal.Vehicle, err error) {
-	fmt.Println("Query parans", FuelType)
+func (r *VehicleMap) FindTipoCombustivel(ctx context.Context, FuelType string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger.FromContext(ctx).Debug("repository: find by fuel type", slog.String("fuel_type", FuelType))
 
 	v = make(map[int]internal.Vehicle)
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.VehicleAttributes.FuelType == FuelType {
 			v[key] = value
 		}
@@ -112,14 +179,18 @@ func (r *VehicleMap) FindTipoCombustivel(FuelType string) (v map[int]internal.Ve
 	return
 }
 
-func (r *VehicleMap) FindVelocidadeMediaMarca(brand string) (m float64, err error) {
-	fmt.Println("Query parans", brand)
-	brandCaptalize := utils.CapitalizeFirst(brand)
+func (r *VehicleMap) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
+	logger.FromContext(ctx).Debug("repository: average speed by brand", slog.String("brand", brand))
 	sum := 0.0
 	count := 0
 	for _, value := range r.db {
-		if value.Brand == brandCaptalize {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if strings.EqualFold(value.Brand, brand) {
 			sum += value.MaxSpeed
 			count += 1
 
@@ -135,7 +206,9 @@ func (r *VehicleMap) FindVelocidadeMediaMarca(brand string) (m float64, err erro
 	return
 }
 
-func (r *VehicleMap) FindByPeso(min, max string) (v map[int]internal.Vehicle, err error) {
+func (r *VehicleMap) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	minFloat, err := strconv.ParseFloat(min, 64)
 	v = make(map[int]internal.Vehicle)
@@ -153,6 +226,9 @@ func (r *VehicleMap) FindByPeso(min, max string) (v map[int]internal.Vehicle, er
 	}
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.VehicleAttributes.Weight >= minFloat &&
 			value.VehicleAttributes.Weight <= maxFloat {
 			v[key] = value
@@ -167,10 +243,15 @@ func (r *VehicleMap) FindByPeso(min, max string) (v map[int]internal.Vehicle, er
 }
 
 // FindAll is a method that returns a map of all vehicles
-func (r *VehicleMap) FindByMarcaAndYearInterval(brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
-	fmt.Println("Query parans", brand, start_year, end_year)
-	brandCaptalize := utils.CapitalizeFirst(brand)
-
+func (r *VehicleMap) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger.FromContext(ctx).Debug("repository: find by brand and year interval",
+		slog.String("brand", brand),
+		slog.String("start_year", start_year),
+		slog.String("end_year", end_year),
+	)
 	v = make(map[int]internal.Vehicle)
 
 	startYearInt, err := strconv.Atoi(start_year)
@@ -183,7 +264,10 @@ func (r *VehicleMap) FindByMarcaAndYearInterval(brand, start_year, end_year stri
 	}
 
 	for key, value := range r.db {
-		if value.Brand == brandCaptalize &&
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if strings.EqualFold(value.Brand, brand) &&
 			value.FabricationYear >= startYearInt &&
 			value.FabricationYear <= endYearInt {
 			v[key] = value
@@ -193,7 +277,9 @@ func (r *VehicleMap) FindByMarcaAndYearInterval(brand, start_year, end_year stri
 	return
 }
 
-func (r *VehicleMap) FindByColorAndYears(color, year string) (v map[int]internal.Vehicle, err error) {
+func (r *VehicleMap) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	v = make(map[int]internal.Vehicle)
 
@@ -204,6 +290,9 @@ func (r *VehicleMap) FindByColorAndYears(color, year string) (v map[int]internal
 	}
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.Color == color && value.FabricationYear == yearInt {
 			v[key] = value
 		}
@@ -212,69 +301,110 @@ func (r *VehicleMap) FindByColorAndYears(color, year string) (v map[int]internal
 	return
 }
 
-func (r *VehicleMap) Save(vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+func (r *VehicleMap) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
 	attr := internal.Vehicle{
-		VehicleAttributes: internal.VehicleAttributes{
-
-			Brand:        vh.Brand,
-			Model:        vh.Model,
-			Registration: vh.Registration,
-
-			Color:           vh.Color,
-			FabricationYear: vh.FabricationYear,
-			Capacity:        vh.Capacity,
-			MaxSpeed:        vh.MaxSpeed,
-			FuelType:        vh.FuelType,
-			Transmission:    vh.Transmission,
-			Weight:          vh.Weight,
-			Dimensions: internal.Dimensions{
-				Height: vh.Height,
-				Width:  vh.Weight,
-				Length: vh.Length,
-			},
-		},
-	}
-
-	attr.Id = len(r.db) + 1
+		VehicleAttributes: *vh,
+	}
+	// the vehicles read share their tags and custom attributes, the ones written are copied
+	attr.Tags, attr.Custom = slices.Clone(attr.Tags), maps.Clone(attr.Custom)
+
+	if r.taken(attr.Registration, 0) {
+		err = apperrors.ErrVehicleAlreadyExists
+		return
+	}
+	r.lastId++
+	attr.Id = r.lastId
 	r.db[attr.Id] = attr
+	r.register(attr)
+	r.index.Put(attr.Id, searchFields(attr)...)
 
 	v = attr
 
 	return
 }
 
-func (r *VehicleMap) Patch(vh *internal.Vehicle) (v internal.Vehicle, err error) {
+// SaveBatch is a method that adds the vehicles under one lock, all or none: the registrations taken
+// or repeated in the batch are checked before any vehicle is stored
+func (r *VehicleMap) SaveBatch(ctx context.Context, vh []internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	batch := make(map[string]struct{}, len(vh))
+	for _, attrs := range vh {
+		key := plate.Normalize(attrs.Registration)
+		if _, repeated := batch[key]; repeated || r.taken(attrs.Registration, 0) {
+			err = fmt.Errorf("%w: %s", apperrors.ErrVehicleAlreadyExists, attrs.Registration)
+			return
+		}
+		batch[key] = struct{}{}
+	}
+
+	v = make(map[int]internal.Vehicle, len(vh))
+	for _, attrs := range vh {
+		vehicle := internal.Vehicle{VehicleAttributes: attrs}
+		// the vehicles read share their tags and custom attributes, the ones written are copied
+		vehicle.Tags, vehicle.Custom = slices.Clone(vehicle.Tags), maps.Clone(vehicle.Custom)
+		r.lastId++
+		vehicle.Id = r.lastId
+		r.db[vehicle.Id] = vehicle
+		r.register(vehicle)
+		r.index.Put(vehicle.Id, searchFields(vehicle)...)
+		v[vehicle.Id] = vehicle
+	}
+	return
+}
+
+func (r *VehicleMap) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
 	attr := internal.Vehicle{
-		Id: vh.Id,
-		VehicleAttributes: internal.VehicleAttributes{
-
-			Brand:        vh.Brand,
-			Model:        vh.Model,
-			Registration: vh.Registration,
-
-			Color:           vh.Color,
-			FabricationYear: vh.FabricationYear,
-			Capacity:        vh.Capacity,
-			MaxSpeed:        vh.MaxSpeed,
-			FuelType:        vh.FuelType,
-			Transmission:    vh.Transmission,
-			Weight:          vh.Weight,
-			Dimensions: internal.Dimensions{
-				Height: vh.Height,
-				Width:  vh.Weight,
-				Length: vh.Length,
-			},
-		},
+		Id:                vh.Id,
+		VehicleAttributes: vh.VehicleAttributes,
+	}
+	// the vehicles read share their tags and custom attributes, the ones written are copied
+	attr.Tags, attr.Custom = slices.Clone(attr.Tags), maps.Clone(attr.Custom)
+	// the duplicates loaded keep their registration, only a change to one of another vehicle fails
+	current, ok := r.db[attr.Id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrVehicleNotFound, attr.Id)
+		return
+	}
+	changed := plate.Normalize(current.Registration) != plate.Normalize(attr.Registration)
+	if changed && r.taken(attr.Registration, attr.Id) {
+		err = apperrors.ErrVehicleAlreadyExists
+		return
 	}
 
+	r.unregister(current)
 	r.db[attr.Id] = attr
+	r.register(attr)
+	r.index.Put(attr.Id, searchFields(attr)...)
 
 	v = attr
 
 	return
 }
 
-func (r *VehicleMap) UpdateMaxSpeed(id int, maxSpeed float64) (v internal.Vehicle, err error) {
+func (r *VehicleMap) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
 
 	vehicle, ok := r.db[id]
 
@@ -285,20 +415,24 @@ func (r *VehicleMap) UpdateMaxSpeed(id int, maxSpeed float64) (v internal.Vehicl
 
 	vehicle.MaxSpeed = maxSpeed
 	r.db[id] = vehicle
+	r.index.Put(id, searchFields(vehicle)...)
 	v = vehicle
 
 	return
 }
 
-func (r *VehicleMap) FindMediaPessoaPorMarca(brand string) (m int, err error) {
-	brandCapitalized := utils.CapitalizeFirst(brand)
+func (r *VehicleMap) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	var count int
 	var sum int
 
 	for _, value := range r.db {
-		if value.VehicleAttributes.Brand == brandCapitalized {
-			fmt.Println(value)
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if strings.EqualFold(value.VehicleAttributes.Brand, brand) {
 			count += 1
 			sum += (value.VehicleAttributes.Capacity)
 		}
@@ -313,7 +447,10 @@ func (r *VehicleMap) FindMediaPessoaPorMarca(brand string) (m int, err error) {
 	return
 }
 
-func (r *VehicleMap) FindByDimenssion(lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+func (r *VehicleMap) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	lengthParams := strings.Split(lengthParam, "-")
 	widthParams := strings.Split(widthParam, "-")
 
@@ -337,16 +474,19 @@ func (r *VehicleMap) FindByDimenssion(lengthParam, widthParam string) (v map[int
 		return v, fmt.Errorf("invalid width max: %w", err)
 	}
 
-	fmt.Println(lengthParams)
-	fmt.Println(widthParams)
+	logger.FromContext(ctx).Debug("repository: find by dimensions",
+		slog.Any("length", lengthParams),
+		slog.Any("width", widthParams),
+	)
 
 	for key, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
 		if value.VehicleAttributes.Dimensions.Length >= lengthMin &&
 			value.VehicleAttributes.Dimensions.Length <= lengthMax &&
 			value.VehicleAttributes.Dimensions.Width >= widthMin &&
 			value.VehicleAttributes.Dimensions.Width <= widthMax {
-			fmt.Println(value)
-
 			v[key] = value
 
 		}
@@ -358,3 +498,134 @@ func (r *VehicleMap) FindByDimenssion(lengthParam, widthParam string) (v map[int
 
 	return
 }
+
+// Canonicalize is a method that replaces the brands and models of the vehicles by their canonical
+// names in ct, for the vehicles stored under a name that became a brand or an alias
+func (r *VehicleMap) Canonicalize(ctx context.Context, ct internal.BrandCatalog) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, value := range r.db {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		attrs := value.VehicleAttributes
+		ct.Canonicalize(&attrs)
+		if attrs.Brand == value.Brand && attrs.Model == value.Model {
+			continue
+		}
+		value.VehicleAttributes = attrs
+		r.db[id] = value
+		r.index.Put(id, searchFields(value)...)
+		n++
+	}
+	return
+}
+
+// Search is a method that returns the vehicles matching the words of query in the index,
+// the most relevant first, at most limit of them
+func (r *VehicleMap) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger.FromContext(ctx).Debug("repository: search", slog.String("query", query))
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	for _, result := range r.index.Search(query, limit) {
+		vehicle, ok := r.db[result.ID]
+		if !ok {
+			continue
+		}
+		v = append(v, internal.VehicleMatch{Vehicle: vehicle, Score: result.Score})
+	}
+	return
+}
+
+// searchFields is a function that returns the fields of a vehicle in the search index,
+// a registration being the most specific
+func searchFields(vh internal.Vehicle) []search.Field {
+	return []search.Field{
+		{Text: vh.Registration, Weight: 3},
+		{Text: vh.Brand, Weight: 2},
+		{Text: vh.Model, Weight: 2},
+		{Text: vh.Color, Weight: 1},
+	}
+}
+
+// FindByRegistration is a method that returns the vehicle of a registration, matched without
+// case and separators. Of the duplicates loaded, the lowest id is returned.
+func (r *VehicleMap) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger.FromContext(ctx).Debug("repository: find by registration", slog.String("registration", registration))
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	ids := r.registrations[plate.Normalize(registration)]
+	if len(ids) == 0 {
+		err = apperrors.ErrVehicleNotFound
+		return
+	}
+	first := -1
+	for id := range ids {
+		if first < 0 || id < first {
+			first = id
+		}
+	}
+	v = r.db[first]
+	return
+}
+
+// DuplicateRegistrations is a method that returns the ids, in order, of the vehicles sharing
+// a registration, by normalized registration
+func (r *VehicleMap) DuplicateRegistrations() (d map[string][]int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d = make(map[string][]int)
+	for registration, ids := range r.registrations {
+		if len(ids) < 2 {
+			continue
+		}
+		for id := range ids {
+			d[registration] = append(d[registration], id)
+		}
+		sort.Ints(d[registration])
+	}
+	return
+}
+
+// taken is a method that reports whether a registration belongs to a vehicle other than id,
+// the lock held
+func (r *VehicleMap) taken(registration string, id int) bool {
+	for other := range r.registrations[plate.Normalize(registration)] {
+		if other != id {
+			return true
+		}
+	}
+	return false
+}
+
+// register is a method that adds a vehicle to the index of the registrations, the lock held
+func (r *VehicleMap) register(vh internal.Vehicle) {
+	key := plate.Normalize(vh.Registration)
+	ids, ok := r.registrations[key]
+	if !ok {
+		ids = make(map[int]struct{})
+		r.registrations[key] = ids
+	}
+	ids[vh.Id] = struct{}{}
+}
+
+// unregister is a method that removes a vehicle from the index of the registrations, the lock held
+func (r *VehicleMap) unregister(vh internal.Vehicle) {
+	key := plate.Normalize(vh.Registration)
+	delete(r.registrations[key], vh.Id)
+	if len(r.registrations[key]) == 0 {
+		delete(r.registrations, key)
+	}
+}
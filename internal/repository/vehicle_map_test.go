@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newScanMap is a function that returns a repository with n vehicles of the same brand, enough
+// for a scan to check its context many times
+func newScanMap(n int) *VehicleMap {
+	db := make(map[int]internal.Vehicle, n)
+	for id := 1; id <= n; id++ {
+		db[id] = internal.Vehicle{
+			Id: id,
+			VehicleAttributes: internal.VehicleAttributes{
+				Brand:           "Fiat",
+				Model:           "Uno",
+				Registration:    fmt.Sprintf("ABC%04d", id),
+				Color:           "Red",
+				FabricationYear: 2020,
+				Capacity:        5,
+				MaxSpeed:        150,
+				Dimensions:      internal.Dimensions{Length: 3.8, Width: 1.6, Height: 1.5},
+			},
+		}
+	}
+	return NewVehicleMap(db)
+}
+
+// TestVehicleMap_ScansHonorContext is a function that checks that the scans of the repository stop
+// with the error of a canceled or expired context
+func TestVehicleMap_ScansHonorContext(t *testing.T) {
+	rp := newScanMap(10000)
+
+	scans := map[string]func(ctx context.Context) error{
+		"FindAll": func(ctx context.Context) error {
+			_, err := rp.FindAll(ctx)
+			return err
+		},
+		"FindByDimenssion": func(ctx context.Context) error {
+			_, err := rp.FindByDimenssion(ctx, "0-10", "0-10")
+			return err
+		},
+		"FindVelocidadeMediaMarca": func(ctx context.Context) error {
+			_, err := rp.FindVelocidadeMediaMarca(ctx, "Fiat")
+			return err
+		},
+		"FindMediaPessoaPorMarca": func(ctx context.Context) error {
+			_, err := rp.FindMediaPessoaPorMarca(ctx, "Fiat")
+			return err
+		},
+		"FindByMarcaAndYearInterval": func(ctx context.Context) error {
+			_, err := rp.FindByMarcaAndYearInterval(ctx, "Fiat", "2000", "2030")
+			return err
+		},
+	}
+
+	contexts := map[string]struct {
+		ctx  func() (context.Context, context.CancelFunc)
+		want error
+	}{
+		"canceled": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			want: context.Canceled,
+		},
+		"deadline exceeded": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			},
+			want: context.DeadlineExceeded,
+		},
+		"canceled during the scan": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				return &cancelAfter{Context: ctx, cancel: cancel, checks: 50}, cancel
+			},
+			want: context.Canceled,
+		},
+	}
+
+	for scan, fn := range scans {
+		for name, c := range contexts {
+			t.Run(scan+"/"+name, func(t *testing.T) {
+				ctx, cancel := c.ctx()
+				defer cancel()
+
+				if err := fn(ctx); !errors.Is(err, c.want) {
+					t.Errorf("got error %v, want %v", err, c.want)
+				}
+			})
+		}
+	}
+}
+
+// cancelAfter is a struct that represents a context canceled by its own Err once checked a number
+// of times, so that a scan is canceled while it iterates
+type cancelAfter struct {
+	context.Context
+	cancel context.CancelFunc
+	// checks is how many more times Err is called before the context is canceled
+	checks int
+}
+
+// Err is a method that cancels the context once checked the number of times set
+func (c *cancelAfter) Err() error {
+	if c.checks--; c.checks == 0 {
+		c.cancel()
+	}
+	return c.Context.Err()
+}
+
+// TestVehicleMap_ScansWithLiveContext is a function that checks that the same scans succeed when
+// the context is still live
+func TestVehicleMap_ScansWithLiveContext(t *testing.T) {
+	rp := newScanMap(100)
+	ctx := context.Background()
+
+	all, err := rp.FindAll(ctx)
+	if err != nil || len(all) != 100 {
+		t.Fatalf("FindAll: got %d vehicles and error %v, want 100 and none", len(all), err)
+	}
+	dims, err := rp.FindByDimenssion(ctx, "0-10", "0-10")
+	if err != nil || len(dims) != 100 {
+		t.Fatalf("FindByDimenssion: got %d vehicles and error %v, want 100 and none", len(dims), err)
+	}
+	speed, err := rp.FindVelocidadeMediaMarca(ctx, "fiat")
+	if err != nil || speed != 150 {
+		t.Fatalf("FindVelocidadeMediaMarca: got %v and error %v, want 150 and none", speed, err)
+	}
+}
+
+// TestVehicleMap_SaveBatch is a function that checks that a batch is stored all or none, a
+// registration taken or repeated in it storing no vehicle
+func TestVehicleMap_SaveBatch(t *testing.T) {
+	batch := func(registrations ...string) (vh []internal.VehicleAttributes) {
+		for _, registration := range registrations {
+			vh = append(vh, internal.VehicleAttributes{Brand: "Fiat", Registration: registration})
+		}
+		return
+	}
+
+	cases := map[string]struct {
+		vh      []internal.VehicleAttributes
+		wantErr error
+	}{
+		"new registrations": {vh: batch("NEW1A11", "NEW2B22")},
+		"taken":             {vh: batch("NEW1A11", "ABC0002"), wantErr: apperrors.ErrVehicleAlreadyExists},
+		"taken differently written": {
+			vh:      batch("NEW1A11", "abc-0002"),
+			wantErr: apperrors.ErrVehicleAlreadyExists,
+		},
+		"repeated in the batch": {vh: batch("NEW1A11", "new1a11"), wantErr: apperrors.ErrVehicleAlreadyExists},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := newScanMap(3)
+
+			v, err := rp.SaveBatch(context.Background(), c.vh)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("got error %v, want %v", err, c.wantErr)
+			}
+
+			all, _ := rp.FindAll(context.Background())
+			if c.wantErr != nil {
+				if len(all) != 3 {
+					t.Fatalf("got %d vehicles stored, want the 3 before the batch", len(all))
+				}
+				return
+			}
+			if len(v) != len(c.vh) || len(all) != 3+len(c.vh) {
+				t.Fatalf("got %d vehicles saved and %d stored, want %d and %d", len(v), len(all), len(c.vh), 3+len(c.vh))
+			}
+			for id := range v {
+				if id <= 3 {
+					t.Errorf("got id %d, want an id after the ones loaded", id)
+				}
+			}
+		})
+	}
+}
+
+// TestVehicleMap_Patch is a function that checks that a patch fails only when it changes the
+// registration to one of another vehicle or the vehicle is deleted, the duplicates loaded staying
+// patchable
+func TestVehicleMap_Patch(t *testing.T) {
+	cases := map[string]struct {
+		id           int
+		registration string
+		deleted      bool
+		wantErr      error
+	}{
+		"duplicate kept":                 {id: 1, registration: "DUP1A11"},
+		"duplicate differently written":  {id: 2, registration: "dup-1a11"},
+		"changed to a free one":          {id: 1, registration: "NEW1A11"},
+		"changed to a taken one":         {id: 3, registration: "DUP1A11", wantErr: apperrors.ErrVehicleAlreadyExists},
+		"duplicate changed to the other": {id: 1, registration: "ABC0003", wantErr: apperrors.ErrVehicleAlreadyExists},
+		"deleted vehicle":                {id: 3, registration: "ABC0003", deleted: true, wantErr: apperrors.ErrVehicleNotFound},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			rp := newScanMap(3)
+			// the vehicles 1 and 2 share a registration, as loaded from the seed file
+			for _, id := range []int{1, 2} {
+				vh := rp.db[id]
+				rp.unregister(vh)
+				vh.Registration = "DUP1A11"
+				rp.db[id] = vh
+				rp.register(vh)
+			}
+
+			vh := rp.db[c.id]
+			if c.deleted {
+				if err := rp.DeleteById(context.Background(), strconv.Itoa(c.id)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			vh.Registration = c.registration
+			vh.Color = "Blue"
+			v, err := rp.Patch(context.Background(), &vh)
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("got error %v, want %v", err, c.wantErr)
+			}
+			if c.deleted {
+				if _, ok := rp.db[c.id]; ok {
+					t.Errorf("got vehicle %d stored again, want it deleted", c.id)
+				}
+				return
+			}
+			if c.wantErr != nil {
+				return
+			}
+			if v.Color != "Blue" || rp.db[c.id].Registration != c.registration {
+				t.Errorf("got %+v stored, want the patch of vehicle %d", rp.db[c.id], c.id)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewZoneEventLog is a function that returns a new instance of ZoneEventLog keeping the events
+// of the last retention
+func NewZoneEventLog(retention time.Duration) *ZoneEventLog {
+	return &ZoneEventLog{
+		retention: retention,
+		vehicles:  make(map[int]*presence),
+	}
+}
+
+// ZoneEventLog is a struct that implements the ZoneEventRepository interface in memory, the
+// zones each vehicle is inside as of its latest sample and the log of the entries and exits.
+// Safe for concurrent use.
+type ZoneEventLog struct {
+	// retention is how long the events are kept
+	retention time.Duration
+
+	mu sync.Mutex
+	// vehicles are the zones of the vehicles by vehicle
+	vehicles map[int]*presence
+	// events are the zone events, the oldest first
+	events []internal.ZoneEvent
+	// lastId is the highest id assigned
+	lastId int
+}
+
+// presence is a struct that represents the zones a vehicle is inside as of its latest sample
+type presence struct {
+	// last is the latest sample of the vehicle
+	last internal.TelemetrySample
+	// zones are the times the vehicle entered the zones it is inside, by zone
+	zones map[int]time.Time
+}
+
+// Move is a method that places a vehicle inside the zones of ids at a sample, logging the exits
+// of the zones it left and then the entries of the zones it entered, by zone id
+func (l *ZoneEventLog) Move(ctx context.Context, s internal.TelemetrySample, ids []int) (e []internal.ZoneEvent, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e = []internal.ZoneEvent{}
+	p, ok := l.vehicles[s.VehicleId]
+	if !ok {
+		p = &presence{zones: make(map[int]time.Time)}
+		l.vehicles[s.VehicleId] = p
+	} else if !s.Time.After(p.last.Time) {
+		return
+	}
+	p.last = s
+
+	inside := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		inside[id] = true
+	}
+	var left, entered []int
+	for id := range p.zones {
+		if !inside[id] {
+			left = append(left, id)
+		}
+	}
+	for id := range inside {
+		if _, ok := p.zones[id]; !ok {
+			entered = append(entered, id)
+		}
+	}
+	sort.Ints(left)
+	sort.Ints(entered)
+
+	event := func(zoneId int, typ string) {
+		l.lastId++
+		e = append(e, internal.ZoneEvent{
+			Id:        l.lastId,
+			ZoneId:    zoneId,
+			VehicleId: s.VehicleId,
+			Type:      typ,
+			Time:      s.Time,
+			Position:  geo.Point{Longitude: s.Longitude, Latitude: s.Latitude},
+		})
+	}
+	for _, id := range left {
+		delete(p.zones, id)
+		event(id, internal.ZoneExit)
+	}
+	for _, id := range entered {
+		p.zones[id] = s.Time
+		event(id, internal.ZoneEnter)
+	}
+	if len(e) == 0 {
+		return
+	}
+
+	l.insert(e)
+	cutoff := l.cutoff()
+	i := sort.Search(len(l.events), func(i int) bool { return !l.events[i].Time.Before(cutoff) })
+	if i > 0 {
+		l.events = append([]internal.ZoneEvent(nil), l.events[i:]...)
+	}
+	return
+}
+
+// insert is a method that adds events of the same time after the events up to that time, the
+// samples of the vehicles arriving in order per vehicle only, the lock held
+func (l *ZoneEventLog) insert(e []internal.ZoneEvent) {
+	n, at := len(l.events), e[0].Time
+	if n == 0 || !at.Before(l.events[n-1].Time) {
+		l.events = append(l.events, e...)
+		return
+	}
+	i := sort.Search(n, func(i int) bool { return l.events[i].Time.After(at) })
+	l.events = append(l.events, e...)
+	copy(l.events[i+len(e):], l.events[i:n])
+	copy(l.events[i:], e)
+}
+
+// FindEvents is a method that returns the events matching the filter, the most recent first
+func (l *ZoneEventLog) FindEvents(ctx context.Context, f internal.ZoneEventFilter) (e []internal.ZoneEvent, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := l.cutoff()
+	e = []internal.ZoneEvent{}
+	for i := len(l.events) - 1; i >= 0; i-- {
+		value := l.events[i]
+		if value.Time.Before(cutoff) || value.Time.Before(f.From) {
+			break
+		}
+		if (f.ZoneId == 0 || value.ZoneId == f.ZoneId) && (f.VehicleId == 0 || value.VehicleId == f.VehicleId) {
+			e = append(e, value)
+		}
+	}
+	return
+}
+
+// Occupants is a method that returns the vehicles inside a zone, by vehicle id
+func (l *ZoneEventLog) Occupants(ctx context.Context, zoneId int) (o []internal.ZoneOccupant, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	o = []internal.ZoneOccupant{}
+	for vehicleId, p := range l.vehicles {
+		since, ok := p.zones[zoneId]
+		if !ok {
+			continue
+		}
+		o = append(o, internal.ZoneOccupant{
+			VehicleId: vehicleId,
+			Since:     since,
+			LastSeen:  p.last.Time,
+			Position:  geo.Point{Longitude: p.last.Longitude, Latitude: p.last.Latitude},
+		})
+	}
+	sort.Slice(o, func(i, j int) bool { return o[i].VehicleId < o[j].VehicleId })
+	return
+}
+
+// Forget is a method that removes the vehicles from a zone deleted
+func (l *ZoneEventLog) Forget(ctx context.Context, zoneId int) (err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, p := range l.vehicles {
+		delete(p.zones, zoneId)
+	}
+	return
+}
+
+// cutoff is a method that returns the time before which the events are dropped
+func (l *ZoneEventLog) cutoff() time.Time {
+	return time.Now().Add(-l.retention)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"app/internal"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestZoneEventLog_Move is a function that checks the exits and then the entries logged as a
+// vehicle moves across the zones, and that the log stays sorted by time across the vehicles
+func TestZoneEventLog_Move(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	at := func(vehicleId, minute int) internal.TelemetrySample {
+		return internal.TelemetrySample{VehicleId: vehicleId, Time: now.Add(time.Duration(minute) * time.Minute)}
+	}
+	type event struct {
+		zoneId int
+		typ    string
+	}
+
+	l := NewZoneEventLog(time.Hour)
+	moves := []struct {
+		name string
+		s    internal.TelemetrySample
+		ids  []int
+		want []event
+	}{
+		{name: "first sample inside", s: at(1, 0), ids: []int{2, 1}, want: []event{{1, internal.ZoneEnter}, {2, internal.ZoneEnter}}},
+		{name: "same zones", s: at(1, 1), ids: []int{1, 2}, want: []event{}},
+		{name: "left one entered another", s: at(1, 3), ids: []int{2, 3}, want: []event{{1, internal.ZoneExit}, {3, internal.ZoneEnter}}},
+		{name: "older sample ignored", s: at(1, 2), ids: nil, want: []event{}},
+		{name: "other vehicle late", s: at(2, 2), ids: []int{1}, want: []event{{1, internal.ZoneEnter}}},
+		{name: "left all", s: at(1, 4), ids: nil, want: []event{{2, internal.ZoneExit}, {3, internal.ZoneExit}}},
+	}
+	for _, m := range moves {
+		e, err := l.Move(ctx, m.s, m.ids)
+		if err != nil {
+			t.Fatalf("%s: %v", m.name, err)
+		}
+		got := make([]event, len(e))
+		for i, value := range e {
+			got[i] = event{value.ZoneId, value.Type}
+		}
+		if len(got) != len(m.want) {
+			t.Fatalf("%s: events = %v, want %v", m.name, got, m.want)
+		}
+		for i := range got {
+			if got[i] != m.want[i] {
+				t.Errorf("%s: events = %v, want %v", m.name, got, m.want)
+				break
+			}
+		}
+	}
+
+	for i := 1; i < len(l.events); i++ {
+		if l.events[i].Time.Before(l.events[i-1].Time) {
+			t.Fatalf("events not sorted by time at %d: %v", i, l.events)
+		}
+	}
+	if n := len(l.events); n != 7 || l.events[2].VehicleId != 2 {
+		t.Errorf("events = %v, want 7 with the late entry of vehicle 2 third", l.events)
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NewZoneMap is a function that returns a new instance of ZoneMap with the zones, their ids kept
+func NewZoneMap(zones []internal.Zone) *ZoneMap {
+	m := &ZoneMap{zones: make(map[int]internal.Zone), names: make(map[string]int)}
+	for _, z := range zones {
+		m.zones[z.Id] = z
+		m.names[zoneKey(z.Name)] = z.Id
+		m.lastId = max(m.lastId, z.Id)
+	}
+	return m
+}
+
+// ZoneMap is a struct that implements the ZoneRepository interface in memory, safe for concurrent use
+type ZoneMap struct {
+	mu sync.RWMutex
+	// zones are the zones by id
+	zones map[int]internal.Zone
+	// names are the ids of the zones by the key of their name
+	names map[string]int
+	// lastId is the highest id assigned
+	lastId int
+}
+
+// FindAll is a method that returns the zones by id
+func (m *ZoneMap) FindAll(ctx context.Context) (z []internal.Zone, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	z = make([]internal.Zone, 0, len(m.zones))
+	for _, value := range m.zones {
+		z = append(z, value)
+	}
+	sort.Slice(z, func(i, j int) bool { return z[i].Id < z[j].Id })
+	return
+}
+
+// FindById is a method that returns a zone
+func (m *ZoneMap) FindById(ctx context.Context, id int) (z internal.Zone, err error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	z, ok := m.zones[id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrZoneNotFound, id)
+	}
+	return
+}
+
+// Save is a method that adds a zone, assigning its id
+func (m *ZoneMap) Save(ctx context.Context, z *internal.Zone) (v internal.Zone, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := zoneKey(z.Name)
+	if _, ok := m.names[key]; ok {
+		err = fmt.Errorf("%w: %s", apperrors.ErrZoneAlreadyExists, z.Name)
+		return
+	}
+
+	m.lastId++
+	v = *z
+	v.Id = m.lastId
+	m.zones[v.Id] = v
+	m.names[key] = v.Id
+	return
+}
+
+// Update is a method that replaces a zone
+func (m *ZoneMap) Update(ctx context.Context, z *internal.Zone) (v internal.Zone, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	old, ok := m.zones[z.Id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrZoneNotFound, z.Id)
+		return
+	}
+	key := zoneKey(z.Name)
+	if id, ok := m.names[key]; ok && id != z.Id {
+		err = fmt.Errorf("%w: %s", apperrors.ErrZoneAlreadyExists, z.Name)
+		return
+	}
+
+	delete(m.names, zoneKey(old.Name))
+	v = *z
+	m.zones[v.Id] = v
+	m.names[key] = v.Id
+	return
+}
+
+// Delete is a method that removes a zone
+func (m *ZoneMap) Delete(ctx context.Context, id int) (err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	z, ok := m.zones[id]
+	if !ok {
+		err = fmt.Errorf("%w: %d", apperrors.ErrZoneNotFound, id)
+		return
+	}
+	delete(m.zones, id)
+	delete(m.names, zoneKey(z.Name))
+	return
+}
+
+// zoneKey is a function that returns the key the names of the zones are unique by,
+// without case and surrounding spaces
+func zoneKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
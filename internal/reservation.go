@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// reservation statuses
+const (
+	// ReservationBooked is a reservation waiting for the check-out of the vehicle
+	ReservationBooked = "booked"
+	// ReservationCheckedOut is a reservation whose vehicle was taken
+	ReservationCheckedOut = "checked_out"
+	// ReservationReturned is a reservation whose vehicle was checked in
+	ReservationReturned = "returned"
+	// ReservationCancelled is a reservation cancelled before the check-out
+	ReservationCancelled = "cancelled"
+)
+
+// Reservation is a struct that represents a pool vehicle booked from Start until End
+type Reservation struct {
+	// Id is the unique identifier of the reservation
+	Id int
+	// VehicleId is the identifier of the vehicle booked
+	VehicleId int
+	// Requester is who booked the vehicle
+	Requester string
+	// Purpose is why the vehicle was booked
+	Purpose string
+	// Start and End are the interval booked, End excluded
+	Start time.Time
+	End   time.Time
+	// Status is ReservationBooked, ReservationCheckedOut, ReservationReturned or ReservationCancelled
+	Status string
+	// CheckedOutAt and CheckedInAt are when the vehicle was taken and returned, nil before
+	CheckedOutAt *time.Time
+	CheckedInAt  *time.Time
+}
+
+// ReservationFilter is a struct that represents the criteria to find reservations, the zero
+// value of each field matching any reservation
+type ReservationFilter struct {
+	// VehicleId is the vehicle booked
+	VehicleId int
+	// From and To is an interval the reservations overlap
+	From time.Time
+	To   time.Time
+	// Status is the status of the reservations
+	Status string
+}
+
+// Validate is a method that validates a reservation to be booked
+func (r *Reservation) Validate() error {
+	if strings.TrimSpace(r.Requester) == "" {
+		return errors.New("requester is required")
+	}
+	if r.Start.IsZero() || r.End.IsZero() {
+		return errors.New("start and end are required")
+	}
+	if !r.End.After(r.Start) {
+		return errors.New("end must be after start")
+	}
+	return nil
+}
+
+// Holds is a method that reports whether the reservation keeps its vehicle from being booked,
+// booked or checked out
+func (r *Reservation) Holds() bool {
+	return r.Status == ReservationBooked || r.Status == ReservationCheckedOut
+}
+
+// Overlaps is a method that reports whether the reservation intersects the interval from start to end
+func (r *Reservation) Overlaps(start, end time.Time) bool {
+	return r.Start.Before(end) && start.Before(r.End)
+}
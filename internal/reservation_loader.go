@@ -0,0 +1,7 @@
+package internal
+
+// ReservationLoader is an interface that represents the loader for the reservations
+type ReservationLoader interface {
+	// Load is a method that loads the reservations
+	Load() (r []Reservation, err error)
+}
@@ -0,0 +1,17 @@
+package internal
+
+import "context"
+
+// ReservationRepository is an interface that represents a repository of the reservations
+type ReservationRepository interface {
+	// FindAll is a method that returns the reservations matching the filter, by start
+	FindAll(ctx context.Context, f ReservationFilter) (r []Reservation, err error)
+	// FindById is a method that returns a reservation, ErrReservationNotFound when there is none
+	FindById(ctx context.Context, id int) (r Reservation, err error)
+	// Save is a method that books a reservation, assigning its id, ErrReservationConflict when
+	// it overlaps another holding its vehicle
+	Save(ctx context.Context, r *Reservation) (v Reservation, err error)
+	// Update is a method that replaces a reservation, its vehicle and interval unchanged, when it
+	// is still in the status, ErrReservationState when another request changed it
+	Update(ctx context.Context, r *Reservation, status string) (v Reservation, err error)
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// ReservationService is an interface that represents the service of the reservations of the pool vehicles
+type ReservationService interface {
+	// FindAll is a method that returns the reservations matching the filter, by start
+	FindAll(ctx context.Context, f ReservationFilter) (r []Reservation, err error)
+	// FindById is a method that returns a reservation
+	FindById(ctx context.Context, id int) (r Reservation, err error)
+	// Book is a method that reserves a vehicle, free during the interval
+	Book(ctx context.Context, r *Reservation) (v Reservation, err error)
+	// Cancel is a method that cancels a reservation before the check-out
+	Cancel(ctx context.Context, id int) (v Reservation, err error)
+	// CheckOut is a method that records the vehicle of a reservation taken at the time at
+	CheckOut(ctx context.Context, id int, at time.Time) (v Reservation, err error)
+	// CheckIn is a method that records the vehicle of a reservation returned at the time at
+	CheckIn(ctx context.Context, id int, at time.Time) (v Reservation, err error)
+	// Reserved is a method that returns the ids of the vehicles held by a reservation overlapping
+	// the interval from start to end
+	Reserved(ctx context.Context, start, end time.Time) (ids map[int]struct{}, err error)
+}
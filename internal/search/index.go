@@ -0,0 +1,224 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// quality of the matches of a query token, multiplied by the weight of the field
+const (
+	// qualityExact is a token equal to a term
+	qualityExact = 1.0
+	// qualityPrefix is a token that starts a term, as typed so far
+	qualityPrefix = 0.75
+	// qualityTypo is a token at one edit of a term, divided by the number of edits
+	qualityTypo = 0.6
+	// qualityTypoPrefix is a token at one edit of the start of a term, divided by the number of edits
+	qualityTypoPrefix = 0.45
+)
+
+// Field is a struct that represents a text of a document and its relevance
+type Field struct {
+	// Text is the text of the field
+	Text string
+	// Weight multiplies the score of the matches in the field
+	Weight float64
+}
+
+// Result is a struct that represents a document matching a query
+type Result struct {
+	// ID is the identifier of the document
+	ID int
+	// Score is the relevance of the document to the query, the higher the better
+	Score float64
+}
+
+// NewIndex is a function that returns a new empty instance of Index
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[int]float64),
+		docs:     make(map[int][]string),
+	}
+}
+
+// Index is a struct that represents an inverted index of documents by the terms of their fields,
+// safe for concurrent use
+type Index struct {
+	mu sync.RWMutex
+	// postings are the documents of each term with the highest weight of the fields having it
+	postings map[string]map[int]float64
+	// docs are the terms of each document, to remove it
+	docs map[int][]string
+	// vocabulary are the terms in order, for the prefix and typo matches
+	vocabulary []string
+}
+
+// Put is a method that indexes the document id, replacing its previous version
+func (ix *Index) Put(id int, fields ...Field) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+	weights := make(map[string]float64)
+	for _, f := range fields {
+		for _, t := range terms(f.Text) {
+			weights[t] = max(weights[t], f.Weight)
+		}
+	}
+	for t, w := range weights {
+		docs, ok := ix.postings[t]
+		if !ok {
+			docs = make(map[int]float64)
+			ix.postings[t] = docs
+			i := sort.SearchStrings(ix.vocabulary, t)
+			ix.vocabulary = append(ix.vocabulary, "")
+			copy(ix.vocabulary[i+1:], ix.vocabulary[i:])
+			ix.vocabulary[i] = t
+		}
+		docs[id] = w
+		ix.docs[id] = append(ix.docs[id], t)
+	}
+}
+
+// Delete is a method that removes the document id
+func (ix *Index) Delete(id int) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	ix.remove(id)
+}
+
+// remove is a method that removes the document id, the lock held
+func (ix *Index) remove(id int) {
+	for _, t := range ix.docs[id] {
+		docs := ix.postings[t]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(ix.postings, t)
+			i := sort.SearchStrings(ix.vocabulary, t)
+			ix.vocabulary = append(ix.vocabulary[:i], ix.vocabulary[i+1:]...)
+		}
+	}
+	delete(ix.docs, id)
+}
+
+// Search is a method that returns the documents matching every token of the query, by relevance
+// and then by id, at most limit of them when it is positive.
+// A token matches a term that is equal, that it starts, or that is within the typos tolerated
+// for its length: none up to 3 letters, 1 up to 7 and 2 from 8. Numbers must match exactly
+// or as a prefix, a typo in a registration being another registration.
+func (ix *Index) Search(query string, limit int) (results []Result) {
+	tokens := Tokenize(query)
+	if len(tokens) == 0 {
+		return
+	}
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	scores := make(map[int]float64)
+	matched := make(map[int]int)
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+
+		// best match of the token in each document
+		best := make(map[int]float64)
+		for term, quality := range ix.expand(token) {
+			for id, weight := range ix.postings[term] {
+				best[id] = max(best[id], quality*weight)
+			}
+		}
+		for id, score := range best {
+			scores[id] += score
+			matched[id]++
+		}
+	}
+
+	for id, score := range scores {
+		if matched[id] == len(seen) {
+			results = append(results, Result{ID: id, Score: score})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return
+}
+
+// expand is a method that returns the terms matching a token and the quality of each match
+func (ix *Index) expand(token string) (matches map[string]float64) {
+	matches = make(map[string]float64)
+	add := func(term string, quality float64) {
+		matches[term] = max(matches[term], quality)
+	}
+
+	// - exact and prefix, the terms starting with the token are contiguous
+	for i := sort.SearchStrings(ix.vocabulary, token); i < len(ix.vocabulary) && strings.HasPrefix(ix.vocabulary[i], token); i++ {
+		if ix.vocabulary[i] == token {
+			add(token, qualityExact)
+			continue
+		}
+		add(ix.vocabulary[i], qualityPrefix)
+	}
+
+	// - typos
+	typos := tolerance(token)
+	if typos == 0 || isNumber(token) {
+		return
+	}
+	tr := []rune(token)
+	for _, term := range ix.vocabulary {
+		if _, ok := matches[term]; ok || isNumber(term) {
+			continue
+		}
+		rt := []rune(term)
+		if abs(len(rt)-len(tr)) <= typos {
+			if d := distance(tr, rt); d <= typos {
+				add(term, qualityTypo/float64(max(d, 1)))
+				continue
+			}
+		}
+		// the start of the term, one rune shorter or longer than the token for the
+		// insertions and deletions
+		for n := len(tr) - 1; n <= len(tr)+1; n++ {
+			if n < 1 || n >= len(rt) {
+				continue
+			}
+			if d := distance(tr, rt[:n]); d <= typos {
+				add(term, qualityTypoPrefix/float64(max(d, 1)))
+			}
+		}
+	}
+	return
+}
+
+// tolerance is a function that returns the number of typos tolerated in a token
+func tolerance(token string) int {
+	switch n := utf8.RuneCountInString(token); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	}
+	return 0
+}
+
+// abs is a function that returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package search
+
+import "testing"
+
+// TestIndex_Search is a function that checks the documents found for a query, each token matching
+// a term exactly, as a prefix or within the typos tolerated, numbers exactly or as a prefix
+func TestIndex_Search(t *testing.T) {
+	ix := NewIndex()
+	ix.Put(1, Field{Text: "Volkswagen", Weight: 1}, Field{Text: "Gol", Weight: 1}, Field{Text: "ABC-1234", Weight: 2})
+	ix.Put(2, Field{Text: "Citroën", Weight: 1}, Field{Text: "C3", Weight: 1}, Field{Text: "DEF-5678", Weight: 2})
+	ix.Put(3, Field{Text: "Mercedes-Benz", Weight: 1}, Field{Text: "Sprinter", Weight: 1}, Field{Text: "ABC-1299", Weight: 2})
+	ix.Put(4, Field{Text: "Fiat", Weight: 1}, Field{Text: "Uno", Weight: 1}, Field{Text: "GHI-9012", Weight: 2})
+
+	cases := map[string]struct {
+		query string
+		want  []int
+	}{
+		"exact":                    {query: "gol", want: []int{1}},
+		"accent folded":            {query: "citroen", want: []int{2}},
+		"accent in the query":      {query: "CITROËN", want: []int{2}},
+		"prefix":                   {query: "volks", want: []int{1}},
+		"prefix of the joined":     {query: "abc12", want: []int{1, 3}},
+		"registration split":       {query: "abc 1234", want: []int{1}},
+		"registration joined":      {query: "abc1234", want: []int{1}},
+		"typo":                     {query: "mercedez", want: []int{3}},
+		"transposition":            {query: "sprnitre", want: []int{3}},
+		"two typos in a long word": {query: "volksvagem", want: []int{1}},
+		"no typo in a short word":  {query: "fit", want: nil},
+		"no typo in a number":      {query: "1235", want: nil},
+		"every token":              {query: "fiat uno", want: []int{4}},
+		"a token matching nothing": {query: "fiat gol", want: nil},
+		"empty":                    {query: " - ", want: nil},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			results := ix.Search(c.query, 0)
+
+			got := make([]int, 0, len(results))
+			for _, r := range results {
+				got = append(got, r.ID)
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("got %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
+
+// TestIndex_Search_Ranking is a function that checks that the exact matches rank before the
+// prefixes and the typos, and that a document replaced or deleted is no longer found by its terms
+func TestIndex_Search_Ranking(t *testing.T) {
+	ix := NewIndex()
+	ix.Put(1, Field{Text: "Uno", Weight: 1})
+	ix.Put(2, Field{Text: "Unomax", Weight: 1})
+	ix.Put(3, Field{Text: "Mobi", Weight: 1})
+	ix.Put(4, Field{Text: "Mobix", Weight: 1})
+
+	if r := ix.Search("uno", 0); len(r) != 2 || r[0].ID != 1 || r[0].Score <= r[1].Score {
+		t.Errorf("uno: got %v, want 1 before 2", r)
+	}
+	if r := ix.Search("mobi", 1); len(r) != 1 || r[0].ID != 3 {
+		t.Errorf("mobi limited to 1: got %v, want 3", r)
+	}
+
+	ix.Put(1, Field{Text: "Palio", Weight: 1})
+	if r := ix.Search("uno", 0); len(r) != 1 || r[0].ID != 2 {
+		t.Errorf("uno after the replace: got %v, want 2", r)
+	}
+	ix.Delete(2)
+	if r := ix.Search("unomax", 0); len(r) != 0 {
+		t.Errorf("unomax after the delete: got %v, want none", r)
+	}
+}
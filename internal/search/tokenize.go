@@ -0,0 +1,75 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tokenize is a function that splits s into its lower case words without accents,
+// "Mercedes-Benz Citroën" is mercedes, benz and citroen
+func Tokenize(s string) (tokens []string) {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Fold is a function that returns s in lower case and without accents
+func Fold(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+	return strings.ToLower(folded)
+}
+
+// terms is a function that returns the terms indexed for a text: its tokens and,
+// when there are several, their concatenation so "ABC-1234" is also found as abc1234
+func terms(s string) []string {
+	tokens := Tokenize(s)
+	if len(tokens) > 1 {
+		tokens = append(tokens, strings.Join(tokens, ""))
+	}
+	return tokens
+}
+
+// distance is a function that returns the edit distance between a and b, counting
+// insertions, deletions, substitutions and transpositions of adjacent runes
+func distance(a, b []rune) int {
+	// rows i-2, i-1 and i of the matrix
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
+
+// isNumber is a function that reports whether s has only digits
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTokenize is a function that checks the words of a text, in lower case and without accents,
+// split at anything but letters and digits
+func TestTokenize(t *testing.T) {
+	cases := map[string]struct {
+		s    string
+		want []string
+	}{
+		"words":           {s: "Mercedes-Benz Citroën", want: []string{"mercedes", "benz", "citroen"}},
+		"accents":         {s: "São Paulo Açaí Über", want: []string{"sao", "paulo", "acai", "uber"}},
+		"registration":    {s: "ABC-1D23", want: []string{"abc", "1d23"}},
+		"punctuation":     {s: "  Fiat, (Uno)!  ", want: []string{"fiat", "uno"}},
+		"only separators": {s: " - / . ", want: []string{}},
+		"empty":           {s: "", want: []string{}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Tokenize(c.s); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+// TestFold is a function that checks that a text is folded to lower case without accents, its
+// separators kept
+func TestFold(t *testing.T) {
+	cases := map[string]string{
+		"Citroën":        "citroen",
+		"ÁÉÍÓÚ ãõ ç":     "aeiou ao c",
+		"Mercedes-Benz":  "mercedes-benz",
+		"already folded": "already folded",
+		"Škoda Š":        "skoda s",
+	}
+
+	for s, want := range cases {
+		if got := Fold(s); got != want {
+			t.Errorf("Fold(%q): got %q, want %q", s, got, want)
+		}
+	}
+}
+
+// TestDistance is a function that checks the edit distance, a transposition of adjacent letters
+// counting as one edit
+func TestDistance(t *testing.T) {
+	cases := map[string]struct {
+		a, b string
+		want int
+	}{
+		"equal":         {a: "fiat", b: "fiat", want: 0},
+		"substitution":  {a: "fiat", b: "fiot", want: 1},
+		"insertion":     {a: "fiat", b: "fiats", want: 1},
+		"deletion":      {a: "fiat", b: "fat", want: 1},
+		"transposition": {a: "fiat", b: "ifat", want: 1},
+		"two edits":     {a: "volkswagen", b: "volksvagem", want: 2},
+		"empty":         {a: "", b: "uno", want: 3},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := distance([]rune(c.a), []rune(c.b)); got != c.want {
+				t.Errorf("got %d, want %d", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"app/pkg/tenant"
+	"context"
+	"fmt"
+)
+
+// NewAttributeDefault is a function that returns a new instance of AttributeDefault
+func NewAttributeDefault(rp internal.AttributeRepository) *AttributeDefault {
+	return &AttributeDefault{rp: rp}
+}
+
+// AttributeDefault is a struct that represents the default service for the custom attributes of the vehicles
+type AttributeDefault struct {
+	// rp is the repository of the attribute definitions
+	rp internal.AttributeRepository
+}
+
+// FindAll is a method that returns the attribute definitions of the tenant by name
+func (s *AttributeDefault) FindAll(ctx context.Context) (d []internal.AttributeDefinition, err error) {
+	d, err = s.rp.FindAll(ctx, tenant.FromContext(ctx))
+	return
+}
+
+// FindByName is a method that returns an attribute definition of the tenant
+func (s *AttributeDefault) FindByName(ctx context.Context, name string) (d internal.AttributeDefinition, err error) {
+	d, err = s.rp.FindByName(ctx, tenant.FromContext(ctx), name)
+	return
+}
+
+// Save is a method that adds an attribute definition to the tenant
+func (s *AttributeDefault) Save(ctx context.Context, d *internal.AttributeDefinition) (v internal.AttributeDefinition, err error) {
+	d.Tenant = tenant.FromContext(ctx)
+	if err = d.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidAttributeData, err.Error())
+		return
+	}
+
+	v, err = s.rp.Save(ctx, d)
+	return
+}
+
+// Update is a method that replaces an attribute definition of the tenant, the vehicles checked
+// against it at their next write
+func (s *AttributeDefault) Update(ctx context.Context, d *internal.AttributeDefinition) (v internal.AttributeDefinition, err error) {
+	d.Tenant = tenant.FromContext(ctx)
+	if err = d.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidAttributeData, err.Error())
+		return
+	}
+
+	v, err = s.rp.Update(ctx, d)
+	return
+}
+
+// Delete is a method that removes an attribute definition of the tenant
+func (s *AttributeDefault) Delete(ctx context.Context, name string) (err error) {
+	err = s.rp.Delete(ctx, tenant.FromContext(ctx), name)
+	return
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/logger"
+	"context"
+	"log/slog"
+)
+
+// NewCatalogCanonical is a function that returns a catalog decorator rewriting the vehicles stored
+// when a name becomes a brand or an alias
+func NewCatalogCanonical(ct internal.BrandCatalog, rp internal.VehicleRepository) *CatalogCanonical {
+	return &CatalogCanonical{ct: ct, rp: rp}
+}
+
+// CatalogCanonical is a struct that decorates a BrandCatalog, keeping the brands of the vehicles
+// stored canonical as the catalog changes, so that the queries by brand, resolved to the canonical
+// name, still find the vehicles stored under a new alias
+type CatalogCanonical struct {
+	// ct is the decorated catalog
+	ct internal.BrandCatalog
+	// rp is the repository of the vehicles rewritten
+	rp internal.VehicleRepository
+}
+
+// Brand is a method that decorates the catalog Brand
+func (c *CatalogCanonical) Brand(name string) (canonical string, ok bool) {
+	return c.ct.Brand(name)
+}
+
+// Canonicalize is a method that decorates the catalog Canonicalize
+func (c *CatalogCanonical) Canonicalize(vh *internal.VehicleAttributes) (ok bool) {
+	return c.ct.Canonicalize(vh)
+}
+
+// FindAll is a method that decorates the catalog FindAll
+func (c *CatalogCanonical) FindAll() (b []internal.Brand) {
+	return c.ct.FindAll()
+}
+
+// SaveBrand is a method that decorates the catalog SaveBrand, rewriting the vehicles stored under
+// another spelling of a brand created
+func (c *CatalogCanonical) SaveBrand(name string) (b internal.Brand, created bool, err error) {
+	b, created, err = c.ct.SaveBrand(name)
+	if err != nil || !created {
+		return
+	}
+
+	err = c.canonicalize(b.Name)
+	return
+}
+
+// AddAlias is a method that decorates the catalog AddAlias, rewriting the vehicles stored under the alias
+func (c *CatalogCanonical) AddAlias(brand, alias string) (b internal.Brand, err error) {
+	b, err = c.ct.AddAlias(brand, alias)
+	if err != nil {
+		return
+	}
+
+	err = c.canonicalize(b.Name)
+	return
+}
+
+// RemoveAlias is a method that decorates the catalog RemoveAlias, the vehicles stored under the
+// alias being already canonical
+func (c *CatalogCanonical) RemoveAlias(brand, alias string) (b internal.Brand, err error) {
+	return c.ct.RemoveAlias(brand, alias)
+}
+
+// Unknown is a method that decorates the catalog Unknown
+func (c *CatalogCanonical) Unknown() (u []internal.UnknownBrand) {
+	return c.ct.Unknown()
+}
+
+// canonicalize is a method that replaces the brands of the vehicles stored by their canonical names
+func (c *CatalogCanonical) canonicalize(brand string) (err error) {
+	// the catalog is administered without a request context
+	ctx := context.Background()
+	n, err := c.rp.Canonicalize(ctx, c.ct)
+	if err != nil {
+		return
+	}
+	if n > 0 {
+		logger.FromContext(ctx).Info("service: vehicles rewritten to the catalog",
+			slog.String("brand", brand),
+			slog.Int("vehicles", n),
+		)
+	}
+	return
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DocumentMaxBytes is the largest file of a document
+const DocumentMaxBytes = 10 << 20
+
+// NewDocumentDefault is a function that returns a new instance of DocumentDefault
+func NewDocumentDefault(rp internal.DocumentRepository, blobs internal.BlobStore, vehicles internal.VehicleRepository) *DocumentDefault {
+	return &DocumentDefault{rp: rp, blobs: blobs, vehicles: vehicles}
+}
+
+// DocumentDefault is a struct that represents the default service for the documents of the vehicles
+type DocumentDefault struct {
+	// rp is the repository of the metadata of the documents
+	rp internal.DocumentRepository
+	// blobs is the store of the files of the documents and of their thumbnails
+	blobs internal.BlobStore
+	// vehicles is the repository of the vehicles the documents are attached to
+	vehicles internal.VehicleRepository
+}
+
+// FindAll is a method that returns the documents of a vehicle by id
+func (s *DocumentDefault) FindAll(ctx context.Context, vehicleId int) (d []internal.Document, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	d, err = s.rp.FindAll(ctx, vehicleId)
+	return
+}
+
+// FindById is a method that returns a document of a vehicle
+func (s *DocumentDefault) FindById(ctx context.Context, vehicleId, id int) (d internal.Document, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	d, err = s.rp.FindById(ctx, vehicleId, id)
+	return
+}
+
+// Upload is a method that stores the file of a document, up to DocumentMaxBytes, its content type
+// sniffed from its first bytes and checked against DocumentContentTypes whatever the client declared,
+// and a thumbnail made for an image
+func (s *DocumentDefault) Upload(ctx context.Context, d *internal.Document, r io.Reader) (v internal.Document, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, d.VehicleId); err != nil {
+		return
+	}
+	if err = d.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidDocumentData, err.Error())
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, DocumentMaxBytes+1))
+	if err != nil {
+		return
+	}
+	switch {
+	case len(content) == 0:
+		err = fmt.Errorf("%w: file is empty", apperrors.ErrInvalidDocumentData)
+		return
+	case len(content) > DocumentMaxBytes:
+		err = fmt.Errorf("%w: larger than %d bytes", apperrors.ErrDocumentTooLarge, DocumentMaxBytes)
+		return
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(content))
+	if !acceptedContentType(contentType) {
+		err = fmt.Errorf("%w: %s, accepted %s", apperrors.ErrUnsupportedContentType, contentType, strings.Join(internal.DocumentContentTypes, ", "))
+		return
+	}
+	var thumb []byte
+	if strings.HasPrefix(contentType, "image/") {
+		if thumb, err = thumbnail(content); err != nil {
+			err = fmt.Errorf("%w: %s", apperrors.ErrInvalidDocumentData, err.Error())
+			return
+		}
+	}
+	sum := sha256.Sum256(content)
+
+	v = *d
+	if v.Id, err = s.rp.NextId(ctx); err != nil {
+		return
+	}
+	v.ContentType, v.Size, v.Checksum = contentType, int64(len(content)), hex.EncodeToString(sum[:])
+	v.UploadedAt, v.Thumbnail = time.Now().UTC(), thumb != nil
+
+	// the files are stored before the metadata, so that a document listed can always be read
+	if err = s.blobs.Put(ctx, v.BlobKey(), bytes.NewReader(content)); err != nil {
+		return
+	}
+	if thumb != nil {
+		err = s.blobs.Put(ctx, v.ThumbnailKey(), bytes.NewReader(thumb))
+	}
+	if err == nil {
+		v, err = s.rp.Save(ctx, &v)
+	}
+	if err != nil {
+		err = errors.Join(err, s.blobs.Delete(ctx, v.BlobKey()), s.blobs.Delete(ctx, v.ThumbnailKey()))
+		v = internal.Document{}
+	}
+	return
+}
+
+// Open is a method that returns a document of a vehicle and its file, or its thumbnail, for reading,
+// ErrDocumentNotFound for the thumbnail of a document without one
+func (s *DocumentDefault) Open(ctx context.Context, vehicleId, id int, thumbnail bool) (d internal.Document, rc io.ReadCloser, err error) {
+	if d, err = s.FindById(ctx, vehicleId, id); err != nil {
+		return
+	}
+
+	key := d.BlobKey()
+	if thumbnail {
+		if !d.Thumbnail {
+			err = fmt.Errorf("%w: %d has no thumbnail", apperrors.ErrDocumentNotFound, id)
+			return
+		}
+		key = d.ThumbnailKey()
+	}
+	rc, err = s.blobs.Get(ctx, key)
+	return
+}
+
+// Delete is a method that removes a document of a vehicle and then its files
+func (s *DocumentDefault) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	d, err := s.FindById(ctx, vehicleId, id)
+	if err != nil {
+		return
+	}
+	if err = s.rp.Delete(ctx, vehicleId, id); err != nil {
+		return
+	}
+
+	err = errors.Join(s.blobs.Delete(ctx, d.BlobKey()), s.blobs.Delete(ctx, d.ThumbnailKey()))
+	return
+}
+
+// Expiring is a method that returns the documents of the fleet expired or expiring until the day
+// until, the soonest first
+func (s *DocumentDefault) Expiring(ctx context.Context, today, until time.Time) (e []internal.ExpiringDocument, err error) {
+	documents, err := s.rp.FindAll(ctx, 0)
+	if err != nil {
+		return
+	}
+
+	today, until = day(today), day(until)
+	e = []internal.ExpiringDocument{}
+	for _, d := range documents {
+		if d.Expiry == nil || d.Expiry.After(until) {
+			continue
+		}
+		e = append(e, internal.ExpiringDocument{
+			Document: d,
+			DaysLeft: int(day(*d.Expiry).Sub(today).Hours() / 24),
+		})
+	}
+	sort.SliceStable(e, func(i, j int) bool { return e[i].Document.Expiry.Before(*e[j].Document.Expiry) })
+	return
+}
+
+// acceptedContentType is a function that reports whether contentType is one of DocumentContentTypes
+func acceptedContentType(contentType string) bool {
+	for _, value := range internal.DocumentContentTypes {
+		if value == contentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// ThumbnailSize is the longest side of the thumbnails of the images, in pixels
+	ThumbnailSize = 256
+	// thumbnailMaxPixels is the largest image decoded for a thumbnail, the larger ones kept without
+	// one so that a small file cannot expand into gigabytes of pixels
+	thumbnailMaxPixels = 25_000_000
+	// thumbnailSamples is the number of pixels of the image averaged per side of a pixel of the thumbnail
+	thumbnailSamples = 4
+)
+
+// thumbnail is a function that returns a JPEG of an image scaled down to fit ThumbnailSize, nil for
+// an image too large to decode, the images smaller than a thumbnail kept at their size
+func thumbnail(content []byte) (b []byte, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		err = fmt.Errorf("image cannot be decoded: %w", err)
+		return
+	}
+	if cfg.Width*cfg.Height > thumbnailMaxPixels {
+		return
+	}
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		err = fmt.Errorf("image cannot be decoded: %w", err)
+		return
+	}
+
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if longest := max(w, h); longest > ThumbnailSize {
+		w, h = max(1, w*ThumbnailSize/longest), max(1, h*ThumbnailSize/longest)
+	}
+
+	// each pixel is the average of a grid of samples of its area of the image, the transparent
+	// ones over white as JPEG has no alpha
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var r, g, bl, n uint32
+			for sy := 0; sy < thumbnailSamples; sy++ {
+				for sx := 0; sx < thumbnailSamples; sx++ {
+					px := bounds.Min.X + (x*thumbnailSamples+sx)*bounds.Dx()/(w*thumbnailSamples)
+					py := bounds.Min.Y + (y*thumbnailSamples+sy)*bounds.Dy()/(h*thumbnailSamples)
+					cr, cg, cb, ca := src.At(px, py).RGBA()
+					r, g, bl, n = r+cr+0xffff-ca, g+cg+0xffff-ca, bl+cb+0xffff-ca, n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(bl / n >> 8), A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return
+	}
+	b = buf.Bytes()
+	return
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewDriverDefault is a function that returns a new instance of DriverDefault
+func NewDriverDefault(rp internal.DriverRepository, vehicles internal.VehicleRepository) *DriverDefault {
+	return &DriverDefault{rp: rp, vehicles: vehicles}
+}
+
+// DriverDefault is a struct that represents the default service for the drivers
+type DriverDefault struct {
+	// rp is the repository of the drivers and their assignments
+	rp internal.DriverRepository
+	// vehicles is the repository of the vehicles assigned
+	vehicles internal.VehicleRepository
+}
+
+// FindAll is a method that returns the drivers by id
+func (s *DriverDefault) FindAll(ctx context.Context) (d []internal.Driver, err error) {
+	d, err = s.rp.FindAll(ctx)
+	return
+}
+
+// FindById is a method that returns a driver
+func (s *DriverDefault) FindById(ctx context.Context, id int) (d internal.Driver, err error) {
+	d, err = s.rp.FindById(ctx, id)
+	return
+}
+
+// Save is a method that adds a driver
+func (s *DriverDefault) Save(ctx context.Context, d *internal.Driver) (v internal.Driver, err error) {
+	if err = validateDriver(d); err != nil {
+		return
+	}
+
+	v, err = s.rp.Save(ctx, d)
+	return
+}
+
+// Patch is a method that replaces a driver, the assignments already made are kept even when
+// the new license no longer fits their vehicles
+func (s *DriverDefault) Patch(ctx context.Context, d *internal.Driver) (v internal.Driver, err error) {
+	if err = validateDriver(d); err != nil {
+		return
+	}
+
+	v, err = s.rp.Update(ctx, d)
+	return
+}
+
+// Assign is a method that assigns a driver to a vehicle, the vehicle and the driver free during
+// the assignment and the driver's license valid at its start and fit for the capacity and the
+// weight of the vehicle
+func (s *DriverDefault) Assign(ctx context.Context, a *internal.Assignment) (v internal.Assignment, err error) {
+	if err = a.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidDriverData, err.Error())
+		return
+	}
+	vh, err := findVehicle(ctx, s.vehicles, a.VehicleId)
+	if err != nil {
+		return
+	}
+	d, err := s.rp.FindById(ctx, a.DriverId)
+	if err != nil {
+		return
+	}
+	if !d.LicenseValid(a.Start) {
+		err = fmt.Errorf("%w: the license of driver %d expired on %s", apperrors.ErrLicenseNotAllowed, d.Id, d.LicenseExpiry.Format(time.DateOnly))
+		return
+	}
+	if !d.LicenseCategories.Fits(vh.Capacity, vh.Weight) {
+		err = fmt.Errorf("%w: category %s for %d passengers and weight %g", apperrors.ErrLicenseNotAllowed, d.LicenseCategories, vh.Capacity, vh.Weight)
+		return
+	}
+
+	// the repository rejects the overlapping assignments
+	v, err = s.rp.SaveAssignment(ctx, a)
+	return
+}
+
+// Unassign is a method that ends the assignment of a vehicle active at the time at
+func (s *DriverDefault) Unassign(ctx context.Context, vehicleId int, at time.Time) (v internal.Assignment, err error) {
+	a, err := s.CurrentByVehicle(ctx, vehicleId, at)
+	if err != nil {
+		return
+	}
+	v, err = s.rp.EndAssignment(ctx, a.Id, at)
+	return
+}
+
+// VehicleAssignments is a method that returns the history of the assignments of a vehicle
+func (s *DriverDefault) VehicleAssignments(ctx context.Context, vehicleId int) (a []internal.Assignment, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	a, err = s.rp.FindAssignments(ctx, internal.AssignmentFilter{VehicleId: vehicleId})
+	return
+}
+
+// DriverAssignments is a method that returns the history of the assignments of a driver
+func (s *DriverDefault) DriverAssignments(ctx context.Context, driverId int) (a []internal.Assignment, err error) {
+	if _, err = s.rp.FindById(ctx, driverId); err != nil {
+		return
+	}
+
+	a, err = s.rp.FindAssignments(ctx, internal.AssignmentFilter{DriverId: driverId})
+	return
+}
+
+// CurrentByVehicle is a method that returns the assignment of a vehicle active at the time at
+func (s *DriverDefault) CurrentByVehicle(ctx context.Context, vehicleId int, at time.Time) (a internal.Assignment, err error) {
+	history, err := s.VehicleAssignments(ctx, vehicleId)
+	if err != nil {
+		return
+	}
+
+	a, err = activeAt(history, at)
+	if err != nil {
+		err = fmt.Errorf("%w: vehicle %d has no driver", err, vehicleId)
+	}
+	return
+}
+
+// CurrentByDriver is a method that returns the assignment of a driver active at the time at
+func (s *DriverDefault) CurrentByDriver(ctx context.Context, driverId int, at time.Time) (a internal.Assignment, err error) {
+	history, err := s.DriverAssignments(ctx, driverId)
+	if err != nil {
+		return
+	}
+
+	a, err = activeAt(history, at)
+	if err != nil {
+		err = fmt.Errorf("%w: driver %d has no vehicle", err, driverId)
+	}
+	return
+}
+
+// validateDriver is a function that validates a driver to be written, trimming its names
+func validateDriver(d *internal.Driver) (err error) {
+	d.Name, d.LicenseNumber = strings.TrimSpace(d.Name), strings.TrimSpace(d.LicenseNumber)
+	if err = d.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidDriverData, err.Error())
+	}
+	return
+}
+
+// activeAt is a function that returns the assignment active at the time at, the assignments
+// of a vehicle or of a driver never overlapping
+func activeAt(history []internal.Assignment, at time.Time) (a internal.Assignment, err error) {
+	for _, value := range history {
+		if value.ActiveAt(at) {
+			a = value
+			return
+		}
+	}
+	err = apperrors.ErrAssignmentNotFound
+	return
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// consumptionMinSamples is the number of consumptions of a vehicle needed to tell its normal band
+const consumptionMinSamples = 3
+
+// NewFuelDefault is a function that returns a new instance of FuelDefault
+func NewFuelDefault(rp internal.FuelRepository, vehicles internal.VehicleRepository) *FuelDefault {
+	return &FuelDefault{rp: rp, vehicles: vehicles}
+}
+
+// FuelDefault is a struct that represents the default service for the fuel transactions and the
+// consumption of the vehicles
+type FuelDefault struct {
+	// rp is the repository of the transactions
+	rp internal.FuelRepository
+	// vehicles is the repository of the vehicles refueled
+	vehicles internal.VehicleRepository
+}
+
+// FindAll is a method that returns the transactions of a vehicle, the oldest first
+func (s *FuelDefault) FindAll(ctx context.Context, vehicleId int) (t []internal.FuelTransaction, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	t, err = s.rp.FindAll(ctx, vehicleId)
+	return
+}
+
+// Record is a method that records a transaction of a vehicle, of its current fuel type when
+// the fuel type is absent
+func (s *FuelDefault) Record(ctx context.Context, t *internal.FuelTransaction) (v internal.FuelTransaction, err error) {
+	vh, err := findVehicle(ctx, s.vehicles, t.VehicleId)
+	if err != nil {
+		return
+	}
+	t.FuelType, t.Station = strings.TrimSpace(t.FuelType), strings.TrimSpace(t.Station)
+	switch {
+	case t.FuelType == "":
+		t.FuelType = vh.FuelType
+	case !strings.EqualFold(t.FuelType, vh.FuelType):
+		err = fmt.Errorf("%w: %s given, vehicle %d uses %s", apperrors.ErrFuelTypeMismatch, t.FuelType, vh.Id, vh.FuelType)
+		return
+	default:
+		t.FuelType = vh.FuelType
+	}
+	if err = t.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidFuelData, err.Error())
+		return
+	}
+
+	// the repository keeps the odometer from decreasing
+	v, err = s.rp.Save(ctx, t)
+	return
+}
+
+// Delete is a method that removes a transaction of a vehicle
+func (s *FuelDefault) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	err = s.rp.Delete(ctx, vehicleId, id)
+	return
+}
+
+// Consumption is a method that returns the consumption of the current fuel type of a vehicle,
+// the normal band within tolerance, a share of the median, from it
+func (s *FuelDefault) Consumption(ctx context.Context, vehicleId int, tolerance float64) (r internal.ConsumptionReport, err error) {
+	if tolerance <= 0 {
+		err = fmt.Errorf("%w: tolerance must be positive", apperrors.ErrInvalidFuelData)
+		return
+	}
+	vh, err := findVehicle(ctx, s.vehicles, vehicleId)
+	if err != nil {
+		return
+	}
+	t, err := s.rp.FindAll(ctx, vehicleId)
+	if err != nil {
+		return
+	}
+
+	r = consumption(vh, t, tolerance)
+	return
+}
+
+// Anomalies is a method that returns the consumptions of the fleet outside the normal band of
+// their vehicle, the most recent first. The transactions of the vehicles removed are skipped.
+func (s *FuelDefault) Anomalies(ctx context.Context, tolerance float64) (c []internal.Consumption, err error) {
+	if tolerance <= 0 {
+		err = fmt.Errorf("%w: tolerance must be positive", apperrors.ErrInvalidFuelData)
+		return
+	}
+	vehicles, err := s.vehicles.FindAll(ctx)
+	if err != nil {
+		return
+	}
+	t, err := s.rp.FindAll(ctx, 0)
+	if err != nil {
+		return
+	}
+
+	byVehicle := make(map[int][]internal.FuelTransaction)
+	for _, value := range t {
+		byVehicle[value.VehicleId] = append(byVehicle[value.VehicleId], value)
+	}
+	c = []internal.Consumption{}
+	for id, value := range byVehicle {
+		vh, ok := vehicles[id]
+		if !ok {
+			continue
+		}
+		for _, cs := range consumption(vh, value, tolerance).Consumptions {
+			if cs.Flag != "" {
+				c = append(c, cs)
+			}
+		}
+	}
+	sort.Slice(c, func(i, j int) bool {
+		if ti, tj := c[i].Transaction.Time, c[j].Transaction.Time; !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return c[i].Transaction.Id > c[j].Transaction.Id
+	})
+	return
+}
+
+// consumption is a function that returns the consumption of the current fuel type of a vehicle
+// from its transactions, the oldest first. Each transaction of the fuel type measures the
+// consumption since the previous one, a transaction of another fuel type breaking the chain, and
+// once there are consumptionMinSamples of them the ones outside tolerance of the median are flagged.
+func consumption(vh internal.Vehicle, t []internal.FuelTransaction, tolerance float64) (r internal.ConsumptionReport) {
+	r = internal.ConsumptionReport{VehicleId: vh.Id, FuelType: vh.FuelType, Unit: internal.ConsumptionKmPerLiter, Consumptions: []internal.Consumption{}}
+	if internal.FuelUnit(vh.FuelType) == internal.FuelKWh {
+		r.Unit = internal.ConsumptionKWhPer100Km
+	}
+
+	var prev *internal.FuelTransaction
+	for i, value := range t {
+		if !strings.EqualFold(value.FuelType, vh.FuelType) {
+			prev = nil
+			continue
+		}
+		if prev != nil && value.Odometer > prev.Odometer {
+			distance := value.Odometer - prev.Odometer
+			r.Consumptions = append(r.Consumptions, internal.Consumption{
+				Transaction: value,
+				Distance:    distance,
+				Value:       rate(r.Unit, distance, value.Quantity),
+				Unit:        r.Unit,
+			})
+			r.Distance += distance
+			r.Quantity += value.Quantity
+			r.Cost += value.Cost
+		}
+		prev = &t[i]
+	}
+	if r.Distance > 0 {
+		r.Average = rate(r.Unit, r.Distance, r.Quantity)
+	}
+	if len(r.Consumptions) < consumptionMinSamples {
+		return
+	}
+
+	values := make([]float64, 0, len(r.Consumptions))
+	for _, c := range r.Consumptions {
+		values = append(values, c.Value)
+	}
+	sort.Float64s(values)
+	r.Median = values[len(values)/2]
+	if len(values)%2 == 0 {
+		r.Median = (values[len(values)/2-1] + values[len(values)/2]) / 2
+	}
+	r.Low, r.High = r.Median*(1-tolerance), r.Median*(1+tolerance)
+
+	// the fewer km per liter, or the more kWh per 100 km, the higher the consumption
+	below, above := internal.ConsumptionHigh, internal.ConsumptionLow
+	if r.Unit == internal.ConsumptionKWhPer100Km {
+		below, above = above, below
+	}
+	for i, c := range r.Consumptions {
+		switch {
+		case c.Value < r.Low:
+			r.Consumptions[i].Flag = below
+		case c.Value > r.High:
+			r.Consumptions[i].Flag = above
+		}
+	}
+	return
+}
+
+// rate is a function that returns the consumption in unit of quantity over distance
+func rate(unit string, distance, quantity float64) float64 {
+	if unit == internal.ConsumptionKWhPer100Km {
+		return quantity / distance * 100
+	}
+	return distance / quantity
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestConsumption is a function that checks the consumptions measured between the transactions
+// of the fuel type of the vehicle and the ones flagged outside the normal band
+func TestConsumption(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, time.October, day, 0, 0, 0, 0, time.UTC) }
+	fill := func(day int, fuel string, odometer, quantity float64) internal.FuelTransaction {
+		return internal.FuelTransaction{Id: day, VehicleId: 1, Time: at(day), FuelType: fuel, Odometer: odometer, Quantity: quantity}
+	}
+
+	cases := map[string]struct {
+		fuel       string
+		t          []internal.FuelTransaction
+		wantValues []float64
+		wantFlags  []string
+		wantMedian float64
+	}{
+		"liquid fuel": {
+			fuel:       "gasoline",
+			t:          []internal.FuelTransaction{fill(1, "gasoline", 0, 40), fill(2, "gasoline", 100, 10), fill(3, "gasoline", 200, 10), fill(4, "gasoline", 300, 20), fill(5, "gasoline", 400, 5)},
+			wantValues: []float64{10, 10, 5, 20},
+			wantFlags:  []string{"", "", internal.ConsumptionHigh, internal.ConsumptionLow},
+			wantMedian: 10,
+		},
+		"electric, the more kWh the higher": {
+			fuel:       internal.FuelElectric,
+			t:          []internal.FuelTransaction{fill(1, "electric", 0, 40), fill(2, "electric", 100, 15), fill(3, "electric", 200, 15), fill(4, "electric", 300, 30)},
+			wantValues: []float64{15, 15, 30},
+			wantFlags:  []string{"", "", internal.ConsumptionHigh},
+			wantMedian: 15,
+		},
+		"another fuel type breaking the chain": {
+			fuel:       "gasoline",
+			t:          []internal.FuelTransaction{fill(1, "gasoline", 0, 40), fill(2, "ethanol", 100, 10), fill(3, "gasoline", 200, 10), fill(4, "gasoline", 300, 10)},
+			wantValues: []float64{10},
+			wantFlags:  []string{""},
+		},
+		"too few to band": {
+			fuel:       "gasoline",
+			t:          []internal.FuelTransaction{fill(1, "gasoline", 0, 40), fill(2, "gasoline", 100, 10), fill(3, "gasoline", 200, 40)},
+			wantValues: []float64{10, 2.5},
+			wantFlags:  []string{"", ""},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			vh := internal.Vehicle{Id: 1, VehicleAttributes: internal.VehicleAttributes{FuelType: c.fuel}}
+
+			r := consumption(vh, c.t, 0.2)
+
+			if len(r.Consumptions) != len(c.wantValues) {
+				t.Fatalf("got %d consumptions, want %d", len(r.Consumptions), len(c.wantValues))
+			}
+			for i, cs := range r.Consumptions {
+				if cs.Value != c.wantValues[i] || cs.Flag != c.wantFlags[i] {
+					t.Errorf("consumption %d: got %v %q, want %v %q", i, cs.Value, cs.Flag, c.wantValues[i], c.wantFlags[i])
+				}
+			}
+			if r.Median != c.wantMedian {
+				t.Errorf("got median %v, want %v", r.Median, c.wantMedian)
+			}
+		})
+	}
+}
+
+// TestFuelDefault_Record is a function that checks that a transaction of a fuel type other than
+// the one of its vehicle is rejected, and that an absent one is the one of the vehicle
+func TestFuelDefault_Record(t *testing.T) {
+	vehicles := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "ABC1D23", FuelType: "gasoline"}},
+	})
+
+	cases := map[string]struct {
+		fuel     string
+		wantFuel string
+		wantErr  error
+	}{
+		"absent":           {fuel: "", wantFuel: "gasoline"},
+		"other case":       {fuel: " Gasoline ", wantFuel: "gasoline"},
+		"other fuel type":  {fuel: "diesel", wantErr: apperrors.ErrFuelTypeMismatch},
+		"electric instead": {fuel: internal.FuelElectric, wantErr: apperrors.ErrFuelTypeMismatch},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			sv := NewFuelDefault(repository.NewFuelMap(nil), vehicles)
+
+			v, err := sv.Record(context.Background(), &internal.FuelTransaction{
+				VehicleId: 1, Time: time.Now(), FuelType: c.fuel, Quantity: 40, Odometer: 1000,
+			})
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got error %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if v.FuelType != c.wantFuel {
+				t.Errorf("got fuel type %q, want %q", v.FuelType, c.wantFuel)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewMaintenanceDefault is a function that returns a new instance of MaintenanceDefault
+func NewMaintenanceDefault(rp internal.MaintenanceRepository, vehicles internal.VehicleRepository) *MaintenanceDefault {
+	return &MaintenanceDefault{rp: rp, vehicles: vehicles}
+}
+
+// MaintenanceDefault is a struct that represents the default service for the maintenance of the vehicles
+type MaintenanceDefault struct {
+	// rp is the repository of the events and plans
+	rp internal.MaintenanceRepository
+	// vehicles is the repository of the vehicles maintained
+	vehicles internal.VehicleRepository
+}
+
+// FindEvents is a method that returns the service history of a vehicle, the oldest first
+func (s *MaintenanceDefault) FindEvents(ctx context.Context, vehicleId int) (e []internal.MaintenanceEvent, err error) {
+	if err = s.exists(ctx, vehicleId); err != nil {
+		return
+	}
+
+	e, err = s.rp.FindEvents(ctx, vehicleId)
+	return
+}
+
+// RecordEvent is a method that records a service performed on a vehicle
+func (s *MaintenanceDefault) RecordEvent(ctx context.Context, e *internal.MaintenanceEvent) (v internal.MaintenanceEvent, err error) {
+	if err = s.exists(ctx, e.VehicleId); err != nil {
+		return
+	}
+	e.Type, e.Workshop = strings.TrimSpace(e.Type), strings.TrimSpace(e.Workshop)
+	if err = e.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidMaintenanceData, err.Error())
+		return
+	}
+
+	v, err = s.rp.SaveEvent(ctx, e)
+	return
+}
+
+// DeleteEvent is a method that removes an event of a vehicle
+func (s *MaintenanceDefault) DeleteEvent(ctx context.Context, vehicleId, id int) (err error) {
+	if err = s.exists(ctx, vehicleId); err != nil {
+		return
+	}
+
+	err = s.rp.DeleteEvent(ctx, vehicleId, id)
+	return
+}
+
+// FindPlans is a method that returns the recurring services of a vehicle
+func (s *MaintenanceDefault) FindPlans(ctx context.Context, vehicleId int) (p []internal.MaintenancePlan, err error) {
+	if err = s.exists(ctx, vehicleId); err != nil {
+		return
+	}
+
+	p, err = s.rp.FindPlans(ctx, vehicleId)
+	return
+}
+
+// SavePlan is a method that adds a recurring service to a vehicle, its first interval starting
+// today and at the odometer of the vehicle when the start is absent
+func (s *MaintenanceDefault) SavePlan(ctx context.Context, p *internal.MaintenancePlan) (v internal.MaintenancePlan, err error) {
+	if err = s.exists(ctx, p.VehicleId); err != nil {
+		return
+	}
+	p.Type = strings.TrimSpace(p.Type)
+	if err = p.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidMaintenanceData, err.Error())
+		return
+	}
+	if p.StartDate.IsZero() {
+		p.StartDate = day(time.Now())
+	}
+	if p.StartOdometer == 0 {
+		var events []internal.MaintenanceEvent
+		if events, err = s.rp.FindEvents(ctx, p.VehicleId); err != nil {
+			return
+		}
+		for _, e := range events {
+			p.StartOdometer = max(p.StartOdometer, e.Odometer)
+		}
+	}
+
+	v, err = s.rp.SavePlan(ctx, p)
+	return
+}
+
+// DeletePlan is a method that removes a recurring service of a vehicle
+func (s *MaintenanceDefault) DeletePlan(ctx context.Context, vehicleId, id int) (err error) {
+	if err = s.exists(ctx, vehicleId); err != nil {
+		return
+	}
+
+	err = s.rp.DeletePlan(ctx, vehicleId, id)
+	return
+}
+
+// Due is a method that returns when each plan of a vehicle is due at the time at
+func (s *MaintenanceDefault) Due(ctx context.Context, vehicleId int, at time.Time, h internal.MaintenanceHorizon) (d []internal.MaintenanceDue, err error) {
+	if err = s.exists(ctx, vehicleId); err != nil {
+		return
+	}
+	plans, err := s.rp.FindPlans(ctx, vehicleId)
+	if err != nil {
+		return
+	}
+	events, err := s.rp.FindEvents(ctx, vehicleId)
+	if err != nil {
+		return
+	}
+
+	d = make([]internal.MaintenanceDue, 0, len(plans))
+	for _, p := range plans {
+		d = append(d, due(p, events, at, h))
+	}
+	return
+}
+
+// FleetDue is a method that returns the plans of the fleet overdue or upcoming at the time at,
+// the overdue first and then by due date. The plans of the vehicles removed are skipped.
+func (s *MaintenanceDefault) FleetDue(ctx context.Context, at time.Time, h internal.MaintenanceHorizon) (d []internal.MaintenanceDue, err error) {
+	vehicles, err := s.vehicles.FindAll(ctx)
+	if err != nil {
+		return
+	}
+	plans, err := s.rp.FindAllPlans(ctx)
+	if err != nil {
+		return
+	}
+
+	d = []internal.MaintenanceDue{}
+	events := make(map[int][]internal.MaintenanceEvent)
+	for _, p := range plans {
+		if _, ok := vehicles[p.VehicleId]; !ok {
+			continue
+		}
+		ev, ok := events[p.VehicleId]
+		if !ok {
+			if ev, err = s.rp.FindEvents(ctx, p.VehicleId); err != nil {
+				return
+			}
+			events[p.VehicleId] = ev
+		}
+		if md := due(p, ev, at, h); md.Status != internal.MaintenanceScheduled {
+			d = append(d, md)
+		}
+	}
+
+	sort.SliceStable(d, func(i, j int) bool {
+		if oi, oj := d[i].Status == internal.MaintenanceOverdue, d[j].Status == internal.MaintenanceOverdue; oi != oj {
+			return oi
+		}
+		switch di, dj := d[i].DueDate, d[j].DueDate; {
+		case di != nil && dj != nil:
+			return di.Before(*dj)
+		default:
+			// the plans due by date before the plans due only by distance
+			return di != nil && dj == nil
+		}
+	})
+	return
+}
+
+// exists is a method that returns ErrVehicleNotFound when the vehicle does not exist
+func (s *MaintenanceDefault) exists(ctx context.Context, vehicleId int) (err error) {
+	_, err = findVehicle(ctx, s.vehicles, vehicleId)
+	return
+}
+
+// due is a function that returns when a plan is due at the time at, its interval starting at the
+// last event of its type or, without one, at its start. The odometer of the vehicle is the highest
+// of its events. A plan is overdue the day after its due date or past its due distance, on the
+// due day or at the due distance it is upcoming whatever the horizon.
+func due(p internal.MaintenancePlan, events []internal.MaintenanceEvent, at time.Time, h internal.MaintenanceHorizon) (d internal.MaintenanceDue) {
+	d.Plan, d.Odometer = p, p.StartOdometer
+	key := internal.MaintenanceTypeKey(p.Type)
+	for i, e := range events {
+		d.Odometer = max(d.Odometer, e.Odometer)
+		if internal.MaintenanceTypeKey(e.Type) == key {
+			d.Last = &events[i]
+		}
+	}
+
+	from, fromKm := p.StartDate, p.StartOdometer
+	if d.Last != nil {
+		from, fromKm = d.Last.Date, d.Last.Odometer
+	}
+	if p.IntervalDays > 0 {
+		dueDate := day(from).AddDate(0, 0, p.IntervalDays)
+		d.DueDate = &dueDate
+	}
+	if p.IntervalKm > 0 {
+		dueKm := fromKm + p.IntervalKm
+		d.DueOdometer = &dueKm
+	}
+
+	today := day(at)
+	switch {
+	case d.DueDate != nil && today.After(*d.DueDate),
+		d.DueOdometer != nil && d.Odometer > *d.DueOdometer:
+		d.Status = internal.MaintenanceOverdue
+	case d.DueDate != nil && !today.AddDate(0, 0, h.Days).Before(*d.DueDate),
+		d.DueOdometer != nil && d.Odometer+h.Km >= *d.DueOdometer:
+		d.Status = internal.MaintenanceUpcoming
+	default:
+		d.Status = internal.MaintenanceScheduled
+	}
+	return
+}
+
+// day is a function that returns the day of t, at midnight UTC
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
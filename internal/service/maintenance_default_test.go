@@ -0,0 +1,164 @@
+package service
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestDue is a function that checks the status of the plans by time and by distance, the
+// boundaries of the due day, the due distance and the horizon, and the interval restarted by the
+// last event of the type of the plan
+func TestDue(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// the plans by time are due on January 31 and the plans by distance at 10000 km
+	byTime := internal.MaintenancePlan{Type: "Oil change", IntervalDays: 30, StartDate: start}
+	byDistance := internal.MaintenancePlan{Type: "Oil change", IntervalKm: 5000, StartDate: start, StartOdometer: 5000}
+	both := internal.MaintenancePlan{Type: "Oil change", IntervalDays: 30, IntervalKm: 5000, StartDate: start, StartOdometer: 5000}
+	odometer := func(km float64) []internal.MaintenanceEvent {
+		return []internal.MaintenanceEvent{{Date: start, Odometer: km, Type: "Tires"}}
+	}
+
+	cases := map[string]struct {
+		plan         internal.MaintenancePlan
+		events       []internal.MaintenanceEvent
+		at           time.Time
+		horizon      internal.MaintenanceHorizon
+		wantStatus   string
+		wantDueDate  time.Time
+		wantDueKm    float64
+		wantOdometer float64
+	}{
+		"by time scheduled": {
+			plan: byTime, at: time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceScheduled, wantDueDate: start.AddDate(0, 0, 30),
+		},
+		"by time the day before the horizon": {
+			plan: byTime, at: time.Date(2026, time.January, 23, 0, 0, 0, 0, time.UTC), horizon: internal.MaintenanceHorizon{Days: 7},
+			wantStatus: internal.MaintenanceScheduled, wantDueDate: start.AddDate(0, 0, 30),
+		},
+		"by time at the horizon": {
+			plan: byTime, at: time.Date(2026, time.January, 24, 0, 0, 0, 0, time.UTC), horizon: internal.MaintenanceHorizon{Days: 7},
+			wantStatus: internal.MaintenanceUpcoming, wantDueDate: start.AddDate(0, 0, 30),
+		},
+		"by time on the due day late in the day": {
+			plan: byTime, at: time.Date(2026, time.January, 31, 23, 59, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceUpcoming, wantDueDate: start.AddDate(0, 0, 30),
+		},
+		"by time the day after the due day": {
+			plan: byTime, at: time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceOverdue, wantDueDate: start.AddDate(0, 0, 30),
+		},
+		"by distance scheduled": {
+			plan: byDistance, events: odometer(8000), at: start, horizon: internal.MaintenanceHorizon{Km: 1000},
+			wantStatus: internal.MaintenanceScheduled, wantDueKm: 10000, wantOdometer: 8000,
+		},
+		"by distance at the horizon": {
+			plan: byDistance, events: odometer(9000), at: start, horizon: internal.MaintenanceHorizon{Km: 1000},
+			wantStatus: internal.MaintenanceUpcoming, wantDueKm: 10000, wantOdometer: 9000,
+		},
+		"by distance at the due distance": {
+			plan: byDistance, events: odometer(10000), at: start,
+			wantStatus: internal.MaintenanceUpcoming, wantDueKm: 10000, wantOdometer: 10000,
+		},
+		"by distance past the due distance": {
+			plan: byDistance, events: odometer(10001), at: start,
+			wantStatus: internal.MaintenanceOverdue, wantDueKm: 10000, wantOdometer: 10001,
+		},
+		"both overdue by distance before the due day": {
+			plan: both, events: odometer(12000), at: time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceOverdue, wantDueDate: start.AddDate(0, 0, 30), wantDueKm: 10000, wantOdometer: 12000,
+		},
+		"both overdue by time before the due distance": {
+			plan: both, events: odometer(6000), at: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceOverdue, wantDueDate: start.AddDate(0, 0, 30), wantDueKm: 10000, wantOdometer: 6000,
+		},
+		"last event of the type restarts the interval": {
+			plan: both,
+			events: []internal.MaintenanceEvent{
+				{Date: start.AddDate(0, 0, 20), Odometer: 9000, Type: "oil  CHANGE"},
+				{Date: start.AddDate(0, 0, 25), Odometer: 9500, Type: "Tires"},
+			},
+			at:         time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceScheduled, wantDueDate: start.AddDate(0, 0, 50), wantDueKm: 14000, wantOdometer: 9500,
+		},
+		"events of other types keep the interval": {
+			plan: byTime,
+			events: []internal.MaintenanceEvent{
+				{Date: start.AddDate(0, 0, 28), Odometer: 9000, Type: "Tires"},
+			},
+			at:         time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC),
+			wantStatus: internal.MaintenanceOverdue, wantDueDate: start.AddDate(0, 0, 30), wantOdometer: 9000,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := due(c.plan, c.events, c.at, c.horizon)
+
+			if d.Status != c.wantStatus {
+				t.Errorf("got status %s, want %s", d.Status, c.wantStatus)
+			}
+			switch {
+			case c.wantDueDate.IsZero() && d.DueDate != nil:
+				t.Errorf("got due date %v, want none", *d.DueDate)
+			case !c.wantDueDate.IsZero() && (d.DueDate == nil || !d.DueDate.Equal(c.wantDueDate)):
+				t.Errorf("got due date %v, want %v", d.DueDate, c.wantDueDate)
+			}
+			switch {
+			case c.wantDueKm == 0 && d.DueOdometer != nil:
+				t.Errorf("got due odometer %v, want none", *d.DueOdometer)
+			case c.wantDueKm != 0 && (d.DueOdometer == nil || *d.DueOdometer != c.wantDueKm):
+				t.Errorf("got due odometer %v, want %v", d.DueOdometer, c.wantDueKm)
+			}
+			if c.wantOdometer != 0 && d.Odometer != c.wantOdometer {
+				t.Errorf("got odometer %v, want %v", d.Odometer, c.wantOdometer)
+			}
+		})
+	}
+}
+
+// TestMaintenanceDefault_FleetDue is a function that checks that the fleet report skips the
+// scheduled plans and the plans of the vehicles removed, the overdue first, then by due date and
+// the plans due only by distance last
+func TestMaintenanceDefault_FleetDue(t *testing.T) {
+	start := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	vehicles := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "ABC1D23"}},
+		2: {Id: 2, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "ABC1D24"}},
+	})
+	rp := repository.NewMaintenanceMap(internal.MaintenanceLog{
+		Events: []internal.MaintenanceEvent{{Id: 1, VehicleId: 2, Date: start, Odometer: 9500, Type: "Tires"}},
+		Plans: []internal.MaintenancePlan{
+			// upcoming, due on January 31
+			{Id: 1, VehicleId: 1, Type: "Oil change", IntervalDays: 30, StartDate: start},
+			// scheduled, due on March 2
+			{Id: 2, VehicleId: 1, Type: "Brakes", IntervalDays: 60, StartDate: start},
+			// overdue, due on January 21
+			{Id: 3, VehicleId: 1, Type: "Filters", IntervalDays: 20, StartDate: start},
+			// upcoming only by distance, due at 10000 km
+			{Id: 4, VehicleId: 2, Type: "Oil change", IntervalKm: 5000, StartOdometer: 5000, StartDate: start},
+			// upcoming, due on January 26
+			{Id: 5, VehicleId: 2, Type: "Brakes", IntervalDays: 25, StartDate: start},
+			// overdue, due on January 11, of a vehicle removed
+			{Id: 6, VehicleId: 3, Type: "Filters", IntervalDays: 10, StartDate: start},
+		},
+	})
+	sv := NewMaintenanceDefault(rp, vehicles)
+
+	d, err := sv.FleetDue(context.Background(), time.Date(2026, time.January, 25, 0, 0, 0, 0, time.UTC), internal.MaintenanceHorizon{Days: 7, Km: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{3, 5, 1, 4}
+	if len(d) != len(want) {
+		t.Fatalf("got %d plans, want %d", len(d), len(want))
+	}
+	for i, id := range want {
+		if d[i].Plan.Id != id {
+			t.Errorf("got plan %d at %d, want %d", d[i].Plan.Id, i, id)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NewReservationDefault is a function that returns a new instance of ReservationDefault
+func NewReservationDefault(rp internal.ReservationRepository, vehicles internal.VehicleRepository) *ReservationDefault {
+	return &ReservationDefault{rp: rp, vehicles: vehicles}
+}
+
+// ReservationDefault is a struct that represents the default service for the reservations
+type ReservationDefault struct {
+	// rp is the repository of the reservations
+	rp internal.ReservationRepository
+	// vehicles is the repository of the vehicles booked
+	vehicles internal.VehicleRepository
+}
+
+// FindAll is a method that returns the reservations matching the filter, by start
+func (s *ReservationDefault) FindAll(ctx context.Context, f internal.ReservationFilter) (r []internal.Reservation, err error) {
+	r, err = s.rp.FindAll(ctx, f)
+	return
+}
+
+// FindById is a method that returns a reservation
+func (s *ReservationDefault) FindById(ctx context.Context, id int) (r internal.Reservation, err error) {
+	r, err = s.rp.FindById(ctx, id)
+	return
+}
+
+// Book is a method that reserves a vehicle, free during the interval
+func (s *ReservationDefault) Book(ctx context.Context, r *internal.Reservation) (v internal.Reservation, err error) {
+	r.Requester, r.Purpose = strings.TrimSpace(r.Requester), strings.TrimSpace(r.Purpose)
+	if err = r.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidReservationData, err.Error())
+		return
+	}
+	if _, err = findVehicle(ctx, s.vehicles, r.VehicleId); err != nil {
+		return
+	}
+	r.Status, r.CheckedOutAt, r.CheckedInAt = internal.ReservationBooked, nil, nil
+
+	// the repository rejects the overlapping reservations
+	v, err = s.rp.Save(ctx, r)
+	return
+}
+
+// Cancel is a method that cancels a reservation before the check-out
+func (s *ReservationDefault) Cancel(ctx context.Context, id int) (v internal.Reservation, err error) {
+	r, err := s.rp.FindById(ctx, id)
+	if err != nil {
+		return
+	}
+	if r.Status != internal.ReservationBooked {
+		err = fmt.Errorf("%w: reservation %d is %s", apperrors.ErrReservationState, id, r.Status)
+		return
+	}
+
+	r.Status = internal.ReservationCancelled
+	v, err = s.rp.Update(ctx, &r, internal.ReservationBooked)
+	return
+}
+
+// CheckOut is a method that records the vehicle of a reservation taken at the time at, before
+// the end of the reservation
+func (s *ReservationDefault) CheckOut(ctx context.Context, id int, at time.Time) (v internal.Reservation, err error) {
+	r, err := s.rp.FindById(ctx, id)
+	if err != nil {
+		return
+	}
+	if r.Status != internal.ReservationBooked {
+		err = fmt.Errorf("%w: reservation %d is %s", apperrors.ErrReservationState, id, r.Status)
+		return
+	}
+	if !at.Before(r.End) {
+		err = fmt.Errorf("%w: reservation %d ended at %s", apperrors.ErrReservationState, id, r.End.Format(time.RFC3339))
+		return
+	}
+
+	r.Status, r.CheckedOutAt = internal.ReservationCheckedOut, &at
+	v, err = s.rp.Update(ctx, &r, internal.ReservationBooked)
+	return
+}
+
+// CheckIn is a method that records the vehicle of a reservation returned at the time at
+func (s *ReservationDefault) CheckIn(ctx context.Context, id int, at time.Time) (v internal.Reservation, err error) {
+	r, err := s.rp.FindById(ctx, id)
+	if err != nil {
+		return
+	}
+	if r.Status != internal.ReservationCheckedOut {
+		err = fmt.Errorf("%w: reservation %d is %s", apperrors.ErrReservationState, id, r.Status)
+		return
+	}
+	if at.Before(*r.CheckedOutAt) {
+		err = fmt.Errorf("%w: check-in before the check-out", apperrors.ErrInvalidReservationData)
+		return
+	}
+
+	r.Status, r.CheckedInAt = internal.ReservationReturned, &at
+	v, err = s.rp.Update(ctx, &r, internal.ReservationCheckedOut)
+	return
+}
+
+// Reserved is a method that returns the ids of the vehicles held by a reservation overlapping
+// the interval from start to end
+func (s *ReservationDefault) Reserved(ctx context.Context, start, end time.Time) (ids map[int]struct{}, err error) {
+	r, err := s.rp.FindAll(ctx, internal.ReservationFilter{From: start, To: end})
+	if err != nil {
+		return
+	}
+
+	ids = make(map[int]struct{})
+	for _, value := range r {
+		if value.Holds() {
+			ids[value.VehicleId] = struct{}{}
+		}
+	}
+	return
+}
+
+// FindAvailable is a function that returns the vehicles of sv matching the filter and free from
+// start to end, no reservation of rs holding them during the interval
+func FindAvailable(ctx context.Context, sv internal.VehicleService, rs internal.ReservationService, f VehicleFilter, start, end time.Time) (v map[int]internal.Vehicle, err error) {
+	if !end.After(start) {
+		err = fmt.Errorf("%w: end must be after start", apperrors.ErrInvalidReservationData)
+		return
+	}
+	v, err = FindByFilter(ctx, sv, f)
+	if err != nil {
+		return
+	}
+	reserved, err := rs.Reserved(ctx, start, end)
+	if err != nil {
+		return
+	}
+
+	for id := range reserved {
+		delete(v, id)
+	}
+	return
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+// TestFindAvailable is a function that checks that the vehicles held by a reservation overlapping
+// the interval are left out, the cancelled and returned reservations freeing their vehicles
+func TestFindAvailable(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, time.October, 20, hour, 0, 0, 0, time.UTC) }
+	vehicles := make(map[int]internal.Vehicle)
+	for id := 1; id <= 4; id++ {
+		vehicles[id] = internal.Vehicle{Id: id, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat"}}
+	}
+	sv := NewVehicleDefault(repository.NewVehicleMap(vehicles), nil, nil, nil)
+	rs := NewReservationDefault(repository.NewReservationMap([]internal.Reservation{
+		{Id: 1, VehicleId: 1, Start: at(8), End: at(12), Status: internal.ReservationBooked},
+		{Id: 2, VehicleId: 2, Start: at(10), End: at(14), Status: internal.ReservationCheckedOut},
+		{Id: 3, VehicleId: 3, Start: at(8), End: at(18), Status: internal.ReservationCancelled},
+		{Id: 4, VehicleId: 4, Start: at(8), End: at(18), Status: internal.ReservationReturned},
+	}), nil)
+
+	cases := map[string]struct {
+		start, end time.Time
+		want       []int
+		wantErr    error
+	}{
+		"before all":           {start: at(6), end: at(8), want: []int{1, 2, 3, 4}},
+		"overlapping a booked": {start: at(7), end: at(9), want: []int{2, 3, 4}},
+		"overlapping both":     {start: at(11), end: at(13), want: []int{3, 4}},
+		"after the booked":     {start: at(12), end: at(16), want: []int{1, 3, 4}},
+		"end before start":     {start: at(12), end: at(11), wantErr: apperrors.ErrInvalidReservationData},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, err := FindAvailable(context.Background(), sv, rs, VehicleFilter{}, c.start, c.end)
+			if c.wantErr != nil {
+				if !errors.Is(err, c.wantErr) {
+					t.Fatalf("got error %v, want %v", err, c.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+
+			got := make([]int, 0, len(v))
+			for id := range v {
+				got = append(got, id)
+			}
+			sort.Ints(got)
+			if len(got) != len(c.want) {
+				t.Fatalf("got vehicles %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("got vehicles %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
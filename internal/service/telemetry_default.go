@@ -0,0 +1,150 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"app/pkg/geo"
+	"app/pkg/logger"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// TelemetryMaxSkew is how far in the future the time of a sample may be, the clocks of the
+// trackers drifting from the one of the server
+const TelemetryMaxSkew = 5 * time.Minute
+
+// NewTelemetryDefault is a function that returns a new instance of TelemetryDefault accepting the
+// samples of the last retention
+func NewTelemetryDefault(rp internal.TelemetryRepository, vehicles internal.VehicleRepository, retention time.Duration) *TelemetryDefault {
+	return &TelemetryDefault{rp: rp, vehicles: vehicles, retention: retention}
+}
+
+// TelemetryDefault is a struct that represents the default service for the telemetry of the vehicles
+type TelemetryDefault struct {
+	// rp is the time series store of the samples and alerts
+	rp internal.TelemetryRepository
+	// vehicles is the repository of the vehicles tracked
+	vehicles internal.VehicleRepository
+	// retention is how old a sample may be, the store dropping the older ones
+	retention time.Duration
+}
+
+// Ingest is a method that stores a batch of samples, rejecting the ones of unknown vehicles,
+// invalid, older than the retention or ahead of TelemetryMaxSkew, and raising an alert for each
+// reading above the max speed of its vehicle
+func (s *TelemetryDefault) Ingest(ctx context.Context, samples []internal.TelemetrySample) (r internal.IngestResult, err error) {
+	now := time.Now()
+	oldest, newest := now.Add(-s.retention), now.Add(TelemetryMaxSkew)
+
+	// the vehicles of a batch are few, each found once
+	vehicles := make(map[int]internal.Vehicle)
+	valid := make([]internal.TelemetrySample, 0, len(samples))
+	r.Rejected, r.Alerts = []internal.IngestRejection{}, []internal.SpeedAlert{}
+	for i, value := range samples {
+		vh, ok := vehicles[value.VehicleId]
+		if !ok {
+			vh, err = findVehicle(ctx, s.vehicles, value.VehicleId)
+			if err != nil && !errors.Is(err, apperrors.ErrVehicleNotFound) {
+				return
+			}
+			err = nil
+			vehicles[value.VehicleId] = vh
+		}
+
+		reason := ""
+		switch e := value.Validate(); {
+		case vh.Id == 0:
+			reason = fmt.Sprintf("vehicle %d not found", value.VehicleId)
+		case e != nil:
+			reason = e.Error()
+		case value.Time.Before(oldest):
+			reason = "time older than the retention of " + s.retention.String()
+		case value.Time.After(newest):
+			reason = "time in the future"
+		}
+		if reason != "" {
+			r.Rejected = append(r.Rejected, internal.IngestRejection{Index: i, Reason: reason})
+			continue
+		}
+
+		valid = append(valid, value)
+		if vh.MaxSpeed > 0 && value.Speed > vh.MaxSpeed {
+			r.Alerts = append(r.Alerts, internal.SpeedAlert{Sample: value, MaxSpeed: vh.MaxSpeed})
+		}
+	}
+
+	if err = s.rp.Append(ctx, valid); err != nil {
+		return
+	}
+	r.Accepted = len(valid)
+	if len(r.Alerts) == 0 {
+		return
+	}
+	if err = s.rp.AppendAlerts(ctx, r.Alerts); err != nil {
+		return
+	}
+	for _, a := range r.Alerts {
+		logger.FromContext(ctx).Warn("service: speed above the max speed of the vehicle",
+			slog.Int("vehicle_id", a.Sample.VehicleId),
+			slog.Float64("speed", a.Sample.Speed),
+			slog.Float64("max_speed", a.MaxSpeed),
+			slog.Time("at", a.Sample.Time),
+		)
+	}
+	return
+}
+
+// Latest is a method that returns the most recent sample of a vehicle
+func (s *TelemetryDefault) Latest(ctx context.Context, vehicleId int) (v internal.TelemetrySample, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	v, err = s.rp.Latest(ctx, vehicleId)
+	return
+}
+
+// Track is a method that returns the samples of a vehicle from from until to, the oldest first
+func (s *TelemetryDefault) Track(ctx context.Context, vehicleId int, from, to time.Time) (v []internal.TelemetrySample, err error) {
+	if to.Before(from) {
+		err = fmt.Errorf("%w: to must not be before from", apperrors.ErrInvalidTelemetryData)
+		return
+	}
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	v, err = s.rp.Track(ctx, vehicleId, from, to)
+	return
+}
+
+// Nearby is a method that returns the latest samples of the vehicles at most radius km from center,
+// the nearest first
+func (s *TelemetryDefault) Nearby(ctx context.Context, center geo.Point, radius float64) (n []internal.NearbySample, err error) {
+	if err = center.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidTelemetryData, err.Error())
+		return
+	}
+	if !(radius > 0) {
+		err = fmt.Errorf("%w: radius must be positive", apperrors.ErrInvalidTelemetryData)
+		return
+	}
+
+	n, err = s.rp.Nearby(ctx, center, radius)
+	return
+}
+
+// Alerts is a method that returns the speed alerts matching the filter, the most recent first
+func (s *TelemetryDefault) Alerts(ctx context.Context, f internal.SpeedAlertFilter) (a []internal.SpeedAlert, err error) {
+	if f.VehicleId != 0 {
+		if _, err = findVehicle(ctx, s.vehicles, f.VehicleId); err != nil {
+			return
+		}
+	}
+
+	a, err = s.rp.FindAlerts(ctx, f)
+	return
+}
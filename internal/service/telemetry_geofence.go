@@ -0,0 +1,65 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"context"
+	"time"
+)
+
+// NewTelemetryGeofence is a function that returns a telemetry service decorator checking the zones
+// of the samples ingested
+func NewTelemetryGeofence(sv internal.TelemetryService, zones internal.ZoneService) *TelemetryGeofence {
+	return &TelemetryGeofence{sv: sv, zones: zones}
+}
+
+// TelemetryGeofence is a struct that decorates a TelemetryService with the geofencing of the
+// samples ingested
+type TelemetryGeofence struct {
+	// sv is the decorated service
+	sv internal.TelemetryService
+	// zones is the service the samples stored are evaluated by
+	zones internal.ZoneService
+}
+
+// Ingest is a method that decorates the service Ingest, evaluating the zones of the samples stored
+func (s *TelemetryGeofence) Ingest(ctx context.Context, samples []internal.TelemetrySample) (r internal.IngestResult, err error) {
+	r, err = s.sv.Ingest(ctx, samples)
+	if err != nil || r.Accepted == 0 {
+		return
+	}
+
+	rejected := make(map[int]bool, len(r.Rejected))
+	for _, value := range r.Rejected {
+		rejected[value.Index] = true
+	}
+	stored := make([]internal.TelemetrySample, 0, r.Accepted)
+	for i, value := range samples {
+		if !rejected[i] {
+			stored = append(stored, value)
+		}
+	}
+
+	_, err = s.zones.Evaluate(ctx, stored)
+	return
+}
+
+// Latest is a method that decorates the service Latest
+func (s *TelemetryGeofence) Latest(ctx context.Context, vehicleId int) (v internal.TelemetrySample, err error) {
+	return s.sv.Latest(ctx, vehicleId)
+}
+
+// Track is a method that decorates the service Track
+func (s *TelemetryGeofence) Track(ctx context.Context, vehicleId int, from, to time.Time) (v []internal.TelemetrySample, err error) {
+	return s.sv.Track(ctx, vehicleId, from, to)
+}
+
+// Nearby is a method that decorates the service Nearby
+func (s *TelemetryGeofence) Nearby(ctx context.Context, center geo.Point, radius float64) (n []internal.NearbySample, err error) {
+	return s.sv.Nearby(ctx, center, radius)
+}
+
+// Alerts is a method that decorates the service Alerts
+func (s *TelemetryGeofence) Alerts(ctx context.Context, f internal.SpeedAlertFilter) (a []internal.SpeedAlert, err error) {
+	return s.sv.Alerts(ctx, f)
+}
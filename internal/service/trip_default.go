@@ -0,0 +1,197 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NewTripDefault is a function that returns a new instance of TripDefault
+func NewTripDefault(rp internal.TripRepository, vehicles internal.VehicleRepository, drivers internal.DriverRepository) *TripDefault {
+	return &TripDefault{rp: rp, vehicles: vehicles, drivers: drivers}
+}
+
+// TripDefault is a struct that represents the default service for the trips and the utilization of the vehicles
+type TripDefault struct {
+	// rp is the repository of the trips
+	rp internal.TripRepository
+	// vehicles is the repository of the vehicles used
+	vehicles internal.VehicleRepository
+	// drivers is the repository of the drivers of the trips
+	drivers internal.DriverRepository
+}
+
+// FindAll is a method that returns the trips matching the filter, by start, the vehicle of the
+// filter must exist
+func (s *TripDefault) FindAll(ctx context.Context, f internal.TripFilter) (t []internal.Trip, err error) {
+	if f.VehicleId != 0 {
+		if _, err = findVehicle(ctx, s.vehicles, f.VehicleId); err != nil {
+			return
+		}
+	}
+
+	t, err = s.rp.FindAll(ctx, f)
+	return
+}
+
+// Record is a method that records a trip of a vehicle, by a known driver when one is given
+func (s *TripDefault) Record(ctx context.Context, t *internal.Trip) (v internal.Trip, err error) {
+	if _, err = findVehicle(ctx, s.vehicles, t.VehicleId); err != nil {
+		return
+	}
+	t.Purpose = strings.TrimSpace(t.Purpose)
+	// the trips are kept in UTC, the days of the utilization being UTC days
+	t.Start, t.End = t.Start.UTC(), t.End.UTC()
+	if err = t.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidTripData, err.Error())
+		return
+	}
+	if t.DriverId != 0 {
+		if _, err = s.drivers.FindById(ctx, t.DriverId); err != nil {
+			return
+		}
+	}
+
+	// the repository keeps the odometer from decreasing
+	v, err = s.rp.Save(ctx, t)
+	return
+}
+
+// Delete is a method that removes a trip of a vehicle
+func (s *TripDefault) Delete(ctx context.Context, vehicleId, id int) (err error) {
+	if _, err = findVehicle(ctx, s.vehicles, vehicleId); err != nil {
+		return
+	}
+
+	err = s.rp.Delete(ctx, vehicleId, id)
+	return
+}
+
+// Utilization is a method that returns the use of the fleet from the day from to the day to,
+// both included, every vehicle reported, the ones without trips idle the whole period
+func (s *TripDefault) Utilization(ctx context.Context, from, to time.Time) (r internal.UtilizationReport, err error) {
+	from, to = day(from), day(to)
+	if to.Before(from) {
+		err = fmt.Errorf("%w: to must not be before from", apperrors.ErrInvalidTripData)
+		return
+	}
+	end := to.AddDate(0, 0, 1)
+
+	vehicles, err := s.vehicles.FindAll(ctx)
+	if err != nil {
+		return
+	}
+	trips, err := s.rp.FindAll(ctx, internal.TripFilter{From: from, To: end})
+	if err != nil {
+		return
+	}
+
+	r = utilization(vehicles, trips, from, end)
+	r.To = to
+	return
+}
+
+// Underused is a method that returns the vehicles below the criteria from the day from to the
+// day to, the least used first and then the least driven
+func (s *TripDefault) Underused(ctx context.Context, from, to time.Time, c internal.UnderuseCriteria) (u []internal.UnderusedVehicle, err error) {
+	if c.MinDistance < 0 || c.MinUsage < 0 || c.MinUsage > 1 {
+		err = fmt.Errorf("%w: the minimum distance must not be negative and the minimum usage must be from 0 to 1", apperrors.ErrInvalidTripData)
+		return
+	}
+	r, err := s.Utilization(ctx, from, to)
+	if err != nil {
+		return
+	}
+
+	u = []internal.UnderusedVehicle{}
+	for _, value := range r.Vehicles {
+		var reasons []string
+		if c.MinDistance > 0 && value.Distance < c.MinDistance {
+			reasons = append(reasons, internal.UnderusedDistance)
+		}
+		if c.MinUsage > 0 && value.Usage() < c.MinUsage {
+			reasons = append(reasons, internal.UnderusedUsage)
+		}
+		if len(reasons) > 0 {
+			u = append(u, internal.UnderusedVehicle{Utilization: value, Reasons: reasons})
+		}
+	}
+	sort.SliceStable(u, func(i, j int) bool {
+		if ui, uj := u[i].Usage(), u[j].Usage(); ui != uj {
+			return ui < uj
+		}
+		return u[i].Distance < u[j].Distance
+	})
+	return
+}
+
+// utilization is a function that returns the use of the vehicles over the days from the day from
+// until end, excluded. The trips are clipped to the period, their distance prorated by time, and
+// the trips of the vehicles removed are skipped.
+func utilization(vehicles map[int]internal.Vehicle, trips []internal.Trip, from, end time.Time) (r internal.UtilizationReport) {
+	r.From = from
+	r.Days = int(end.Sub(from) / (24 * time.Hour))
+
+	used := make(map[int]*internal.Utilization, len(vehicles))
+	active := make(map[int][]bool, len(vehicles))
+	for id, vh := range vehicles {
+		used[id] = &internal.Utilization{Vehicle: vh}
+		active[id] = make([]bool, r.Days)
+	}
+	for _, t := range trips {
+		u, ok := used[t.VehicleId]
+		if !ok {
+			continue
+		}
+		start, stop := t.Start.UTC(), t.End.UTC()
+		if start.Before(from) {
+			start = from
+		}
+		if stop.After(end) {
+			stop = end
+		}
+		in := stop.Sub(start)
+
+		u.Trips++
+		u.Hours += in.Hours()
+		u.Distance += t.Distance() * float64(in) / float64(t.End.Sub(t.Start))
+		for d := day(start); d.Before(stop); d = d.AddDate(0, 0, 1) {
+			if i := int(d.Sub(from) / (24 * time.Hour)); i >= 0 && i < r.Days {
+				active[t.VehicleId][i] = true
+			}
+		}
+	}
+
+	brands := make(map[string]*internal.BrandUtilization)
+	for id, u := range used {
+		for _, a := range active[id] {
+			if a {
+				u.ActiveDays++
+			}
+		}
+		u.IdleDays = r.Days - u.ActiveDays
+		r.Vehicles = append(r.Vehicles, *u)
+
+		b, ok := brands[u.Vehicle.Brand]
+		if !ok {
+			b = &internal.BrandUtilization{Brand: u.Vehicle.Brand}
+			brands[u.Vehicle.Brand] = b
+		}
+		b.Vehicles++
+		b.Trips += u.Trips
+		b.Distance += u.Distance
+		b.Hours += u.Hours
+		b.ActiveDays += u.ActiveDays
+		b.IdleDays += u.IdleDays
+	}
+	for _, b := range brands {
+		r.Brands = append(r.Brands, *b)
+	}
+	sort.Slice(r.Vehicles, func(i, j int) bool { return r.Vehicles[i].Vehicle.Id < r.Vehicles[j].Vehicle.Id })
+	sort.Slice(r.Brands, func(i, j int) bool { return r.Brands[i].Brand < r.Brands[j].Brand })
+	return
+}
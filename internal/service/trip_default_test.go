@@ -0,0 +1,108 @@
+package service
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestUtilization_Offset is a function that checks that a trip written with an offset behind
+// UTC, on the day before the period in its own time, counts on the UTC day it falls on
+func TestUtilization_Offset(t *testing.T) {
+	brt := time.FixedZone("BRT", -3*60*60)
+	vehicles := map[int]internal.Vehicle{1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat"}}}
+	trips := []internal.Trip{{
+		Id: 1, VehicleId: 1,
+		Start: time.Date(2026, time.October, 1, 22, 0, 0, 0, brt),
+		End:   time.Date(2026, time.October, 2, 2, 0, 0, 0, brt),
+	}}
+	from := time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC)
+
+	r := utilization(vehicles, trips, from, from.AddDate(0, 0, 1))
+
+	u := r.Vehicles[0]
+	if u.ActiveDays != 1 || u.IdleDays != 0 || u.Hours != 4 {
+		t.Errorf("got %d active days, %d idle and %v hours, want 1, 0 and 4", u.ActiveDays, u.IdleDays, u.Hours)
+	}
+}
+
+// TestTripDefault_Record_UTC is a function that checks that the trips are recorded in UTC and
+// reported by Utilization on the UTC days they fall on
+func TestTripDefault_Record_UTC(t *testing.T) {
+	brt := time.FixedZone("BRT", -3*60*60)
+	vehicles := repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "ABC1D23"}},
+	})
+	sv := NewTripDefault(repository.NewTripMap(nil), vehicles, nil)
+	ctx := context.Background()
+
+	v, err := sv.Record(ctx, &internal.Trip{
+		VehicleId:   1,
+		Start:       time.Date(2026, time.October, 1, 22, 0, 0, 0, brt),
+		End:         time.Date(2026, time.October, 1, 23, 0, 0, 0, brt),
+		EndOdometer: 40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Start.Location() != time.UTC || v.End.Location() != time.UTC {
+		t.Errorf("got trip from %s to %s, want UTC", v.Start, v.End)
+	}
+
+	day := time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC)
+	r, err := sv.Utilization(ctx, day, day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Vehicles[0]; got.ActiveDays != 1 || got.Distance != 40 {
+		t.Errorf("got %d active days and %v km, want 1 and 40", got.ActiveDays, got.Distance)
+	}
+}
+
+// TestUtilization_Clipping is a function that checks that the trips crossing the bounds of the
+// period count only their part inside it, hours, distance and active days alike
+func TestUtilization_Clipping(t *testing.T) {
+	from := time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return time.Date(2026, time.October, day, hour, 0, 0, 0, time.UTC) }
+	vehicles := map[int]internal.Vehicle{1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat"}}}
+
+	cases := map[string]struct {
+		trip       internal.Trip
+		wantHours  float64
+		wantKm     float64
+		wantActive int
+	}{
+		"inside": {
+			trip:      internal.Trip{Start: at(2, 10), End: at(2, 12), StartOdometer: 0, EndOdometer: 100},
+			wantHours: 2, wantKm: 100, wantActive: 1,
+		},
+		"across the start": {
+			trip:      internal.Trip{Start: at(1, 22), End: at(2, 2), StartOdometer: 0, EndOdometer: 100},
+			wantHours: 2, wantKm: 50, wantActive: 1,
+		},
+		"across the end": {
+			trip:      internal.Trip{Start: at(3, 18), End: at(4, 6), StartOdometer: 0, EndOdometer: 120},
+			wantHours: 6, wantKm: 60, wantActive: 1,
+		},
+		"across both days": {
+			trip:      internal.Trip{Start: at(2, 20), End: at(3, 4), StartOdometer: 0, EndOdometer: 80},
+			wantHours: 8, wantKm: 80, wantActive: 2,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.trip.Id, c.trip.VehicleId = 1, 1
+
+			r := utilization(vehicles, []internal.Trip{c.trip}, from, from.AddDate(0, 0, 2))
+
+			u := r.Vehicles[0]
+			if u.Hours != c.wantHours || u.Distance != c.wantKm || u.ActiveDays != c.wantActive || u.IdleDays != 2-c.wantActive {
+				t.Errorf("got %v hours, %v km, %d active and %d idle days, want %v, %v, %d and %d",
+					u.Hours, u.Distance, u.ActiveDays, u.IdleDays, c.wantHours, c.wantKm, c.wantActive, 2-c.wantActive)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// CompareMaxVehicles is the highest number of vehicles compared at once
+const CompareMaxVehicles = 10
+
+// ranking is the direction of the ranking of a numeric field
+type ranking int
+
+const (
+	// unranked fields have no best nor worst value
+	unranked ranking = iota
+	higherIsBetter
+	lowerIsBetter
+)
+
+// Compare is a function that returns the vehicles of ids side by side, from 2 to CompareMaxVehicles
+// of them. Max speed, passengers and weight, the lighter the better, are ranked, as the derived
+// volume of the dimensions and the power to weight proxy, the max speed by unit of weight.
+func Compare(ctx context.Context, sv internal.VehicleService, ids []int) (c internal.VehicleComparison, err error) {
+	if len(ids) < 2 || len(ids) > CompareMaxVehicles {
+		err = fmt.Errorf("%w: compare from 2 to %d vehicles, %d given", apperrors.ErrInvalidVehicleData, CompareMaxVehicles, len(ids))
+		return
+	}
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if seen[id] {
+			err = fmt.Errorf("%w: vehicle %d given twice", apperrors.ErrInvalidVehicleData, id)
+			return
+		}
+		seen[id] = true
+
+		var vh internal.Vehicle
+		vh, err = sv.FindById(ctx, strconv.Itoa(id))
+		if err != nil {
+			return
+		}
+		// the service returns a zero vehicle when it does not exist
+		if vh.Id == 0 {
+			err = fmt.Errorf("%w: %d", apperrors.ErrVehicleNotFound, id)
+			return
+		}
+		c.Vehicles = append(c.Vehicles, vh)
+	}
+
+	text := func(name string, value func(vh internal.Vehicle) string) {
+		f := internal.ComparedField{Name: name}
+		for _, vh := range c.Vehicles {
+			f.Text = append(f.Text, value(vh))
+		}
+		c.Fields = append(c.Fields, f)
+	}
+	number := func(name string, derived bool, r ranking, value func(vh internal.Vehicle) float64) {
+		f := internal.ComparedField{Name: name, Derived: derived, Ranked: r != unranked, HigherIsBetter: r == higherIsBetter}
+		for _, vh := range c.Vehicles {
+			f.Numbers = append(f.Numbers, value(vh))
+		}
+		if f.Ranked {
+			rank(&f, c.Vehicles)
+		}
+		c.Fields = append(c.Fields, f)
+	}
+
+	text("brand", func(vh internal.Vehicle) string { return vh.Brand })
+	text("model", func(vh internal.Vehicle) string { return vh.Model })
+	text("registration", func(vh internal.Vehicle) string { return vh.Registration })
+	text("color", func(vh internal.Vehicle) string { return vh.Color })
+	number("year", false, unranked, func(vh internal.Vehicle) float64 { return float64(vh.FabricationYear) })
+	number("passengers", false, higherIsBetter, func(vh internal.Vehicle) float64 { return float64(vh.Capacity) })
+	number("max_speed", false, higherIsBetter, func(vh internal.Vehicle) float64 { return vh.MaxSpeed })
+	text("fuel_type", func(vh internal.Vehicle) string { return vh.FuelType })
+	text("transmission", func(vh internal.Vehicle) string { return vh.Transmission })
+	number("weight", false, lowerIsBetter, func(vh internal.Vehicle) float64 { return vh.Weight })
+	number("height", false, unranked, func(vh internal.Vehicle) float64 { return vh.Height })
+	number("length", false, unranked, func(vh internal.Vehicle) float64 { return vh.Length })
+	number("width", false, unranked, func(vh internal.Vehicle) float64 { return vh.Width })
+	number("volume", true, higherIsBetter, volume)
+	number("power_to_weight", true, higherIsBetter, powerToWeight)
+	return
+}
+
+// volume is a function that returns the volume of the dimensions of a vehicle,
+// NaN when one of them is missing
+func volume(vh internal.Vehicle) float64 {
+	if vh.Height <= 0 || vh.Length <= 0 || vh.Width <= 0 {
+		return math.NaN()
+	}
+	return vh.Height * vh.Length * vh.Width
+}
+
+// powerToWeight is a function that returns the max speed of a vehicle by unit of weight,
+// the catalog having no power, NaN without weight
+func powerToWeight(vh internal.Vehicle) float64 {
+	if vh.Weight <= 0 {
+		return math.NaN()
+	}
+	return vh.MaxSpeed / vh.Weight
+}
+
+// rank is a function that sets the ids of the vehicles with the best and the worst value
+// of a numeric field, the NaN values ignored
+func rank(f *internal.ComparedField, vehicles []internal.Vehicle) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, n := range f.Numbers {
+		if !math.IsNaN(n) {
+			lo, hi = min(lo, n), max(hi, n)
+		}
+	}
+	// all equal, or fewer than two values
+	if !(lo < hi) {
+		return
+	}
+	best, worst := hi, lo
+	if !f.HigherIsBetter {
+		best, worst = lo, hi
+	}
+	for i, n := range f.Numbers {
+		switch n {
+		case best:
+			f.Best = append(f.Best, vehicles[i].Id)
+		case worst:
+			f.Worst = append(f.Worst, vehicles[i].Id)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+// TestCompare is a function that checks the ranking of the compared vehicles, the ties and the
+// metrics that cannot be computed, and the rejection of the unknown, duplicate and out of range ids
+func TestCompare(t *testing.T) {
+	vehicle := func(id int, maxSpeed float64, capacity int, weight, height float64) internal.Vehicle {
+		return internal.Vehicle{Id: id, VehicleAttributes: internal.VehicleAttributes{
+			Brand: "Fiat", MaxSpeed: maxSpeed, Capacity: capacity, Weight: weight,
+			Dimensions: internal.Dimensions{Height: height, Length: 4, Width: 2},
+		}}
+	}
+	sv := NewVehicleDefault(repository.NewVehicleMap(map[int]internal.Vehicle{
+		// volume 12 and power to weight 0.2
+		1: vehicle(1, 200, 5, 1000, 1.5),
+		// no volume without height and power to weight 0.25
+		2: vehicle(2, 200, 2, 800, 0),
+		// volume 8 and power to weight 0.125
+		3: vehicle(3, 150, 5, 1200, 1),
+		// volume 8 and no power to weight without weight
+		4: vehicle(4, 180, 5, 0, 1),
+	}), nil, nil, nil)
+
+	type ranked struct {
+		best, worst []int
+	}
+	cases := map[string]struct {
+		ids     []int
+		want    map[string]ranked
+		wantNaN map[string][]bool
+		wantErr error
+	}{
+		"ranked with ties": {
+			ids: []int{1, 2, 3},
+			want: map[string]ranked{
+				"max_speed":       {best: []int{1, 2}, worst: []int{3}},
+				"passengers":      {best: []int{1, 3}, worst: []int{2}},
+				"weight":          {best: []int{2}, worst: []int{3}},
+				"volume":          {best: []int{1}, worst: []int{3}},
+				"power_to_weight": {best: []int{2}, worst: []int{3}},
+				"year":            {},
+			},
+			wantNaN: map[string][]bool{"volume": {false, true, false}},
+		},
+		"metrics without a second value unranked": {
+			ids: []int{2, 4},
+			want: map[string]ranked{
+				"volume":          {},
+				"power_to_weight": {},
+			},
+			wantNaN: map[string][]bool{"volume": {true, false}, "power_to_weight": {false, true}},
+		},
+		"all equal unranked": {
+			ids:  []int{3, 4},
+			want: map[string]ranked{"passengers": {}, "volume": {}},
+		},
+		"ten vehicles accepted": {
+			ids:     []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			wantErr: apperrors.ErrVehicleNotFound,
+		},
+		"unknown id":     {ids: []int{1, 99}, wantErr: apperrors.ErrVehicleNotFound},
+		"duplicate id":   {ids: []int{1, 2, 1}, wantErr: apperrors.ErrInvalidVehicleData},
+		"a single id":    {ids: []int{1}, wantErr: apperrors.ErrInvalidVehicleData},
+		"eleven ids":     {ids: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, wantErr: apperrors.ErrInvalidVehicleData},
+		"no ids at all":  {ids: nil, wantErr: apperrors.ErrInvalidVehicleData},
+		"two known ones": {ids: []int{4, 1}, want: map[string]ranked{"max_speed": {best: []int{1}, worst: []int{4}}}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cmp, err := Compare(context.Background(), sv, c.ids)
+
+			if !errors.Is(err, c.wantErr) {
+				t.Fatalf("got error %v, want %v", err, c.wantErr)
+			}
+			if c.wantErr != nil {
+				return
+			}
+			for i, vh := range cmp.Vehicles {
+				if vh.Id != c.ids[i] {
+					t.Errorf("got vehicle %d at %d, want %d", vh.Id, i, c.ids[i])
+				}
+			}
+			fields := make(map[string]internal.ComparedField)
+			for _, f := range cmp.Fields {
+				fields[f.Name] = f
+			}
+			for name, want := range c.want {
+				f := fields[name]
+				if !slices.Equal(f.Best, want.best) || !slices.Equal(f.Worst, want.worst) {
+					t.Errorf("got %s best %v and worst %v, want %v and %v", name, f.Best, f.Worst, want.best, want.worst)
+				}
+			}
+			for name, want := range c.wantNaN {
+				for i, n := range fields[name].Numbers {
+					if math.IsNaN(n) != want[i] {
+						t.Errorf("got %s %v for vehicle %d, want NaN %t", name, n, c.ids[i], want[i])
+					}
+				}
+			}
+		})
+	}
+}
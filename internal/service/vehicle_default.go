@@ -2,28 +2,42 @@ package service
 
 import (
 	"app/internal"
+	"app/internal/search"
 	"app/pkg/apperrors"
+	"app/pkg/logger"
+	"app/pkg/plate"
+	"app/pkg/tenant"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 )
 
 // NewVehicleDefault is a function that returns a new instance of VehicleDefault
-func NewVehicleDefault(rp internal.VehicleRepository) *VehicleDefault {
-	return &VehicleDefault{rp: rp}
+func NewVehicleDefault(rp internal.VehicleRepository, ct internal.BrandCatalog, plates plate.Policy, attributes internal.AttributeRepository) *VehicleDefault {
+	return &VehicleDefault{rp: rp, ct: ct, plates: plates, attributes: attributes}
 }
 
 // VehicleDefault is a struct that represents the default service for vehicles
 type VehicleDefault struct {
 	// rp is the repository that will be used by the service
 	rp internal.VehicleRepository
+	// ct is the catalog of the brands, their canonical names are written and queried
+	ct internal.BrandCatalog
+	// plates are the formats of the registrations accepted for each tenant
+	plates plate.Policy
+	// attributes are the custom attributes defined by each tenant, nil accepting any custom attribute
+	attributes internal.AttributeRepository
 }
 
 // FindAll is a method that returns a map of all vehicles
-func (s *VehicleDefault) FindAll() (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindAll()
+func (s *VehicleDefault) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindAll(ctx)
 	return
 }
 
-func (s *VehicleDefault) UpdateFuel(id int, fuel string) (v internal.Vehicle, err error) {
-	v, err = s.rp.UpdateFuel(id, fuel)
+func (s *VehicleDefault) UpdateFuel(ctx context.Context, id int, fuel string) (v internal.Vehicle, err error) {
+	v, err = s.rp.UpdateFuel(ctx, id, fuel)
 
 	if err != nil {
 		return
@@ -31,8 +45,8 @@ func (s *VehicleDefault) UpdateFuel(id int, fuel string) (v internal.Vehicle, er
 
 	return
 }
-func (s *VehicleDefault) FindById(id string) (v internal.Vehicle, err error) {
-	v, err = s.rp.FindById(id)
+func (s *VehicleDefault) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	v, err = s.rp.FindById(ctx, id)
 
 	if err != nil {
 		return
@@ -41,8 +55,8 @@ func (s *VehicleDefault) FindById(id string) (v internal.Vehicle, err error) {
 	return
 }
 
-func (s *VehicleDefault) DeleteById(id string) (err error) {
-	err = s.rp.DeleteById(id)
+func (s *VehicleDefault) DeleteById(ctx context.Context, id string) (err error) {
+	err = s.rp.DeleteById(ctx, id)
 
 	if err != nil {
 		return
@@ -50,12 +64,15 @@ func (s *VehicleDefault) DeleteById(id string) (err error) {
 	return
 }
 
-func (s *VehicleDefault) FindByTransmissionType(typeTransmission string) (v map[int]internal.Vehicle, err error) {
+func (s *VehicleDefault) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
 
-	v, err = s.rp.FindByTransmissionType(typeTransmission)
+	v, err = s.rp.FindByTransmissionType(ctx, typeTransmission)
 
 	if err != nil {
-		err = apperrors.ErrInvalidVehicleData
+		// cancellation and deadlines are reported as they are
+		if ctx.Err() == nil {
+			err = apperrors.ErrInvalidVehicleData
+		}
 		return
 	}
 
@@ -67,8 +84,8 @@ func (s *VehicleDefault) FindByTransmissionType(typeTransmission string) (v map[
 	return
 }
 
-func (s *VehicleDefault) FindTipoCombustivel(FuelType string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindTipoCombustivel(FuelType)
+func (s *VehicleDefault) FindTipoCombustivel(ctx context.Context, FuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindTipoCombustivel(ctx, FuelType)
 
 	if err != nil {
 		return
@@ -81,8 +98,8 @@ func (s *VehicleDefault) FindTipoCombustivel(FuelType string) (v map[int]interna
 	return
 }
 
-func (s *VehicleDefault) FindByColorAndYears(color, year string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByColorAndYears(color, year)
+func (s *VehicleDefault) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByColorAndYears(ctx, color, year)
 
 	if err != nil {
 		return
@@ -95,8 +112,9 @@ func (s *VehicleDefault) FindByColorAndYears(color, year string) (v map[int]inte
 	return
 }
 
-func (s *VehicleDefault) FindVelocidadeMediaMarca(brand string) (m float64, err error) {
-	m, err = s.rp.FindVelocidadeMediaMarca(brand)
+func (s *VehicleDefault) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	brand, _ = s.ct.Brand(brand)
+	m, err = s.rp.FindVelocidadeMediaMarca(ctx, brand)
 
 	if err != nil {
 		return
@@ -110,8 +128,9 @@ func (s *VehicleDefault) FindVelocidadeMediaMarca(brand string) (m float64, err
 	return
 }
 
-func (s *VehicleDefault) FindByMarcaAndYearInterval(brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByMarcaAndYearInterval(brand, start_year, end_year)
+func (s *VehicleDefault) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	brand, _ = s.ct.Brand(brand)
+	v, err = s.rp.FindByMarcaAndYearInterval(ctx, brand, start_year, end_year)
 
 	if err != nil {
 		return
@@ -124,118 +143,157 @@ func (s *VehicleDefault) FindByMarcaAndYearInterval(brand, start_year, end_year
 	return
 }
 
-func (s *VehicleDefault) Save(vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+func (s *VehicleDefault) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
 
-	err = vh.Validate()
+	s.canonicalize(ctx, vh)
+	err = s.validate(ctx, vh)
 
 	if err != nil {
+		logger.FromContext(ctx).Info("service: invalid vehicle", slog.String("error", err.Error()))
 		return
 	}
-	vehicle, _ := s.rp.FindAll()
 
-	for _, vehicle := range vehicle {
-		if vehicle.Registration == vh.Registration {
-			err = apperrors.ErrVehicleAlreadyExists
-			return
-		}
+	// the repository enforces the unique registration
+	v, err = s.rp.Save(ctx, vh)
+	if errors.Is(err, apperrors.ErrVehicleAlreadyExists) {
+		logger.FromContext(ctx).Info("service: registration already exists", slog.String("registration", vh.Registration))
 	}
-	v, err = s.rp.Save(vh)
 	return
 }
 
-func (s *VehicleDefault) SaveMultipleVehicles(vh *[]internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+func (s *VehicleDefault) SaveMultipleVehicles(ctx context.Context, vh *[]internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
 
-	for _, vehicle := range *vh {
-		err = vehicle.Validate()
+	for i := range *vh {
+		s.canonicalize(ctx, &(*vh)[i])
 	}
-	if err != nil {
-		return
+	for i := range *vh {
+		if err = s.validate(ctx, &(*vh)[i]); err != nil {
+			return
+		}
 	}
 
-	vehicleExisting, _ := s.rp.FindAll()
-
-	// criar um MAP com registro como chave
-	registroMap := make(map[string]struct{}) // é menos do que veiculos existentes  pois tem registros repetidos
-	// registroError := make(map[string]string)
-	for _, ve := range vehicleExisting {
-		registroMap[ve.Registration] = struct{}{}
+	// the registrations taken or repeated in the batch are checked by the repository with the
+	// vehicles stored, all or none
+	v, err = s.rp.SaveBatch(ctx, *vh)
+	if errors.Is(err, apperrors.ErrVehicleAlreadyExists) {
+		logger.FromContext(ctx).Info("service: registration already exists", slog.String("error", err.Error()))
 	}
+	return
+}
 
-	v = make(map[int]internal.Vehicle)
-
-	for _, vehicle := range *vh {
+func (s *VehicleDefault) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
 
-		// similar ao in no python
-		_, exists := registroMap[vehicle.Registration]
+	s.canonicalize(ctx, &vh.VehicleAttributes)
+	err = s.validate(ctx, &vh.VehicleAttributes)
 
-		if exists {
-			err = apperrors.ErrVehicleAlreadyExists
-			return
-		}
+	if err != nil {
+		return
+	}
 
-		// Salve cada veículo individualmente
-		saved, saveErr := s.rp.Save(&vehicle)
+	v, err = s.rp.Patch(ctx, vh)
+	return
+}
 
-		if saveErr != nil {
-			err = saveErr
-			return
-		}
-		v[saved.Id] = saved
+func (s *VehicleDefault) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
 
-		// Atualize o map para evitar duplicidade entre os novos também
-		registroMap[vehicle.Registration] = struct{}{}
+	v, err = s.rp.UpdateMaxSpeed(ctx, id, maxSpeed)
+	if err != nil && ctx.Err() == nil {
+		err = apperrors.ErrVehicleNotFound
 	}
+	return
+}
+
+func (s *VehicleDefault) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	brand, _ = s.ct.Brand(brand)
+	m, err = s.rp.FindMediaPessoaPorMarca(ctx, brand)
 
+	if err != nil {
+		return
+	}
 	return
 }
 
-func (s *VehicleDefault) Patch(vh *internal.Vehicle) (v internal.Vehicle, err error) {
+func (s *VehicleDefault) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
 
-	err = vh.VehicleAttributes.Validate()
+	v, err = s.rp.FindByDimenssion(ctx, lengthParam, widthParam)
 
 	if err != nil {
 		return
 	}
 
-	v, err = s.rp.Patch(vh)
 	return
 }
 
-func (s *VehicleDefault) UpdateMaxSpeed(id int, maxSpeed float64) (v internal.Vehicle, err error) {
+func (s *VehicleDefault) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByPeso(ctx, min, max)
 
-	v, err = s.rp.UpdateMaxSpeed(id, maxSpeed)
 	if err != nil {
-		err = apperrors.ErrVehicleNotFound
+		return
 	}
+
 	return
 }
 
-func (s *VehicleDefault) FindMediaPessoaPorMarca(brand string) (m int, err error) {
-	m, err = s.rp.FindMediaPessoaPorMarca(brand)
+// Search is a method that returns the vehicles matching the words of query, the most relevant first.
+// A query without words is invalid, and nothing matching is an empty result, not an error.
+func (s *VehicleDefault) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	if len(search.Tokenize(query)) == 0 {
+		err = fmt.Errorf("%w: the query has no words", apperrors.ErrInvalidVehicleData)
+		return
+	}
 
+	v, err = s.rp.Search(ctx, query, limit)
 	if err != nil {
 		return
 	}
+	if v == nil {
+		v = []internal.VehicleMatch{}
+	}
+
 	return
 }
 
-func (s *VehicleDefault) FindByDimenssion(lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
-
-	v, err = s.rp.FindByDimenssion(lengthParam, widthParam)
+// canonicalize is a method that replaces the brand and the model of a vehicle to be written
+// by their names in the catalog, an unknown brand is kept as it is, and normalizes its tags
+func (s *VehicleDefault) canonicalize(ctx context.Context, vh *internal.VehicleAttributes) {
+	if !s.ct.Canonicalize(vh) && vh.Brand != "" {
+		logger.FromContext(ctx).Info("service: brand not in the catalog", slog.String("brand", vh.Brand))
+	}
+	vh.Tags = internal.NormalizeTags(vh.Tags)
+}
 
-	if err != nil {
+// FindByRegistration is a method that returns the vehicle of a registration, matched without
+// case and separators, ErrVehicleNotFound when there is none
+func (s *VehicleDefault) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	if plate.Normalize(registration) == "" {
+		err = fmt.Errorf("%w: the registration has no letters or digits", apperrors.ErrInvalidVehicleData)
 		return
 	}
 
+	v, err = s.rp.FindByRegistration(ctx, registration)
 	return
 }
 
-func (s *VehicleDefault) FindByPeso(min, max string) (v map[int]internal.Vehicle, err error) {
-	v, err = s.rp.FindByPeso(min, max)
-
+// validate is a method that validates a vehicle to be written, its registration in one of
+// the plate formats of the tenant and its custom attributes against the definitions of the tenant,
+// unless ctx skips them
+func (s *VehicleDefault) validate(ctx context.Context, vh *internal.VehicleAttributes) (err error) {
+	if err = vh.Validate(); err != nil {
+		return
+	}
+	if err = s.plates.Validate(tenant.FromContext(ctx), vh.Registration); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidVehicleData, err.Error())
+		return
+	}
+	if s.attributes == nil || !internal.CustomChecked(ctx) {
+		return
+	}
+	defs, err := s.attributes.FindAll(ctx, tenant.FromContext(ctx))
 	if err != nil {
 		return
 	}
-
+	if vh.Custom, err = internal.ValidateCustom(defs, vh.Custom); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidVehicleData, err.Error())
+	}
 	return
 }
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Range is a struct that represents a closed range of numbers
+type Range struct {
+	// Min is the lower bound of the range
+	Min float64
+	// Max is the upper bound of the range
+	Max float64
+}
+
+// VehicleFilter is a struct that represents the criteria to find vehicles, combined with AND,
+// the zero value matches every vehicle
+type VehicleFilter struct {
+	// Color and Year are informed together
+	Color string
+	Year  *int
+	// Brand, optionally between YearFrom and YearTo
+	Brand    string
+	YearFrom *int
+	YearTo   *int
+	// FuelType is the fuel type
+	FuelType string
+	// Transmission is the transmission
+	Transmission string
+	// Length and Width are the ranges of the dimensions, an absent one matches any value
+	Length *Range
+	Width  *Range
+	// WeightMin and WeightMax are the bounds of the weight
+	WeightMin *float64
+	WeightMax *float64
+	// PassengersMin is the lowest capacity
+	PassengersMin *int
+	// Tags are the tags the vehicles all have
+	Tags []string
+	// Custom are the values of the custom attributes by name, as written in the query parameters
+	Custom map[string]string
+}
+
+// FindByFilter is a function that returns the vehicles of sv matching every criteria of f,
+// each finder of the service narrows the result of the previous.
+// Nothing matching is an empty map, not an error.
+func FindByFilter(ctx context.Context, sv internal.VehicleService, f VehicleFilter) (v map[int]internal.Vehicle, err error) {
+	var finders []func() (map[int]internal.Vehicle, error)
+
+	if (f.Color != "") != (f.Year != nil) {
+		err = fmt.Errorf("%w: color and year must be informed together", apperrors.ErrInvalidVehicleData)
+		return
+	}
+	if f.Color != "" {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return sv.FindByColorAndYears(ctx, f.Color, strconv.Itoa(*f.Year))
+		})
+	}
+	if f.Brand != "" {
+		from, to := 0, math.MaxInt32
+		if f.YearFrom != nil {
+			from = *f.YearFrom
+		}
+		if f.YearTo != nil {
+			to = *f.YearTo
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return sv.FindByMarcaAndYearInterval(ctx, f.Brand, strconv.Itoa(from), strconv.Itoa(to))
+		})
+	}
+	if f.FuelType != "" {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) { return sv.FindTipoCombustivel(ctx, f.FuelType) })
+	}
+	if f.Transmission != "" {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) { return sv.FindByTransmissionType(ctx, f.Transmission) })
+	}
+	if f.Length != nil || f.Width != nil {
+		length, width := rangeParam(f.Length), rangeParam(f.Width)
+		if length == "" || width == "" {
+			err = fmt.Errorf("%w: dimensions must not be negative", apperrors.ErrInvalidVehicleData)
+			return
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) { return sv.FindByDimenssion(ctx, length, width) })
+	}
+	if f.WeightMin != nil || f.WeightMax != nil {
+		lower, upper := 0.0, math.MaxFloat64
+		if f.WeightMin != nil {
+			lower = *f.WeightMin
+		}
+		if f.WeightMax != nil {
+			upper = *f.WeightMax
+		}
+		finders = append(finders, func() (map[int]internal.Vehicle, error) {
+			return sv.FindByPeso(ctx, formatFloat(lower), formatFloat(upper))
+		})
+	}
+	if len(finders) == 0 {
+		finders = append(finders, func() (map[int]internal.Vehicle, error) { return sv.FindAll(ctx) })
+	}
+
+	for i, finder := range finders {
+		found, e := finder()
+		if e != nil && !IsNotFound(e) {
+			err = e
+			return
+		}
+		if i == 0 {
+			v = found
+			continue
+		}
+		v = intersect(v, found)
+	}
+	if v == nil {
+		v = make(map[int]internal.Vehicle)
+	}
+	// the service has no finder by capacity, tags nor custom attributes
+	tags := internal.NormalizeTags(f.Tags)
+	for key, value := range v {
+		if (f.PassengersMin != nil && value.Capacity < *f.PassengersMin) || !value.HasTags(tags) || !matchCustom(value, f.Custom) {
+			delete(v, key)
+		}
+	}
+	return
+}
+
+// matchCustom is a function that reports whether a vehicle has every one of the values of the
+// custom attributes
+func matchCustom(vh internal.Vehicle, custom map[string]string) bool {
+	for name, s := range custom {
+		if !internal.MatchAttribute(vh.Custom[name], s) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNotFound is a function that reports whether err means that nothing matched
+func IsNotFound(err error) bool {
+	return errors.Is(err, apperrors.ErrVehicleNotFound) ||
+		errors.Is(err, apperrors.ErrVehicleWithCriteria) ||
+		errors.Is(err, apperrors.ErrVehicleBrand)
+}
+
+// findVehicle is a function that returns the vehicle of id, ErrVehicleNotFound when the
+// repository returns the zero vehicle of a missing one
+func findVehicle(ctx context.Context, rp internal.VehicleRepository, id int) (vh internal.Vehicle, err error) {
+	vh, err = rp.FindById(ctx, strconv.Itoa(id))
+	if err != nil {
+		return
+	}
+	if vh.Id == 0 {
+		err = fmt.Errorf("%w: %d", apperrors.ErrVehicleNotFound, id)
+	}
+	return
+}
+
+// intersect is a function that returns the vehicles present in both a and b
+func intersect(a, b map[int]internal.Vehicle) map[int]internal.Vehicle {
+	v := make(map[int]internal.Vehicle)
+	for key, value := range a {
+		if _, ok := b[key]; ok {
+			v[key] = value
+		}
+	}
+	return v
+}
+
+// rangeParam is a function that formats r as the min-max parameter of the finders,
+// nil is any value and a negative bound, that the format cannot express, is ""
+func rangeParam(r *Range) string {
+	if r == nil {
+		return formatFloat(0) + "-" + formatFloat(math.MaxFloat64)
+	}
+	if r.Min < 0 || r.Max < 0 {
+		return ""
+	}
+	return formatFloat(r.Min) + "-" + formatFloat(r.Max)
+}
+
+// formatFloat is a function that formats f as a parameter of the finders
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/geo"
+	"context"
+	"time"
+)
+
+// NearbyQuery is a struct that represents a search of the vehicles around a point
+type NearbyQuery struct {
+	// Center is the point searched around
+	Center geo.Point
+	// Radius is the greatest distance to the center, in km
+	Radius float64
+	// Limit is the highest number of vehicles returned
+	Limit int
+	// Filter are the criteria the vehicles match
+	Filter VehicleFilter
+	// AvailableUntil, when not zero, keeps the vehicles without a reservation from now until it
+	AvailableUntil time.Time
+}
+
+// FindNearby is a function that returns up to q.Limit vehicles of sv matching the filter of q, the
+// nearest to its center first by the latest position of ts, and, with q.AvailableUntil, free of the
+// reservations of rs until then
+func FindNearby(ctx context.Context, sv internal.VehicleService, ts internal.TelemetryService, rs internal.ReservationService, q NearbyQuery) (v []internal.NearbyVehicle, err error) {
+	samples, err := ts.Nearby(ctx, q.Center, q.Radius)
+	if err != nil {
+		return
+	}
+	v = []internal.NearbyVehicle{}
+	if len(samples) == 0 {
+		return
+	}
+
+	var vehicles map[int]internal.Vehicle
+	if q.AvailableUntil.IsZero() {
+		vehicles, err = FindByFilter(ctx, sv, q.Filter)
+	} else {
+		vehicles, err = FindAvailable(ctx, sv, rs, q.Filter, time.Now(), q.AvailableUntil)
+	}
+	if err != nil {
+		return
+	}
+
+	for _, value := range samples {
+		if len(v) == q.Limit {
+			break
+		}
+		if vh, ok := vehicles[value.Sample.VehicleId]; ok {
+			v = append(v, internal.NearbyVehicle{Vehicle: vh, Sample: value.Sample, Distance: value.Distance})
+		}
+	}
+	return
+}
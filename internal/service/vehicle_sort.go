@@ -0,0 +1,135 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CustomPrefix is the prefix of the custom attributes in the names of the sort fields and of the
+// query filters, custom.cost_center
+const CustomPrefix = "custom."
+
+// vehicleSortFields are the values of the fields the vehicles are sorted by, by name as the JSON fields
+var vehicleSortFields = map[string]func(vh internal.Vehicle) any{
+	"id":           func(vh internal.Vehicle) any { return float64(vh.Id) },
+	"brand":        func(vh internal.Vehicle) any { return vh.Brand },
+	"model":        func(vh internal.Vehicle) any { return vh.Model },
+	"registration": func(vh internal.Vehicle) any { return vh.Registration },
+	"color":        func(vh internal.Vehicle) any { return vh.Color },
+	"year":         func(vh internal.Vehicle) any { return float64(vh.FabricationYear) },
+	"passengers":   func(vh internal.Vehicle) any { return float64(vh.Capacity) },
+	"max_speed":    func(vh internal.Vehicle) any { return vh.MaxSpeed },
+	"fuel_type":    func(vh internal.Vehicle) any { return vh.FuelType },
+	"transmission": func(vh internal.Vehicle) any { return vh.Transmission },
+	"weight":       func(vh internal.Vehicle) any { return vh.Weight },
+	"height":       func(vh internal.Vehicle) any { return vh.Height },
+	"length":       func(vh internal.Vehicle) any { return vh.Length },
+	"width":        func(vh internal.Vehicle) any { return vh.Width },
+}
+
+// VehicleSortFields are the names of the fields the vehicles can be sorted by, but for the custom attributes
+var VehicleSortFields = []string{
+	"id", "brand", "model", "registration", "color", "year", "passengers", "max_speed",
+	"fuel_type", "transmission", "weight", "height", "length", "width",
+}
+
+// VehicleSort is a struct that represents the order of a list of vehicles
+type VehicleSort struct {
+	// Field is the name of the field, one of VehicleSortFields or a custom attribute after CustomPrefix
+	Field string
+	// Descending is whether the greatest values come first
+	Descending bool
+}
+
+// ParseVehicleSort is a function that returns the order written as field, -field for the descending
+// order, the id when s is empty
+func ParseVehicleSort(s string) (o VehicleSort, err error) {
+	o.Field, o.Descending = strings.CutPrefix(s, "-")
+	if o.Field == "" && !o.Descending {
+		o.Field = "id"
+	}
+	if name, ok := strings.CutPrefix(o.Field, CustomPrefix); ok {
+		if !internal.AttributeName.MatchString(name) {
+			err = fmt.Errorf("%w: sort: %q is not a valid attribute name", apperrors.ErrInvalidVehicleData, name)
+		}
+		return
+	}
+	if _, ok := vehicleSortFields[o.Field]; !ok {
+		err = fmt.Errorf("%w: sort must be one of %s or a custom attribute as %sname, - first for the descending order",
+			apperrors.ErrInvalidVehicleData, strings.Join(VehicleSortFields, ", "), CustomPrefix)
+	}
+	return
+}
+
+// SortVehicles is a function that returns the vehicles in the order, the ties by id. The vehicles
+// without a value of a custom attribute come last in either direction, and the text is compared
+// without case.
+func SortVehicles(v map[int]internal.Vehicle, o VehicleSort) (s []internal.Vehicle) {
+	value := vehicleSortFields[o.Field]
+	if name, ok := strings.CutPrefix(o.Field, CustomPrefix); ok {
+		value = func(vh internal.Vehicle) any { return vh.Custom[name] }
+	}
+
+	s = make([]internal.Vehicle, 0, len(v))
+	for _, vh := range v {
+		s = append(s, vh)
+	}
+	slices.SortFunc(s, func(a, b internal.Vehicle) int {
+		va, vb := value(a), value(b)
+		switch {
+		case va == nil && vb == nil:
+		case va == nil:
+			return 1
+		case vb == nil:
+			return -1
+		default:
+			c := compareValues(va, vb)
+			if o.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return
+}
+
+// compareValues is a function that compares two values of a field, the booleans before the numbers
+// before the strings when they are of different types
+func compareValues(a, b any) int {
+	rank := func(v any) int {
+		switch v.(type) {
+		case bool:
+			return 0
+		case float64:
+			return 1
+		default:
+			return 2
+		}
+	}
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 {
+		return c
+	}
+	switch a := a.(type) {
+	case bool:
+		b := b.(bool)
+		switch {
+		case a == b:
+			return 0
+		case !a:
+			return -1
+		default:
+			return 1
+		}
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	default:
+		return cmp.Compare(strings.ToLower(internal.FormatAttribute(a)), strings.ToLower(internal.FormatAttribute(b)))
+	}
+}
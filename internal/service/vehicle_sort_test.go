@@ -0,0 +1,64 @@
+package service
+
+import (
+	"app/internal"
+	"testing"
+)
+
+// TestSortVehicles is a function that checks the order of the vehicles by a field or a custom
+// attribute, the ties by id and the vehicles without the custom attribute last either way
+func TestSortVehicles(t *testing.T) {
+	v := map[int]internal.Vehicle{
+		1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "fiat", MaxSpeed: 150, Custom: map[string]any{"region": "South", "insured_value": 90.0}}},
+		2: {Id: 2, VehicleAttributes: internal.VehicleAttributes{Brand: "Audi", MaxSpeed: 220}},
+		3: {Id: 3, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", MaxSpeed: 150, Custom: map[string]any{"region": "north", "insured_value": 100.0}}},
+		4: {Id: 4, VehicleAttributes: internal.VehicleAttributes{Brand: "BMW", MaxSpeed: 250, Custom: map[string]any{"insured_value": 80.0}}},
+	}
+
+	cases := map[string]struct {
+		sort string
+		want []int
+	}{
+		"default":                  {sort: "", want: []int{1, 2, 3, 4}},
+		"descending id":            {sort: "-id", want: []int{4, 3, 2, 1}},
+		"brand without case":       {sort: "brand", want: []int{2, 4, 1, 3}},
+		"number with ties":         {sort: "max_speed", want: []int{1, 3, 2, 4}},
+		"number descending":        {sort: "-max_speed", want: []int{4, 2, 1, 3}},
+		"custom text":              {sort: "custom.region", want: []int{3, 1, 2, 4}},
+		"custom text descending":   {sort: "-custom.region", want: []int{1, 3, 2, 4}},
+		"custom number":            {sort: "custom.insured_value", want: []int{4, 1, 3, 2}},
+		"custom number descending": {sort: "-custom.insured_value", want: []int{3, 1, 4, 2}},
+		"custom nobody has":        {sort: "custom.armored", want: []int{1, 2, 3, 4}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := ParseVehicleSort(c.sort)
+			if err != nil {
+				t.Fatalf("parse %q: %v", c.sort, err)
+			}
+
+			s := SortVehicles(v, o)
+
+			got := make([]int, len(s))
+			for i, vh := range s {
+				got[i] = vh.Id
+			}
+			for i := range got {
+				if len(got) != len(c.want) || got[i] != c.want[i] {
+					t.Fatalf("got %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
+
+// TestParseVehicleSort is a function that checks that the sort by an unknown field or by an
+// invalid attribute name is rejected
+func TestParseVehicleSort(t *testing.T) {
+	for _, s := range []string{"-", "speed", "custom.", "custom.Region", "custom.1st"} {
+		if _, err := ParseVehicleSort(s); err == nil {
+			t.Errorf("got no error for %q, want one", s)
+		}
+	}
+}
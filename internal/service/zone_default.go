@@ -0,0 +1,135 @@
+package service
+
+import (
+	"app/internal"
+	"app/pkg/apperrors"
+	"app/pkg/geo"
+	"app/pkg/logger"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+// NewZoneDefault is a function that returns a new instance of ZoneDefault
+func NewZoneDefault(rp internal.ZoneRepository, events internal.ZoneEventRepository) *ZoneDefault {
+	return &ZoneDefault{rp: rp, events: events}
+}
+
+// ZoneDefault is a struct that represents the default service for the geofencing of the vehicles
+type ZoneDefault struct {
+	// rp is the repository of the zones
+	rp internal.ZoneRepository
+	// events is the log of the vehicles entering and leaving the zones
+	events internal.ZoneEventRepository
+}
+
+// FindAll is a method that returns the zones by id
+func (s *ZoneDefault) FindAll(ctx context.Context) (z []internal.Zone, err error) {
+	z, err = s.rp.FindAll(ctx)
+	return
+}
+
+// FindById is a method that returns a zone
+func (s *ZoneDefault) FindById(ctx context.Context, id int) (z internal.Zone, err error) {
+	z, err = s.rp.FindById(ctx, id)
+	return
+}
+
+// Save is a method that adds a zone
+func (s *ZoneDefault) Save(ctx context.Context, z *internal.Zone) (v internal.Zone, err error) {
+	if err = z.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidZoneData, err.Error())
+		return
+	}
+
+	v, err = s.rp.Save(ctx, z)
+	return
+}
+
+// Update is a method that replaces a zone, the vehicles inside it evaluated again at their next sample
+func (s *ZoneDefault) Update(ctx context.Context, z *internal.Zone) (v internal.Zone, err error) {
+	if err = z.Validate(); err != nil {
+		err = fmt.Errorf("%w: %s", apperrors.ErrInvalidZoneData, err.Error())
+		return
+	}
+
+	v, err = s.rp.Update(ctx, z)
+	return
+}
+
+// Delete is a method that removes a zone, the vehicles inside it forgotten without exit events
+func (s *ZoneDefault) Delete(ctx context.Context, id int) (err error) {
+	if err = s.rp.Delete(ctx, id); err != nil {
+		return
+	}
+
+	err = s.events.Forget(ctx, id)
+	return
+}
+
+// Evaluate is a method that checks the zones of the positions of samples in time order,
+// returning the events of the vehicles entering and leaving them. The entries of the
+// restricted zones are logged as warnings.
+func (s *ZoneDefault) Evaluate(ctx context.Context, samples []internal.TelemetrySample) (e []internal.ZoneEvent, err error) {
+	e = []internal.ZoneEvent{}
+	if len(samples) == 0 {
+		return
+	}
+	zones, err := s.rp.FindAll(ctx)
+	if err != nil {
+		return
+	}
+
+	// the events of a vehicle follow its samples, only the ones after its latest taken
+	sorted := append([]internal.TelemetrySample(nil), samples...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+	for _, value := range sorted {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		pt := geo.Point{Longitude: value.Longitude, Latitude: value.Latitude}
+		var ids []int
+		for _, z := range zones {
+			if z.Area.Contains(pt) {
+				ids = append(ids, z.Id)
+			}
+		}
+
+		var moved []internal.ZoneEvent
+		if moved, err = s.events.Move(ctx, value, ids); err != nil {
+			return
+		}
+		e = append(e, moved...)
+	}
+
+	for _, ev := range e {
+		i := sort.Search(len(zones), func(i int) bool { return zones[i].Id >= ev.ZoneId })
+		if ev.Type != internal.ZoneEnter || i == len(zones) || zones[i].Kind != internal.ZoneRestricted {
+			continue
+		}
+		logger.FromContext(ctx).Warn("service: vehicle entered a restricted zone",
+			slog.Int("vehicle_id", ev.VehicleId),
+			slog.Int("zone_id", ev.ZoneId),
+			slog.String("zone", zones[i].Name),
+			slog.Time("at", ev.Time),
+		)
+	}
+	return
+}
+
+// Events is a method that returns the zone events matching the filter, the most recent first
+func (s *ZoneDefault) Events(ctx context.Context, f internal.ZoneEventFilter) (e []internal.ZoneEvent, err error) {
+	e, err = s.events.FindEvents(ctx, f)
+	return
+}
+
+// Occupants is a method that returns the vehicles inside a zone now
+func (s *ZoneDefault) Occupants(ctx context.Context, zoneId int) (o []internal.ZoneOccupant, err error) {
+	if _, err = s.rp.FindById(ctx, zoneId); err != nil {
+		return
+	}
+
+	o, err = s.events.Occupants(ctx, zoneId)
+	return
+}
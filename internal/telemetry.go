@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// TelemetrySample is a struct that represents a reading of the GPS tracker of a vehicle
+type TelemetrySample struct {
+	// VehicleId is the identifier of the vehicle tracked
+	VehicleId int
+	// Time is when the reading was taken
+	Time time.Time
+	// Latitude and Longitude are the position of the vehicle, in decimal degrees
+	Latitude  float64
+	Longitude float64
+	// Speed is the speed of the vehicle, in km/h
+	Speed float64
+}
+
+// Validate is a method that validates the position and the speed of a sample
+func (s *TelemetrySample) Validate() error {
+	if s.Time.IsZero() {
+		return errors.New("time is required")
+	}
+	if s.Latitude < -90 || s.Latitude > 90 {
+		return errors.New("latitude must be from -90 to 90")
+	}
+	if s.Longitude < -180 || s.Longitude > 180 {
+		return errors.New("longitude must be from -180 to 180")
+	}
+	if s.Speed < 0 {
+		return errors.New("speed must not be negative")
+	}
+	return nil
+}
+
+// SpeedAlert is a struct that represents a reading above the max speed of its vehicle
+type SpeedAlert struct {
+	// Sample is the reading
+	Sample TelemetrySample
+	// MaxSpeed is the max speed of the vehicle at the reading
+	MaxSpeed float64
+}
+
+// SpeedAlertFilter is a struct that represents the criteria to find speed alerts, the zero value
+// of each field matching any alert
+type SpeedAlertFilter struct {
+	// VehicleId is the vehicle of the alerts
+	VehicleId int
+	// From is the time the alerts are at or after
+	From time.Time
+}
+
+// IngestRejection is a struct that represents a sample of a batch not ingested
+type IngestRejection struct {
+	// Index is the position of the sample in the batch, from 0
+	Index int
+	// Reason is why the sample was rejected
+	Reason string
+}
+
+// IngestResult is a struct that represents the outcome of the ingestion of a batch of samples
+type IngestResult struct {
+	// Accepted is the number of samples stored
+	Accepted int
+	// Rejected are the samples not stored, by index
+	Rejected []IngestRejection
+	// Alerts are the samples stored above the max speed of their vehicle
+	Alerts []SpeedAlert
+}
+
+// NearbySample is a struct that represents the latest sample of a vehicle near a point
+type NearbySample struct {
+	// Sample is the latest sample of the vehicle
+	Sample TelemetrySample
+	// Distance is the distance of the sample to the point, in km
+	Distance float64
+}
+
+// NearbyVehicle is a struct that represents a vehicle near a point, as of its latest sample
+type NearbyVehicle struct {
+	// Vehicle is the vehicle
+	Vehicle Vehicle
+	// Sample is the latest sample of the vehicle
+	Sample TelemetrySample
+	// Distance is the distance of the sample to the point, in km
+	Distance float64
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"app/pkg/geo"
+	"context"
+	"time"
+)
+
+// TelemetryRepository is an interface that represents a time series store of the telemetry of the
+// vehicles, the samples and alerts older than its retention dropped
+type TelemetryRepository interface {
+	// Append is a method that stores samples, a sample at the time of another of its vehicle replacing it
+	Append(ctx context.Context, s []TelemetrySample) (err error)
+	// Latest is a method that returns the most recent sample of a vehicle, ErrTelemetryNotFound when there is none
+	Latest(ctx context.Context, vehicleId int) (s TelemetrySample, err error)
+	// Track is a method that returns the samples of a vehicle from from until to, both included, the oldest first
+	Track(ctx context.Context, vehicleId int, from, to time.Time) (s []TelemetrySample, err error)
+	// Nearby is a method that returns the latest samples of the vehicles at most radius km from center,
+	// the nearest first
+	Nearby(ctx context.Context, center geo.Point, radius float64) (n []NearbySample, err error)
+	// AppendAlerts is a method that stores speed alerts
+	AppendAlerts(ctx context.Context, a []SpeedAlert) (err error)
+	// FindAlerts is a method that returns the speed alerts matching the filter, the most recent first
+	FindAlerts(ctx context.Context, f SpeedAlertFilter) (a []SpeedAlert, err error)
+}
@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"app/pkg/geo"
+	"context"
+	"time"
+)
+
+// TelemetryService is an interface that represents the service of the telemetry of the vehicles
+type TelemetryService interface {
+	// Ingest is a method that stores a batch of samples, the invalid ones rejected, raising an alert
+	// for each reading above the max speed of its vehicle
+	Ingest(ctx context.Context, s []TelemetrySample) (r IngestResult, err error)
+	// Latest is a method that returns the most recent sample of a vehicle
+	Latest(ctx context.Context, vehicleId int) (s TelemetrySample, err error)
+	// Track is a method that returns the samples of a vehicle from from until to, the oldest first
+	Track(ctx context.Context, vehicleId int, from, to time.Time) (s []TelemetrySample, err error)
+	// Nearby is a method that returns the latest samples of the vehicles at most radius km from center,
+	// the nearest first
+	Nearby(ctx context.Context, center geo.Point, radius float64) (n []NearbySample, err error)
+	// Alerts is a method that returns the speed alerts matching the filter, the most recent first
+	Alerts(ctx context.Context, f SpeedAlertFilter) (a []SpeedAlert, err error)
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor is a function that returns an interceptor starting a server span
+// per unary call, continuing the W3C trace-context of the inbound metadata
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx, span := startRPC(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err = handler(ctx, req)
+		endRPC(span, err)
+		return
+	}
+}
+
+// StreamServerInterceptor is a function that returns an interceptor starting a server span
+// per streaming call, continuing the W3C trace-context of the inbound metadata
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, span := startRPC(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		err = handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		endRPC(span, err)
+		return
+	}
+}
+
+// startRPC is a function that starts the server span of the call to method
+func startRPC(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			attribute.String("rpc.method", method),
+			attribute.String("request.id", middleware.GetReqID(ctx)),
+		),
+	)
+}
+
+// endRPC is a function that records the status code of the call on span
+func endRPC(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	if err != nil {
+		span.SetStatus(codes.Error, st.Message())
+	}
+}
+
+// metadataCarrier is a type that adapts the gRPC metadata to a propagation.TextMapCarrier
+type metadataCarrier metadata.MD
+
+// Get is a method that returns the first value of key
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set is a method that sets the value of key
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys is a method that returns the keys of the metadata
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// serverStream is a struct that replaces the context of a grpc.ServerStream
+type serverStream struct {
+	grpc.ServerStream
+	// ctx is the context of the stream
+	ctx context.Context
+}
+
+// Context is a method that returns the context of the stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ExporterNone disables the export of spans
+	ExporterNone = ""
+	// ExporterStdout writes the spans to stdout, for local testing
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends the spans to an OTLP/HTTP endpoint
+	ExporterOTLP = "otlp"
+)
+
+// instrumentation is the name of the tracer used by the application
+const instrumentation = "app"
+
+// Config is a struct that represents the configuration of the tracer provider
+type Config struct {
+	// ServiceName is the service.name resource attribute
+	ServiceName string
+	// Exporter is the exporter of the spans (ExporterNone, ExporterStdout or ExporterOTLP)
+	Exporter string
+	// Endpoint is the host:port of the OTLP/HTTP collector, defaults to localhost:4318
+	Endpoint string
+	// Insecure disables TLS for the OTLP exporter
+	Insecure bool
+}
+
+// NewTracerProvider is a function that builds the tracer provider and registers it,
+// with the W3C trace-context propagator, as the global one
+func NewTracerProvider(ctx context.Context, cfg Config) (tp *sdktrace.TracerProvider, err error) {
+	var exp sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case ExporterNone:
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exp, err = otlptracehttp.New(ctx, opts...)
+	default:
+		err = fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	if exp != nil {
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+	tp = sdktrace.NewTracerProvider(opts...)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return
+}
+
+// tracer is a function that returns the tracer of the application from the global provider
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// end is a function that records err, if any, on the span and ends it
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,198 @@
+package tracing
+
+import (
+	"app/internal"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewVehicleRepository is a function that returns a repository decorator creating a span per method
+func NewVehicleRepository(rp internal.VehicleRepository) *VehicleRepository {
+	return &VehicleRepository{rp: rp}
+}
+
+// VehicleRepository is a struct that decorates a VehicleRepository with tracing
+type VehicleRepository struct {
+	// rp is the decorated repository
+	rp internal.VehicleRepository
+}
+
+// FindAll is a method that traces the repository FindAll
+func (t *VehicleRepository) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindAll")
+	defer func() { end(span, err) }()
+	return t.rp.FindAll(ctx)
+}
+
+// Save is a method that traces the repository Save
+func (t *VehicleRepository) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.Save")
+	defer func() { end(span, err) }()
+	return t.rp.Save(ctx, vh)
+}
+
+// SaveBatch is a method that traces the repository SaveBatch
+func (t *VehicleRepository) SaveBatch(ctx context.Context, vh []internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.SaveBatch", trace.WithAttributes(
+		attribute.Int("vehicles.count", len(vh)),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.SaveBatch(ctx, vh)
+}
+
+// FindByColorAndYears is a method that traces the repository FindByColorAndYears
+func (t *VehicleRepository) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByColorAndYears", trace.WithAttributes(
+		attribute.String("vehicle.color", color),
+		attribute.String("vehicle.year", year),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindByColorAndYears(ctx, color, year)
+}
+
+// FindByMarcaAndYearInterval is a method that traces the repository FindByMarcaAndYearInterval
+func (t *VehicleRepository) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByMarcaAndYearInterval", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+		attribute.String("vehicle.start_year", start_year),
+		attribute.String("vehicle.end_year", end_year),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindByMarcaAndYearInterval(ctx, brand, start_year, end_year)
+}
+
+// FindVelocidadeMediaMarca is a method that traces the repository FindVelocidadeMediaMarca
+func (t *VehicleRepository) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindVelocidadeMediaMarca", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindVelocidadeMediaMarca(ctx, brand)
+}
+
+// FindByTransmissionType is a method that traces the repository FindByTransmissionType
+func (t *VehicleRepository) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByTransmissionType", trace.WithAttributes(
+		attribute.String("vehicle.type_transmission", typeTransmission),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindByTransmissionType(ctx, typeTransmission)
+}
+
+// FindByDimenssion is a method that traces the repository FindByDimenssion
+func (t *VehicleRepository) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByDimenssion", trace.WithAttributes(
+		attribute.String("vehicle.length_param", lengthParam),
+		attribute.String("vehicle.width_param", widthParam),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int("vehicles.matched", len(v)))
+		end(span, err)
+	}()
+	v, err = t.rp.FindByDimenssion(ctx, lengthParam, widthParam)
+	return
+}
+
+// FindByPeso is a method that traces the repository FindByPeso
+func (t *VehicleRepository) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByPeso", trace.WithAttributes(
+		attribute.String("vehicle.min", min),
+		attribute.String("vehicle.max", max),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindByPeso(ctx, min, max)
+}
+
+// FindMediaPessoaPorMarca is a method that traces the repository FindMediaPessoaPorMarca
+func (t *VehicleRepository) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindMediaPessoaPorMarca", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindMediaPessoaPorMarca(ctx, brand)
+}
+
+// FindTipoCombustivel is a method that traces the repository FindTipoCombustivel
+func (t *VehicleRepository) FindTipoCombustivel(ctx context.Context, typeFuel string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindTipoCombustivel", trace.WithAttributes(
+		attribute.String("vehicle.type_fuel", typeFuel),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindTipoCombustivel(ctx, typeFuel)
+}
+
+// FindById is a method that traces the repository FindById
+func (t *VehicleRepository) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindById", trace.WithAttributes(
+		attribute.String("vehicle.id", id),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindById(ctx, id)
+}
+
+// Patch is a method that traces the repository Patch
+func (t *VehicleRepository) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.Patch")
+	defer func() { end(span, err) }()
+	return t.rp.Patch(ctx, vh)
+}
+
+// UpdateMaxSpeed is a method that traces the repository UpdateMaxSpeed
+func (t *VehicleRepository) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.UpdateMaxSpeed", trace.WithAttributes(
+		attribute.Int("vehicle.id", id),
+		attribute.Float64("vehicle.max_speed", maxSpeed),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.UpdateMaxSpeed(ctx, id, maxSpeed)
+}
+
+// UpdateFuel is a method that traces the repository UpdateFuel
+func (t *VehicleRepository) UpdateFuel(ctx context.Context, id int, fuelType string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.UpdateFuel", trace.WithAttributes(
+		attribute.Int("vehicle.id", id),
+		attribute.String("vehicle.fuel_type", fuelType),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.UpdateFuel(ctx, id, fuelType)
+}
+
+// DeleteById is a method that traces the repository DeleteById
+func (t *VehicleRepository) DeleteById(ctx context.Context, id string) (err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.DeleteById", trace.WithAttributes(
+		attribute.String("vehicle.id", id),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.DeleteById(ctx, id)
+}
+
+// Search is a method that traces the repository Search
+func (t *VehicleRepository) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.Search", trace.WithAttributes(
+		attribute.String("vehicle.query", query),
+		attribute.Int("vehicle.limit", limit),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.Search(ctx, query, limit)
+}
+
+// FindByRegistration is a method that traces the repository FindByRegistration
+func (t *VehicleRepository) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.FindByRegistration", trace.WithAttributes(
+		attribute.String("vehicle.registration", registration),
+	))
+	defer func() { end(span, err) }()
+	return t.rp.FindByRegistration(ctx, registration)
+}
+
+// Canonicalize is a method that traces the repository Canonicalize
+func (t *VehicleRepository) Canonicalize(ctx context.Context, ct internal.BrandCatalog) (n int, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleRepository.Canonicalize")
+	defer func() {
+		span.SetAttributes(attribute.Int("vehicles.changed", n))
+		end(span, err)
+	}()
+	return t.rp.Canonicalize(ctx, ct)
+}
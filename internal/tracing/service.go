@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"app/internal"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewVehicleService is a function that returns a service decorator creating a span per method
+func NewVehicleService(sv internal.VehicleService) *VehicleService {
+	return &VehicleService{sv: sv}
+}
+
+// VehicleService is a struct that decorates a VehicleService with tracing
+type VehicleService struct {
+	// sv is the decorated service
+	sv internal.VehicleService
+}
+
+// FindAll is a method that traces the service FindAll
+func (t *VehicleService) FindAll(ctx context.Context) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindAll")
+	defer func() { end(span, err) }()
+	return t.sv.FindAll(ctx)
+}
+
+// Save is a method that traces the service Save
+func (t *VehicleService) Save(ctx context.Context, vh *internal.VehicleAttributes) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.Save")
+	defer func() { end(span, err) }()
+	return t.sv.Save(ctx, vh)
+}
+
+// FindByColorAndYears is a method that traces the service FindByColorAndYears
+func (t *VehicleService) FindByColorAndYears(ctx context.Context, color, year string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByColorAndYears", trace.WithAttributes(
+		attribute.String("vehicle.color", color),
+		attribute.String("vehicle.year", year),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByColorAndYears(ctx, color, year)
+}
+
+// FindById is a method that traces the service FindById
+func (t *VehicleService) FindById(ctx context.Context, id string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindById", trace.WithAttributes(
+		attribute.String("vehicle.id", id),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindById(ctx, id)
+}
+
+// FindByMarcaAndYearInterval is a method that traces the service FindByMarcaAndYearInterval
+func (t *VehicleService) FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByMarcaAndYearInterval", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+		attribute.String("vehicle.start_year", start_year),
+		attribute.String("vehicle.end_year", end_year),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByMarcaAndYearInterval(ctx, brand, start_year, end_year)
+}
+
+// FindTipoCombustivel is a method that traces the service FindTipoCombustivel
+func (t *VehicleService) FindTipoCombustivel(ctx context.Context, typeFuel string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindTipoCombustivel", trace.WithAttributes(
+		attribute.String("vehicle.type_fuel", typeFuel),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindTipoCombustivel(ctx, typeFuel)
+}
+
+// FindByTransmissionType is a method that traces the service FindByTransmissionType
+func (t *VehicleService) FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByTransmissionType", trace.WithAttributes(
+		attribute.String("vehicle.type_transmission", typeTransmission),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByTransmissionType(ctx, typeTransmission)
+}
+
+// FindMediaPessoaPorMarca is a method that traces the service FindMediaPessoaPorMarca
+func (t *VehicleService) FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindMediaPessoaPorMarca", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindMediaPessoaPorMarca(ctx, brand)
+}
+
+// FindByDimenssion is a method that traces the service FindByDimenssion
+func (t *VehicleService) FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByDimenssion", trace.WithAttributes(
+		attribute.String("vehicle.length_param", lengthParam),
+		attribute.String("vehicle.width_param", widthParam),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByDimenssion(ctx, lengthParam, widthParam)
+}
+
+// FindByPeso is a method that traces the service FindByPeso
+func (t *VehicleService) FindByPeso(ctx context.Context, min, max string) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByPeso", trace.WithAttributes(
+		attribute.String("vehicle.min", min),
+		attribute.String("vehicle.max", max),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByPeso(ctx, min, max)
+}
+
+// FindVelocidadeMediaMarca is a method that traces the service FindVelocidadeMediaMarca
+func (t *VehicleService) FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindVelocidadeMediaMarca", trace.WithAttributes(
+		attribute.String("vehicle.brand", brand),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindVelocidadeMediaMarca(ctx, brand)
+}
+
+// SaveMultipleVehicles is a method that traces the service SaveMultipleVehicles
+func (t *VehicleService) SaveMultipleVehicles(ctx context.Context, vh *[]internal.VehicleAttributes) (v map[int]internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.SaveMultipleVehicles")
+	defer func() { end(span, err) }()
+	return t.sv.SaveMultipleVehicles(ctx, vh)
+}
+
+// Patch is a method that traces the service Patch
+func (t *VehicleService) Patch(ctx context.Context, vh *internal.Vehicle) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.Patch")
+	defer func() { end(span, err) }()
+	return t.sv.Patch(ctx, vh)
+}
+
+// UpdateMaxSpeed is a method that traces the service UpdateMaxSpeed
+func (t *VehicleService) UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.UpdateMaxSpeed", trace.WithAttributes(
+		attribute.Int("vehicle.id", id),
+		attribute.Float64("vehicle.max_speed", maxSpeed),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.UpdateMaxSpeed(ctx, id, maxSpeed)
+}
+
+// UpdateFuel is a method that traces the service UpdateFuel
+func (t *VehicleService) UpdateFuel(ctx context.Context, id int, fuelType string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.UpdateFuel", trace.WithAttributes(
+		attribute.Int("vehicle.id", id),
+		attribute.String("vehicle.fuel_type", fuelType),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.UpdateFuel(ctx, id, fuelType)
+}
+
+// DeleteById is a method that traces the service DeleteById
+func (t *VehicleService) DeleteById(ctx context.Context, id string) (err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.DeleteById", trace.WithAttributes(
+		attribute.String("vehicle.id", id),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.DeleteById(ctx, id)
+}
+
+// Search is a method that traces the service Search
+func (t *VehicleService) Search(ctx context.Context, query string, limit int) (v []internal.VehicleMatch, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.Search", trace.WithAttributes(
+		attribute.String("vehicle.query", query),
+		attribute.Int("vehicle.limit", limit),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.Search(ctx, query, limit)
+}
+
+// FindByRegistration is a method that traces the service FindByRegistration
+func (t *VehicleService) FindByRegistration(ctx context.Context, registration string) (v internal.Vehicle, err error) {
+	ctx, span := tracer().Start(ctx, "VehicleService.FindByRegistration", trace.WithAttributes(
+		attribute.String("vehicle.registration", registration),
+	))
+	defer func() { end(span, err) }()
+	return t.sv.FindByRegistration(ctx, registration)
+}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"errors"
+	"time"
+)
+
+// Trip is a struct that represents a use of a vehicle, from Start until End, with the readings
+// of its odometer at both
+type Trip struct {
+	// Id is the unique identifier of the trip
+	Id int
+	// VehicleId is the identifier of the vehicle used
+	VehicleId int
+	// DriverId is the identifier of the driver, 0 when unknown
+	DriverId int
+	// Purpose is why the vehicle was used
+	Purpose string
+	// Start and End are the interval of the trip
+	Start time.Time
+	End   time.Time
+	// StartOdometer and EndOdometer are the readings of the odometer, in km
+	StartOdometer float64
+	EndOdometer   float64
+}
+
+// TripFilter is a struct that represents the criteria to find trips, the zero value of each
+// field matching any trip
+type TripFilter struct {
+	// VehicleId is the vehicle used
+	VehicleId int
+	// From and To is an interval the trips overlap
+	From time.Time
+	To   time.Time
+}
+
+// Validate is a method that validates a trip to be recorded
+func (t *Trip) Validate() error {
+	if t.Start.IsZero() || t.End.IsZero() {
+		return errors.New("start and end are required")
+	}
+	if !t.End.After(t.Start) {
+		return errors.New("end must be after start")
+	}
+	if t.StartOdometer < 0 {
+		return errors.New("start_odometer must not be negative")
+	}
+	if t.EndOdometer < t.StartOdometer {
+		return errors.New("end_odometer must not be lower than start_odometer")
+	}
+	return nil
+}
+
+// Distance is a method that returns the km driven
+func (t *Trip) Distance() float64 {
+	return t.EndOdometer - t.StartOdometer
+}
+
+// Overlaps is a method that reports whether the trip intersects the interval from start to end
+func (t *Trip) Overlaps(start, end time.Time) bool {
+	return t.Start.Before(end) && start.Before(t.End)
+}
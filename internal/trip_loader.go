@@ -0,0 +1,7 @@
+package internal
+
+// TripLoader is an interface that represents the loader for the trips of the vehicles
+type TripLoader interface {
+	// Load is a method that loads the trips
+	Load() (t []Trip, err error)
+}
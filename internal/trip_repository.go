@@ -0,0 +1,15 @@
+package internal
+
+import "context"
+
+// TripRepository is an interface that represents a repository of the trips of the vehicles
+type TripRepository interface {
+	// FindAll is a method that returns the trips matching the filter, by start
+	FindAll(ctx context.Context, f TripFilter) (t []Trip, err error)
+	// Save is a method that records a trip, assigning its id, ErrTripConflict when it overlaps
+	// another of its vehicle and ErrOdometerDecreased when its readings are lower than the ones
+	// of an earlier trip of the vehicle or higher than the ones of a later trip
+	Save(ctx context.Context, t *Trip) (v Trip, err error)
+	// Delete is a method that removes a trip of a vehicle, ErrTripNotFound when there is none
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+}
@@ -0,0 +1,21 @@
+package internal
+
+import (
+	"context"
+	"time"
+)
+
+// TripService is an interface that represents the service of the trips and the utilization of the vehicles
+type TripService interface {
+	// FindAll is a method that returns the trips matching the filter, by start
+	FindAll(ctx context.Context, f TripFilter) (t []Trip, err error)
+	// Record is a method that records a trip of a vehicle
+	Record(ctx context.Context, t *Trip) (v Trip, err error)
+	// Delete is a method that removes a trip of a vehicle
+	Delete(ctx context.Context, vehicleId, id int) (err error)
+	// Utilization is a method that returns the use of the fleet from the day from to the day to
+	Utilization(ctx context.Context, from, to time.Time) (r UtilizationReport, err error)
+	// Underused is a method that returns the vehicles below the criteria from the day from to the
+	// day to, the least used first
+	Underused(ctx context.Context, from, to time.Time, c UnderuseCriteria) (u []UnderusedVehicle, err error)
+}
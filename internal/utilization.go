@@ -0,0 +1,88 @@
+package internal
+
+import "time"
+
+// reasons a vehicle is underused
+const (
+	// UnderusedDistance is a vehicle driven less than the minimum distance of the period
+	UnderusedDistance = "low_distance"
+	// UnderusedUsage is a vehicle used on fewer days than the minimum usage of the period
+	UnderusedUsage = "low_usage"
+)
+
+// Utilization is a struct that represents the use of a vehicle over a period
+type Utilization struct {
+	// Vehicle is the vehicle used
+	Vehicle Vehicle
+	// Trips is the number of trips overlapping the period
+	Trips int
+	// Distance is the km driven during the period, the trips partly in it prorated by time
+	Distance float64
+	// Hours is the time in use during the period
+	Hours float64
+	// ActiveDays are the days of the period with a trip, IdleDays the other ones
+	ActiveDays int
+	IdleDays   int
+}
+
+// Usage is a method that returns the share of the days of the period with a trip, from 0 to 1
+func (u Utilization) Usage() float64 {
+	days := u.ActiveDays + u.IdleDays
+	if days == 0 {
+		return 0
+	}
+	return float64(u.ActiveDays) / float64(days)
+}
+
+// BrandUtilization is a struct that represents the use of the vehicles of a brand over a period,
+// the sums of their utilizations
+type BrandUtilization struct {
+	// Brand is the brand of the vehicles
+	Brand string
+	// Vehicles is the number of vehicles of the brand
+	Vehicles int
+	// Trips, Distance, Hours, ActiveDays and IdleDays are the sums of the vehicles
+	Trips      int
+	Distance   float64
+	Hours      float64
+	ActiveDays int
+	IdleDays   int
+}
+
+// Usage is a method that returns the share of the vehicle days of the period with a trip, from 0 to 1
+func (b BrandUtilization) Usage() float64 {
+	days := b.ActiveDays + b.IdleDays
+	if days == 0 {
+		return 0
+	}
+	return float64(b.ActiveDays) / float64(days)
+}
+
+// UtilizationReport is a struct that represents the use of the fleet over a period of days
+type UtilizationReport struct {
+	// From and To are the first and the last day of the period, both included
+	From time.Time
+	To   time.Time
+	// Days is the number of days of the period
+	Days int
+	// Vehicles are the utilizations of the vehicles, by id
+	Vehicles []Utilization
+	// Brands are the utilizations of the brands, by name
+	Brands []BrandUtilization
+}
+
+// UnderuseCriteria is a struct that represents the thresholds below which a vehicle is underused
+// over a period, a zero threshold ignored
+type UnderuseCriteria struct {
+	// MinDistance is the lowest km driven
+	MinDistance float64
+	// MinUsage is the lowest share of the days with a trip, from 0 to 1
+	MinUsage float64
+}
+
+// UnderusedVehicle is a struct that represents a vehicle underused over a period, a candidate for sale
+type UnderusedVehicle struct {
+	Utilization
+	// Reasons are UnderusedDistance and UnderusedUsage, the thresholds the vehicle is below
+	Reasons []string
+}
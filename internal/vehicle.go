@@ -1,6 +1,18 @@
 package internal
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MaxTags is the largest number of tags of a vehicle
+const MaxTags = 20
+
+// tagPattern is the format of the tags, lower case without spaces
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]{0,49}$`)
 
 // Dimensions is a struct that represents a dimension in 3d
 type Dimensions struct {
@@ -36,6 +48,11 @@ type VehicleAttributes struct {
 	Weight float64
 	// Dimensions is the dimensions of the vehicle
 	Dimensions
+	// Tags are the free-form labels of the vehicle, sorted
+	Tags []string
+	// Custom are the values of the custom attributes defined by the tenant, by name,
+	// a string, a float64 or a bool
+	Custom map[string]any
 }
 
 // Vehicle is a struct that represents a vehicle
@@ -47,12 +64,12 @@ type Vehicle struct {
 	VehicleAttributes
 }
 
-type UpdateMaxSpeedRequest struct {
-	MaxSpeed float64 `json:"MaxSpeed"`
-}
-
-type UpdateFuel struct {
-	FuelType string `json:"FuelType"`
+// VehicleMatch is a struct that represents a vehicle found by a search
+type VehicleMatch struct {
+	// Vehicle is the vehicle found
+	Vehicle
+	// Score is the relevance of the vehicle to the query, the higher the better
+	Score float64
 }
 
 func (v *VehicleAttributes) ToDomain() *Vehicle {
@@ -98,5 +115,57 @@ func (v *VehicleAttributes) Validate() error {
 	if v.Width <= 0 {
 		return errors.New("dimensions: width must be greater than zero")
 	}
+	if len(v.Tags) > MaxTags {
+		return fmt.Errorf("tags: at most %d", MaxTags)
+	}
+	for _, tag := range v.Tags {
+		if !tagPattern.MatchString(tag) {
+			return fmt.Errorf("tags: %q must be lower case letters, digits and _ . : - up to 50", tag)
+		}
+	}
+	names := make([]string, 0, len(v.Custom))
+	for name := range v.Custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !AttributeName.MatchString(name) {
+			return fmt.Errorf("custom: %q is not a valid attribute name", name)
+		}
+	}
 	return nil
 }
+
+// HasTags is a method that reports whether the vehicle has every one of tags
+func (v *VehicleAttributes) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		i := sort.SearchStrings(v.Tags, tag)
+		if i == len(v.Tags) || v.Tags[i] != tag {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeTags is a function that returns tags trimmed, in lower case, sorted and without
+// repetitions, nil for none
+func NormalizeTags(tags []string) (t []string) {
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			t = append(t, tag)
+		}
+	}
+	sort.Strings(t)
+	n := 0
+	for i, tag := range t {
+		if i == 0 || tag != t[n-1] {
+			t[n] = tag
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	return t[:n]
+}
@@ -0,0 +1,31 @@
+package internal
+
+// VehicleComparison is a struct that represents vehicles side by side, their attributes
+// aligned per field in the order of the vehicles
+type VehicleComparison struct {
+	// Vehicles are the vehicles compared, in the order requested
+	Vehicles []Vehicle
+	// Fields are the attributes and the derived metrics of the vehicles
+	Fields []ComparedField
+}
+
+// ComparedField is a struct that represents an attribute of the vehicles compared,
+// Text for the textual ones and Numbers for the numeric ones, one value per vehicle
+type ComparedField struct {
+	// Name is the name of the field, as the JSON fields of the REST API
+	Name string
+	// Derived is true for a metric computed from the attributes
+	Derived bool
+	// Text are the values of a textual field
+	Text []string
+	// Numbers are the values of a numeric field, NaN when a metric cannot be computed
+	Numbers []float64
+	// Ranked is true for a numeric field with a best and a worst value
+	Ranked bool
+	// HigherIsBetter is the direction of the ranking of a ranked field
+	HigherIsBetter bool
+	// Best and Worst are the ids of the vehicles with the best and the worst value of a
+	// ranked field, both empty when the values are all equal
+	Best  []int
+	Worst []int
+}
@@ -1,26 +1,35 @@
 package internal
 
+import "context"
+
 // VehicleService is an interface that represents a vehicle service
 type VehicleService interface {
 	// FindAll is a method that returns a map of all vehicles
-	FindAll() (v map[int]Vehicle, err error)
-	Save(vh *VehicleAttributes) (v Vehicle, err error)
-	FindByColorAndYears(color, year string) (v map[int]Vehicle, err error)
-	FindById(id string) (v Vehicle, err error)
-	FindByMarcaAndYearInterval(brand, start_year, end_year string) (v map[int]Vehicle, err error)
-	FindTipoCombustivel(typeFuel string) (v map[int]Vehicle, err error)
-	FindByTransmissionType(typeTransmission string) (v map[int]Vehicle, err error)
-	FindMediaPessoaPorMarca(brand string) (m int, err error)
-	FindByDimenssion(lengthParam, widthParam string) (v map[int]Vehicle, err error)
+	FindAll(ctx context.Context) (v map[int]Vehicle, err error)
+	Save(ctx context.Context, vh *VehicleAttributes) (v Vehicle, err error)
+	FindByColorAndYears(ctx context.Context, color, year string) (v map[int]Vehicle, err error)
+	FindById(ctx context.Context, id string) (v Vehicle, err error)
+	// FindByRegistration is a method that returns the vehicle of a registration, matched without
+	// case and separators, ErrVehicleNotFound when there is none
+	FindByRegistration(ctx context.Context, registration string) (v Vehicle, err error)
+	FindByMarcaAndYearInterval(ctx context.Context, brand, start_year, end_year string) (v map[int]Vehicle, err error)
+	FindTipoCombustivel(ctx context.Context, typeFuel string) (v map[int]Vehicle, err error)
+	FindByTransmissionType(ctx context.Context, typeTransmission string) (v map[int]Vehicle, err error)
+	FindMediaPessoaPorMarca(ctx context.Context, brand string) (m int, err error)
+	FindByDimenssion(ctx context.Context, lengthParam, widthParam string) (v map[int]Vehicle, err error)
+
+	FindByPeso(ctx context.Context, min, max string) (v map[int]Vehicle, err error)
 
-	FindByPeso(min, max string) (v map[int]Vehicle, err error)
+	FindVelocidadeMediaMarca(ctx context.Context, brand string) (m float64, err error)
+	SaveMultipleVehicles(ctx context.Context, vh *[]VehicleAttributes) (v map[int]Vehicle, err error)
 
-	FindVelocidadeMediaMarca(brand string) (m float64, err error)
-	SaveMultipleVehicles(vh *[]VehicleAttributes) (v map[int]Vehicle, err error)
+	Patch(ctx context.Context, vh *Vehicle) (v Vehicle, err error)
+	UpdateMaxSpeed(ctx context.Context, id int, maxSpeed float64) (v Vehicle, err error)
+	UpdateFuel(ctx context.Context, id int, fuelType string) (v Vehicle, err error)
 
-	Patch(vh *Vehicle) (v Vehicle, err error)
-	UpdateMaxSpeed(id int, maxSpeed float64) (v Vehicle, err error)
-	UpdateFuel(id int, fuelType string) (v Vehicle, err error)
+	DeleteById(ctx context.Context, id string) (err error)
 
-	DeleteById(id string) (err error)
+	// Search is a method that returns the vehicles whose brand, model, color or registration
+	// match the words of query, the most relevant first, at most limit of them
+	Search(ctx context.Context, query string, limit int) (v []VehicleMatch, err error)
 }
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"app/pkg/geo"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// kinds of zone
+const (
+	// ZoneDepot is a zone where the vehicles are parked and serviced
+	ZoneDepot = "depot"
+	// ZoneClientSite is a zone of a client visited by the vehicles
+	ZoneClientSite = "client_site"
+	// ZoneRestricted is a zone the vehicles should not enter
+	ZoneRestricted = "restricted"
+)
+
+// ZoneKinds are the kinds of zone
+var ZoneKinds = []string{ZoneDepot, ZoneClientSite, ZoneRestricted}
+
+// types of zone event
+const (
+	// ZoneEnter is the event of a vehicle entering a zone
+	ZoneEnter = "enter"
+	// ZoneExit is the event of a vehicle leaving a zone
+	ZoneExit = "exit"
+)
+
+// Zone is a struct that represents an area watched for the vehicles entering and leaving it
+type Zone struct {
+	// Id is the unique identifier of the zone
+	Id int
+	// Name is the name of the zone, unique in the fleet
+	Name string
+	// Kind is the kind of the zone, one of ZoneKinds
+	Kind string
+	// Area is the boundary of the zone
+	Area geo.Polygon
+}
+
+// ZoneEvent is a struct that represents a vehicle entering or leaving a zone
+type ZoneEvent struct {
+	// Id is the unique identifier of the event
+	Id int
+	// ZoneId is the identifier of the zone
+	ZoneId int
+	// VehicleId is the identifier of the vehicle
+	VehicleId int
+	// Type is ZoneEnter or ZoneExit
+	Type string
+	// Time is the time of the first sample of the vehicle inside, or outside, the zone
+	Time time.Time
+	// Position is the position of the vehicle at that sample
+	Position geo.Point
+}
+
+// ZoneEventFilter is a struct that represents the criteria to find zone events, the zero value
+// of each field matching any event
+type ZoneEventFilter struct {
+	ZoneId    int
+	VehicleId int
+	// From is the time the events are at or after
+	From time.Time
+}
+
+// ZoneOccupant is a struct that represents a vehicle inside a zone
+type ZoneOccupant struct {
+	// VehicleId is the identifier of the vehicle
+	VehicleId int
+	// Since is the time the vehicle entered the zone
+	Since time.Time
+	// LastSeen is the time of the latest sample of the vehicle
+	LastSeen time.Time
+	// Position is the position of the vehicle at that sample
+	Position geo.Point
+}
+
+// Validate is a method that validates a zone to be written
+func (z *Zone) Validate() error {
+	if strings.TrimSpace(z.Name) == "" {
+		return errors.New("name is required")
+	}
+	switch z.Kind {
+	case ZoneDepot, ZoneClientSite, ZoneRestricted:
+	default:
+		return fmt.Errorf("kind must be one of %s", strings.Join(ZoneKinds, ", "))
+	}
+	if err := z.Area.Validate(); err != nil {
+		return fmt.Errorf("area: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package internal
+
+// ZoneLoader is an interface that represents the loader for the zones of the geofencing
+type ZoneLoader interface {
+	// Load is a method that loads the zones
+	Load() (z []Zone, err error)
+}
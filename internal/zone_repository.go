@@ -0,0 +1,33 @@
+package internal
+
+import "context"
+
+// ZoneRepository is an interface that represents a repository of the zones of the geofencing
+type ZoneRepository interface {
+	// FindAll is a method that returns the zones by id
+	FindAll(ctx context.Context) (z []Zone, err error)
+	// FindById is a method that returns a zone, ErrZoneNotFound when there is none
+	FindById(ctx context.Context, id int) (z Zone, err error)
+	// Save is a method that adds a zone, assigning its id, ErrZoneAlreadyExists when its name is taken
+	Save(ctx context.Context, z *Zone) (v Zone, err error)
+	// Update is a method that replaces a zone, ErrZoneNotFound when there is none and
+	// ErrZoneAlreadyExists when its name is taken by another zone
+	Update(ctx context.Context, z *Zone) (v Zone, err error)
+	// Delete is a method that removes a zone, ErrZoneNotFound when there is none
+	Delete(ctx context.Context, id int) (err error)
+}
+
+// ZoneEventRepository is an interface that represents the log of the vehicles entering and leaving
+// the zones and the vehicles inside each zone, the events older than its retention dropped
+type ZoneEventRepository interface {
+	// Move is a method that places a vehicle inside the zones of ids at a sample, logging an event for
+	// each zone it entered or left since its previous sample. A sample not after the previous one of
+	// its vehicle is ignored.
+	Move(ctx context.Context, s TelemetrySample, ids []int) (e []ZoneEvent, err error)
+	// FindEvents is a method that returns the events matching the filter, the most recent first
+	FindEvents(ctx context.Context, f ZoneEventFilter) (e []ZoneEvent, err error)
+	// Occupants is a method that returns the vehicles inside a zone, by vehicle id
+	Occupants(ctx context.Context, zoneId int) (o []ZoneOccupant, err error)
+	// Forget is a method that removes the vehicles from a zone deleted, without logging events
+	Forget(ctx context.Context, zoneId int) (err error)
+}
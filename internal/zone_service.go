@@ -0,0 +1,24 @@
+package internal
+
+import "context"
+
+// ZoneService is an interface that represents the service of the geofencing of the vehicles
+type ZoneService interface {
+	// FindAll is a method that returns the zones by id
+	FindAll(ctx context.Context) (z []Zone, err error)
+	// FindById is a method that returns a zone
+	FindById(ctx context.Context, id int) (z Zone, err error)
+	// Save is a method that adds a zone
+	Save(ctx context.Context, z *Zone) (v Zone, err error)
+	// Update is a method that replaces a zone, the vehicles inside it evaluated again at their next sample
+	Update(ctx context.Context, z *Zone) (v Zone, err error)
+	// Delete is a method that removes a zone, its events kept
+	Delete(ctx context.Context, id int) (err error)
+	// Evaluate is a method that checks the zones of the positions of samples, returning the events
+	// of the vehicles entering and leaving them
+	Evaluate(ctx context.Context, s []TelemetrySample) (e []ZoneEvent, err error)
+	// Events is a method that returns the zone events matching the filter, the most recent first
+	Events(ctx context.Context, f ZoneEventFilter) (e []ZoneEvent, err error)
+	// Occupants is a method that returns the vehicles inside a zone now
+	Occupants(ctx context.Context, zoneId int) (o []ZoneOccupant, err error)
+}
@@ -8,4 +8,49 @@ var (
 	ErrVehicleAlreadyExists = errors.New("vehicle identifier already exists")
 	ErrInvalidVehicleData   = errors.New("required or invalid vehicle data")
 	ErrVehicleNotFound      = errors.New("vehicle not Found")
+	ErrBrandNotFound        = errors.New("brand not found in the catalog")
+	ErrBrandAliasNotFound   = errors.New("alias not found in the catalog")
+	ErrBrandAliasConflict   = errors.New("alias already names another brand")
+
+	ErrMaintenanceEventNotFound = errors.New("maintenance event not found")
+	ErrMaintenancePlanNotFound  = errors.New("maintenance plan not found")
+	ErrInvalidMaintenanceData   = errors.New("required or invalid maintenance data")
+
+	ErrDriverNotFound      = errors.New("driver not found")
+	ErrDriverAlreadyExists = errors.New("driver license number already exists")
+	ErrInvalidDriverData   = errors.New("required or invalid driver data")
+	ErrAssignmentNotFound  = errors.New("assignment not found")
+	ErrAssignmentConflict  = errors.New("assignment overlaps another of the vehicle or of the driver")
+	ErrLicenseNotAllowed   = errors.New("driver license does not allow the vehicle")
+
+	ErrReservationNotFound    = errors.New("reservation not found")
+	ErrReservationConflict    = errors.New("reservation overlaps another of the vehicle")
+	ErrReservationState       = errors.New("reservation not in a status allowing the operation")
+	ErrInvalidReservationData = errors.New("required or invalid reservation data")
+
+	ErrTripNotFound      = errors.New("trip not found")
+	ErrTripConflict      = errors.New("trip overlaps another of the vehicle")
+	ErrOdometerDecreased = errors.New("odometer of the vehicle would decrease")
+	ErrInvalidTripData   = errors.New("required or invalid trip data")
+
+	ErrFuelTransactionNotFound = errors.New("fuel transaction not found")
+	ErrFuelTypeMismatch        = errors.New("fuel type does not match the vehicle")
+	ErrInvalidFuelData         = errors.New("required or invalid fuel data")
+
+	ErrTelemetryNotFound    = errors.New("no telemetry for the vehicle")
+	ErrInvalidTelemetryData = errors.New("required or invalid telemetry data")
+
+	ErrZoneNotFound      = errors.New("zone not found")
+	ErrZoneAlreadyExists = errors.New("zone name already exists")
+	ErrInvalidZoneData   = errors.New("required or invalid zone data")
+
+	ErrDocumentNotFound       = errors.New("document not found")
+	ErrInvalidDocumentData    = errors.New("required or invalid document data")
+	ErrUnsupportedContentType = errors.New("content type of the document not accepted")
+	ErrDocumentTooLarge       = errors.New("document file too large")
+	ErrBlobNotFound           = errors.New("blob not found")
+
+	ErrAttributeNotFound      = errors.New("custom attribute not found")
+	ErrAttributeAlreadyExists = errors.New("custom attribute name already exists")
+	ErrInvalidAttributeData   = errors.New("required or invalid custom attribute data")
 )
@@ -0,0 +1,796 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: fleet/v1/fleet.proto
+
+package fleetpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FleetService_ListVehicles_FullMethodName               = "/fleet.v1.FleetService/ListVehicles"
+	FleetService_ExportVehicles_FullMethodName             = "/fleet.v1.FleetService/ExportVehicles"
+	FleetService_GetVehicle_FullMethodName                 = "/fleet.v1.FleetService/GetVehicle"
+	FleetService_CreateVehicle_FullMethodName              = "/fleet.v1.FleetService/CreateVehicle"
+	FleetService_CreateVehicles_FullMethodName             = "/fleet.v1.FleetService/CreateVehicles"
+	FleetService_PatchVehicle_FullMethodName               = "/fleet.v1.FleetService/PatchVehicle"
+	FleetService_UpdateMaxSpeed_FullMethodName             = "/fleet.v1.FleetService/UpdateMaxSpeed"
+	FleetService_UpdateFuel_FullMethodName                 = "/fleet.v1.FleetService/UpdateFuel"
+	FleetService_DeleteVehicle_FullMethodName              = "/fleet.v1.FleetService/DeleteVehicle"
+	FleetService_FindByColorAndYear_FullMethodName         = "/fleet.v1.FleetService/FindByColorAndYear"
+	FleetService_FindByBrandAndYearInterval_FullMethodName = "/fleet.v1.FleetService/FindByBrandAndYearInterval"
+	FleetService_FindByFuelType_FullMethodName             = "/fleet.v1.FleetService/FindByFuelType"
+	FleetService_FindByTransmission_FullMethodName         = "/fleet.v1.FleetService/FindByTransmission"
+	FleetService_FindByDimensions_FullMethodName           = "/fleet.v1.FleetService/FindByDimensions"
+	FleetService_FindByWeight_FullMethodName               = "/fleet.v1.FleetService/FindByWeight"
+	FleetService_GetBrandAverageSpeed_FullMethodName       = "/fleet.v1.FleetService/GetBrandAverageSpeed"
+	FleetService_GetBrandAverageCapacity_FullMethodName    = "/fleet.v1.FleetService/GetBrandAverageCapacity"
+)
+
+// FleetServiceClient is the client API for FleetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FleetService exposes the operations of the vehicle service.
+// The tenant is read from the "x-tenant-id" metadata and the request id from
+// "x-request-id", as the X-Tenant-ID and X-Request-Id headers of the REST API.
+type FleetServiceClient interface {
+	// ListVehicles streams every vehicle, ordered by id.
+	ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// ExportVehicles streams every vehicle encoded in the requested format, in chunks.
+	ExportVehicles(ctx context.Context, in *ExportVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	// GetVehicle returns a vehicle, NOT_FOUND when it does not exist.
+	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// CreateVehicle creates a vehicle, ALREADY_EXISTS when the registration is taken.
+	CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// CreateVehicles creates many vehicles, stopping at the first failure.
+	CreateVehicles(ctx context.Context, in *CreateVehiclesRequest, opts ...grpc.CallOption) (*CreateVehiclesResponse, error)
+	// PatchVehicle updates the attributes present in the request.
+	PatchVehicle(ctx context.Context, in *PatchVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// UpdateMaxSpeed updates the maximum speed of a vehicle.
+	UpdateMaxSpeed(ctx context.Context, in *UpdateMaxSpeedRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// UpdateFuel updates the fuel type of a vehicle.
+	UpdateFuel(ctx context.Context, in *UpdateFuelRequest, opts ...grpc.CallOption) (*Vehicle, error)
+	// DeleteVehicle deletes a vehicle.
+	DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*DeleteVehicleResponse, error)
+	// FindByColorAndYear streams the vehicles of a color fabricated in a year.
+	FindByColorAndYear(ctx context.Context, in *FindByColorAndYearRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// FindByBrandAndYearInterval streams the vehicles of a brand fabricated between two years.
+	FindByBrandAndYearInterval(ctx context.Context, in *FindByBrandAndYearIntervalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// FindByFuelType streams the vehicles of a fuel type.
+	FindByFuelType(ctx context.Context, in *FindByFuelTypeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// FindByTransmission streams the vehicles of a transmission.
+	FindByTransmission(ctx context.Context, in *FindByTransmissionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// FindByDimensions streams the vehicles within a length and a width range.
+	FindByDimensions(ctx context.Context, in *FindByDimensionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// FindByWeight streams the vehicles within a weight range.
+	FindByWeight(ctx context.Context, in *FindByWeightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error)
+	// GetBrandAverageSpeed returns the average maximum speed of the vehicles of a brand.
+	GetBrandAverageSpeed(ctx context.Context, in *GetBrandAverageSpeedRequest, opts ...grpc.CallOption) (*GetBrandAverageSpeedResponse, error)
+	// GetBrandAverageCapacity returns the average capacity of people of the vehicles of a brand.
+	GetBrandAverageCapacity(ctx context.Context, in *GetBrandAverageCapacityRequest, opts ...grpc.CallOption) (*GetBrandAverageCapacityResponse, error)
+}
+
+type fleetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFleetServiceClient(cc grpc.ClientConnInterface) FleetServiceClient {
+	return &fleetServiceClient{cc}
+}
+
+func (c *fleetServiceClient) ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[0], FleetService_ListVehicles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListVehiclesRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_ListVehiclesClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) ExportVehicles(ctx context.Context, in *ExportVehiclesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[1], FleetService_ExportVehicles_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportVehiclesRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_ExportVehiclesClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *fleetServiceClient) GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, FleetService_GetVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, FleetService_CreateVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) CreateVehicles(ctx context.Context, in *CreateVehiclesRequest, opts ...grpc.CallOption) (*CreateVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateVehiclesResponse)
+	err := c.cc.Invoke(ctx, FleetService_CreateVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) PatchVehicle(ctx context.Context, in *PatchVehicleRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, FleetService_PatchVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) UpdateMaxSpeed(ctx context.Context, in *UpdateMaxSpeedRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, FleetService_UpdateMaxSpeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) UpdateFuel(ctx context.Context, in *UpdateFuelRequest, opts ...grpc.CallOption) (*Vehicle, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Vehicle)
+	err := c.cc.Invoke(ctx, FleetService_UpdateFuel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) DeleteVehicle(ctx context.Context, in *DeleteVehicleRequest, opts ...grpc.CallOption) (*DeleteVehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteVehicleResponse)
+	err := c.cc.Invoke(ctx, FleetService_DeleteVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) FindByColorAndYear(ctx context.Context, in *FindByColorAndYearRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[2], FleetService_FindByColorAndYear_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByColorAndYearRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByColorAndYearClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) FindByBrandAndYearInterval(ctx context.Context, in *FindByBrandAndYearIntervalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[3], FleetService_FindByBrandAndYearInterval_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByBrandAndYearIntervalRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByBrandAndYearIntervalClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) FindByFuelType(ctx context.Context, in *FindByFuelTypeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[4], FleetService_FindByFuelType_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByFuelTypeRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByFuelTypeClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) FindByTransmission(ctx context.Context, in *FindByTransmissionRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[5], FleetService_FindByTransmission_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByTransmissionRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByTransmissionClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) FindByDimensions(ctx context.Context, in *FindByDimensionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[6], FleetService_FindByDimensions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByDimensionsRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByDimensionsClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) FindByWeight(ctx context.Context, in *FindByWeightRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Vehicle], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FleetService_ServiceDesc.Streams[7], FleetService_FindByWeight_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindByWeightRequest, Vehicle]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByWeightClient = grpc.ServerStreamingClient[Vehicle]
+
+func (c *fleetServiceClient) GetBrandAverageSpeed(ctx context.Context, in *GetBrandAverageSpeedRequest, opts ...grpc.CallOption) (*GetBrandAverageSpeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBrandAverageSpeedResponse)
+	err := c.cc.Invoke(ctx, FleetService_GetBrandAverageSpeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) GetBrandAverageCapacity(ctx context.Context, in *GetBrandAverageCapacityRequest, opts ...grpc.CallOption) (*GetBrandAverageCapacityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBrandAverageCapacityResponse)
+	err := c.cc.Invoke(ctx, FleetService_GetBrandAverageCapacity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FleetServiceServer is the server API for FleetService service.
+// All implementations must embed UnimplementedFleetServiceServer
+// for forward compatibility.
+//
+// FleetService exposes the operations of the vehicle service.
+// The tenant is read from the "x-tenant-id" metadata and the request id from
+// "x-request-id", as the X-Tenant-ID and X-Request-Id headers of the REST API.
+type FleetServiceServer interface {
+	// ListVehicles streams every vehicle, ordered by id.
+	ListVehicles(*ListVehiclesRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// ExportVehicles streams every vehicle encoded in the requested format, in chunks.
+	ExportVehicles(*ExportVehiclesRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	// GetVehicle returns a vehicle, NOT_FOUND when it does not exist.
+	GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error)
+	// CreateVehicle creates a vehicle, ALREADY_EXISTS when the registration is taken.
+	CreateVehicle(context.Context, *CreateVehicleRequest) (*Vehicle, error)
+	// CreateVehicles creates many vehicles, stopping at the first failure.
+	CreateVehicles(context.Context, *CreateVehiclesRequest) (*CreateVehiclesResponse, error)
+	// PatchVehicle updates the attributes present in the request.
+	PatchVehicle(context.Context, *PatchVehicleRequest) (*Vehicle, error)
+	// UpdateMaxSpeed updates the maximum speed of a vehicle.
+	UpdateMaxSpeed(context.Context, *UpdateMaxSpeedRequest) (*Vehicle, error)
+	// UpdateFuel updates the fuel type of a vehicle.
+	UpdateFuel(context.Context, *UpdateFuelRequest) (*Vehicle, error)
+	// DeleteVehicle deletes a vehicle.
+	DeleteVehicle(context.Context, *DeleteVehicleRequest) (*DeleteVehicleResponse, error)
+	// FindByColorAndYear streams the vehicles of a color fabricated in a year.
+	FindByColorAndYear(*FindByColorAndYearRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// FindByBrandAndYearInterval streams the vehicles of a brand fabricated between two years.
+	FindByBrandAndYearInterval(*FindByBrandAndYearIntervalRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// FindByFuelType streams the vehicles of a fuel type.
+	FindByFuelType(*FindByFuelTypeRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// FindByTransmission streams the vehicles of a transmission.
+	FindByTransmission(*FindByTransmissionRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// FindByDimensions streams the vehicles within a length and a width range.
+	FindByDimensions(*FindByDimensionsRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// FindByWeight streams the vehicles within a weight range.
+	FindByWeight(*FindByWeightRequest, grpc.ServerStreamingServer[Vehicle]) error
+	// GetBrandAverageSpeed returns the average maximum speed of the vehicles of a brand.
+	GetBrandAverageSpeed(context.Context, *GetBrandAverageSpeedRequest) (*GetBrandAverageSpeedResponse, error)
+	// GetBrandAverageCapacity returns the average capacity of people of the vehicles of a brand.
+	GetBrandAverageCapacity(context.Context, *GetBrandAverageCapacityRequest) (*GetBrandAverageCapacityResponse, error)
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+// UnimplementedFleetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFleetServiceServer struct{}
+
+func (UnimplementedFleetServiceServer) ListVehicles(*ListVehiclesRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method ListVehicles not implemented")
+}
+func (UnimplementedFleetServiceServer) ExportVehicles(*ExportVehiclesRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportVehicles not implemented")
+}
+func (UnimplementedFleetServiceServer) GetVehicle(context.Context, *GetVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
+}
+func (UnimplementedFleetServiceServer) CreateVehicle(context.Context, *CreateVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicle not implemented")
+}
+func (UnimplementedFleetServiceServer) CreateVehicles(context.Context, *CreateVehiclesRequest) (*CreateVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicles not implemented")
+}
+func (UnimplementedFleetServiceServer) PatchVehicle(context.Context, *PatchVehicleRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PatchVehicle not implemented")
+}
+func (UnimplementedFleetServiceServer) UpdateMaxSpeed(context.Context, *UpdateMaxSpeedRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMaxSpeed not implemented")
+}
+func (UnimplementedFleetServiceServer) UpdateFuel(context.Context, *UpdateFuelRequest) (*Vehicle, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFuel not implemented")
+}
+func (UnimplementedFleetServiceServer) DeleteVehicle(context.Context, *DeleteVehicleRequest) (*DeleteVehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVehicle not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByColorAndYear(*FindByColorAndYearRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByColorAndYear not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByBrandAndYearInterval(*FindByBrandAndYearIntervalRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByBrandAndYearInterval not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByFuelType(*FindByFuelTypeRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByFuelType not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByTransmission(*FindByTransmissionRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByTransmission not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByDimensions(*FindByDimensionsRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByDimensions not implemented")
+}
+func (UnimplementedFleetServiceServer) FindByWeight(*FindByWeightRequest, grpc.ServerStreamingServer[Vehicle]) error {
+	return status.Errorf(codes.Unimplemented, "method FindByWeight not implemented")
+}
+func (UnimplementedFleetServiceServer) GetBrandAverageSpeed(context.Context, *GetBrandAverageSpeedRequest) (*GetBrandAverageSpeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrandAverageSpeed not implemented")
+}
+func (UnimplementedFleetServiceServer) GetBrandAverageCapacity(context.Context, *GetBrandAverageCapacityRequest) (*GetBrandAverageCapacityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBrandAverageCapacity not implemented")
+}
+func (UnimplementedFleetServiceServer) mustEmbedUnimplementedFleetServiceServer() {}
+func (UnimplementedFleetServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeFleetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FleetServiceServer will
+// result in compilation errors.
+type UnsafeFleetServiceServer interface {
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+func RegisterFleetServiceServer(s grpc.ServiceRegistrar, srv FleetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFleetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FleetService_ServiceDesc, srv)
+}
+
+func _FleetService_ListVehicles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListVehiclesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).ListVehicles(m, &grpc.GenericServerStream[ListVehiclesRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_ListVehiclesServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_ExportVehicles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportVehiclesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).ExportVehicles(m, &grpc.GenericServerStream[ExportVehiclesRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_ExportVehiclesServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _FleetService_GetVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).GetVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_GetVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).GetVehicle(ctx, req.(*GetVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CreateVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CreateVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CreateVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CreateVehicle(ctx, req.(*CreateVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_CreateVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).CreateVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_CreateVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).CreateVehicles(ctx, req.(*CreateVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_PatchVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).PatchVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_PatchVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).PatchVehicle(ctx, req.(*PatchVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_UpdateMaxSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMaxSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).UpdateMaxSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_UpdateMaxSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).UpdateMaxSpeed(ctx, req.(*UpdateMaxSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_UpdateFuel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFuelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).UpdateFuel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_UpdateFuel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).UpdateFuel(ctx, req.(*UpdateFuelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_DeleteVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).DeleteVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_DeleteVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).DeleteVehicle(ctx, req.(*DeleteVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_FindByColorAndYear_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByColorAndYearRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByColorAndYear(m, &grpc.GenericServerStream[FindByColorAndYearRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByColorAndYearServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_FindByBrandAndYearInterval_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByBrandAndYearIntervalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByBrandAndYearInterval(m, &grpc.GenericServerStream[FindByBrandAndYearIntervalRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByBrandAndYearIntervalServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_FindByFuelType_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByFuelTypeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByFuelType(m, &grpc.GenericServerStream[FindByFuelTypeRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByFuelTypeServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_FindByTransmission_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByTransmissionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByTransmission(m, &grpc.GenericServerStream[FindByTransmissionRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByTransmissionServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_FindByDimensions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByDimensionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByDimensions(m, &grpc.GenericServerStream[FindByDimensionsRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByDimensionsServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_FindByWeight_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindByWeightRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FleetServiceServer).FindByWeight(m, &grpc.GenericServerStream[FindByWeightRequest, Vehicle]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FleetService_FindByWeightServer = grpc.ServerStreamingServer[Vehicle]
+
+func _FleetService_GetBrandAverageSpeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrandAverageSpeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).GetBrandAverageSpeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_GetBrandAverageSpeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).GetBrandAverageSpeed(ctx, req.(*GetBrandAverageSpeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_GetBrandAverageCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrandAverageCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).GetBrandAverageCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_GetBrandAverageCapacity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).GetBrandAverageCapacity(ctx, req.(*GetBrandAverageCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FleetService_ServiceDesc is the grpc.ServiceDesc for FleetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FleetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fleet.v1.FleetService",
+	HandlerType: (*FleetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVehicle",
+			Handler:    _FleetService_GetVehicle_Handler,
+		},
+		{
+			MethodName: "CreateVehicle",
+			Handler:    _FleetService_CreateVehicle_Handler,
+		},
+		{
+			MethodName: "CreateVehicles",
+			Handler:    _FleetService_CreateVehicles_Handler,
+		},
+		{
+			MethodName: "PatchVehicle",
+			Handler:    _FleetService_PatchVehicle_Handler,
+		},
+		{
+			MethodName: "UpdateMaxSpeed",
+			Handler:    _FleetService_UpdateMaxSpeed_Handler,
+		},
+		{
+			MethodName: "UpdateFuel",
+			Handler:    _FleetService_UpdateFuel_Handler,
+		},
+		{
+			MethodName: "DeleteVehicle",
+			Handler:    _FleetService_DeleteVehicle_Handler,
+		},
+		{
+			MethodName: "GetBrandAverageSpeed",
+			Handler:    _FleetService_GetBrandAverageSpeed_Handler,
+		},
+		{
+			MethodName: "GetBrandAverageCapacity",
+			Handler:    _FleetService_GetBrandAverageCapacity_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListVehicles",
+			Handler:       _FleetService_ListVehicles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportVehicles",
+			Handler:       _FleetService_ExportVehicles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByColorAndYear",
+			Handler:       _FleetService_FindByColorAndYear_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByBrandAndYearInterval",
+			Handler:       _FleetService_FindByBrandAndYearInterval_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByFuelType",
+			Handler:       _FleetService_FindByFuelType_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByTransmission",
+			Handler:       _FleetService_FindByTransmission_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByDimensions",
+			Handler:       _FleetService_FindByDimensions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FindByWeight",
+			Handler:       _FleetService_FindByWeight_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "fleet/v1/fleet.proto",
+}
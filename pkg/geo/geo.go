@@ -0,0 +1,116 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// EarthRadius is the mean radius of the Earth, in km
+const EarthRadius = 6371.0088
+
+// Point is a struct that represents a position on the Earth, in decimal degrees
+type Point struct {
+	// Longitude is from -180 to 180, Latitude from -90 to 90
+	Longitude float64
+	Latitude  float64
+}
+
+// Validate is a method that validates the range of the coordinates of a point
+func (p Point) Validate() error {
+	if math.IsNaN(p.Longitude) || p.Longitude < -180 || p.Longitude > 180 {
+		return errors.New("longitude must be from -180 to 180")
+	}
+	if math.IsNaN(p.Latitude) || p.Latitude < -90 || p.Latitude > 90 {
+		return errors.New("latitude must be from -90 to 90")
+	}
+	return nil
+}
+
+// Distance is a function that returns the great-circle distance between two points, in km,
+// by the haversine formula
+func Distance(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat, dLon := lat2-lat1, radians(b.Longitude-a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * EarthRadius * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// radians is a function that converts degrees to radians
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// Ring is a closed line of points, its first and last points equal, as the linear rings of GeoJSON
+type Ring []Point
+
+// Polygon is a list of rings as the polygons of GeoJSON, the outer boundary first and then its holes.
+// The coordinates are taken as planar, fit for areas of a city, not for the ones crossing the
+// antimeridian or around the poles.
+type Polygon []Ring
+
+// Validate is a method that validates a polygon, each of its rings closed, with at least 4 points,
+// the coordinates in range and enclosing an area
+func (p Polygon) Validate() error {
+	if len(p) == 0 {
+		return errors.New("polygon must have an outer ring")
+	}
+	for i, r := range p {
+		if len(r) < 4 {
+			return fmt.Errorf("ring %d must have at least 4 points", i)
+		}
+		for j, pt := range r {
+			if err := pt.Validate(); err != nil {
+				return fmt.Errorf("ring %d point %d: %w", i, j, err)
+			}
+		}
+		if r[0] != r[len(r)-1] {
+			return fmt.Errorf("ring %d must be closed, its last point equal to the first", i)
+		}
+		if r.area() == 0 {
+			return fmt.Errorf("ring %d must enclose an area", i)
+		}
+	}
+	return nil
+}
+
+// Contains is a method that reports whether a point is inside the outer ring of a polygon
+// and outside its holes, a point on a boundary being either
+func (p Polygon) Contains(pt Point) bool {
+	if len(p) == 0 || !p[0].contains(pt) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if hole.contains(pt) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains is a method that reports whether a point is inside a ring by the even-odd rule,
+// counting the edges crossed by a ray from the point to the east
+func (r Ring) contains(pt Point) bool {
+	in := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Latitude > pt.Latitude) == (b.Latitude > pt.Latitude) {
+			continue
+		}
+		// longitude of the edge at the latitude of the point
+		x := a.Longitude + (pt.Latitude-a.Latitude)*(b.Longitude-a.Longitude)/(b.Latitude-a.Latitude)
+		if pt.Longitude < x {
+			in = !in
+		}
+	}
+	return in
+}
+
+// area is a method that returns the planar area of a ring by the shoelace formula, in square degrees
+func (r Ring) area() float64 {
+	var sum float64
+	for i := 0; i < len(r)-1; i++ {
+		sum += r[i].Longitude*r[i+1].Latitude - r[i+1].Longitude*r[i].Latitude
+	}
+	return math.Abs(sum) / 2
+}
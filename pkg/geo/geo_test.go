@@ -0,0 +1,36 @@
+package geo
+
+import "testing"
+
+// TestPolygon_Contains is a function that checks that a point is inside a polygon when it is
+// inside its outer ring and outside its holes
+func TestPolygon_Contains(t *testing.T) {
+	square := func(min, max float64) Ring {
+		return Ring{{min, min}, {max, min}, {max, max}, {min, max}, {min, min}}
+	}
+	outer, hole := square(0, 10), square(4, 6)
+
+	cases := map[string]struct {
+		p    Polygon
+		pt   Point
+		want bool
+	}{
+		"inside":                {p: Polygon{outer}, pt: Point{Longitude: 2, Latitude: 3}, want: true},
+		"outside":               {p: Polygon{outer}, pt: Point{Longitude: 11, Latitude: 5}, want: false},
+		"inside the hole":       {p: Polygon{outer, hole}, pt: Point{Longitude: 5, Latitude: 5}, want: false},
+		"around the hole":       {p: Polygon{outer, hole}, pt: Point{Longitude: 5, Latitude: 8}, want: true},
+		"beside the hole":       {p: Polygon{outer, hole}, pt: Point{Longitude: 3, Latitude: 5}, want: true},
+		"outside with a hole":   {p: Polygon{outer, hole}, pt: Point{Longitude: -1, Latitude: 5}, want: false},
+		"in the second hole":    {p: Polygon{outer, hole, square(7, 9)}, pt: Point{Longitude: 8, Latitude: 8}, want: false},
+		"between the two holes": {p: Polygon{outer, hole, square(7, 9)}, pt: Point{Longitude: 6.5, Latitude: 6.5}, want: true},
+		"no rings":              {p: Polygon{}, pt: Point{Longitude: 5, Latitude: 5}, want: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := c.p.Contains(c.pt); got != c.want {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
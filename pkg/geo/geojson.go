@@ -0,0 +1,48 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Geometry is a struct that represents a GeoJSON geometry of type Polygon, RFC 7946
+type Geometry struct {
+	Type string `json:"type"`
+	// Coordinates are the rings of the polygon, each a list of positions [longitude, latitude],
+	// an optional altitude ignored
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+// Polygon is a method that returns the polygon of a GeoJSON geometry
+func (g Geometry) Polygon() (p Polygon, err error) {
+	if g.Type != "Polygon" {
+		err = errors.New(`geometry type must be "Polygon"`)
+		return
+	}
+	p = make(Polygon, 0, len(g.Coordinates))
+	for i, ring := range g.Coordinates {
+		r := make(Ring, 0, len(ring))
+		for j, position := range ring {
+			if len(position) < 2 || len(position) > 3 {
+				err = fmt.Errorf("ring %d position %d must be [longitude, latitude]", i, j)
+				return
+			}
+			r = append(r, Point{Longitude: position[0], Latitude: position[1]})
+		}
+		p = append(p, r)
+	}
+	return
+}
+
+// PolygonGeometry is a function that returns the GeoJSON geometry of a polygon
+func PolygonGeometry(p Polygon) Geometry {
+	g := Geometry{Type: "Polygon", Coordinates: make([][][]float64, 0, len(p))}
+	for _, r := range p {
+		ring := make([][]float64, 0, len(r))
+		for _, pt := range r {
+			ring = append(ring, []float64{pt.Longitude, pt.Latitude})
+		}
+		g.Coordinates = append(g.Coordinates, ring)
+	}
+	return g
+}
@@ -0,0 +1,138 @@
+package geo
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// NewGrid is a function that returns a new instance of Grid with square cells of cellSize degrees
+func NewGrid(cellSize float64) *Grid {
+	return &Grid{
+		cellSize: cellSize,
+		columns:  int(math.Ceil(360 / cellSize)),
+		cells:    make(map[cell]map[int]Point),
+		points:   make(map[int]Point),
+	}
+}
+
+// Grid is a struct that represents a spatial index of points by id, each in the cell of a grid of
+// latitudes and longitudes so that a search only measures the points of the cells around its center.
+// Safe for concurrent use.
+type Grid struct {
+	// cellSize is the side of the cells, in degrees
+	cellSize float64
+	// columns is the number of cells around a parallel, the longitudes wrapping at the antimeridian
+	columns int
+
+	mu sync.RWMutex
+	// cells are the points by id in each cell
+	cells map[cell]map[int]Point
+	// points are the points by id
+	points map[int]Point
+}
+
+// cell is a struct that represents the column and the row of a cell of a grid
+type cell struct {
+	x, y int
+}
+
+// Neighbor is a struct that represents a point of a grid near the center of a search
+type Neighbor struct {
+	// Id is the identifier of the point
+	Id int
+	// Point is the point
+	Point Point
+	// Distance is the distance to the center, in km
+	Distance float64
+}
+
+// Set is a method that places the point of id, moving it when it is in the grid
+func (g *Grid) Set(id int, p Point) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.remove(id)
+	c := g.cellOf(p)
+	if g.cells[c] == nil {
+		g.cells[c] = make(map[int]Point)
+	}
+	g.cells[c][id] = p
+	g.points[id] = p
+}
+
+// Delete is a method that removes the point of id
+func (g *Grid) Delete(id int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.remove(id)
+}
+
+// Within is a method that returns the points at most radius km from center, the nearest first
+// and then by id
+func (g *Grid) Within(center Point, radius float64) (n []Neighbor) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	n = []Neighbor{}
+	add := func(id int, p Point) {
+		if d := Distance(center, p); d <= radius {
+			n = append(n, Neighbor{Id: id, Point: p, Distance: d})
+		}
+	}
+
+	// the box of cells around the circle, every point when it reaches a pole or wraps the globe
+	dLat := radius / (EarthRadius * math.Pi / 180)
+	south, north := center.Latitude-dLat, center.Latitude+dLat
+	var dLon float64
+	if south > -90 && north < 90 {
+		dLon = dLat / math.Cos(radians(center.Latitude))
+	}
+	if dLon == 0 || 2*dLon >= 360 {
+		for id, p := range g.points {
+			add(id, p)
+		}
+	} else {
+		sw, ne := g.cellOf(Point{Longitude: center.Longitude - dLon, Latitude: south}), g.cellOf(Point{Longitude: center.Longitude + dLon, Latitude: north})
+		columns := (ne.x - sw.x + g.columns) % g.columns
+		for y := sw.y; y <= ne.y; y++ {
+			for i := 0; i <= columns; i++ {
+				for id, p := range g.cells[cell{x: (sw.x + i) % g.columns, y: y}] {
+					add(id, p)
+				}
+			}
+		}
+	}
+
+	sort.Slice(n, func(i, j int) bool {
+		if n[i].Distance != n[j].Distance {
+			return n[i].Distance < n[j].Distance
+		}
+		return n[i].Id < n[j].Id
+	})
+	return
+}
+
+// remove is a method that removes the point of id, the lock held
+func (g *Grid) remove(id int) {
+	p, ok := g.points[id]
+	if !ok {
+		return
+	}
+	c := g.cellOf(p)
+	delete(g.cells[c], id)
+	if len(g.cells[c]) == 0 {
+		delete(g.cells, c)
+	}
+	delete(g.points, id)
+}
+
+// cellOf is a method that returns the cell of a point, the longitudes wrapped from -180
+func (g *Grid) cellOf(p Point) cell {
+	x := int(math.Floor((p.Longitude + 180) / g.cellSize))
+	return cell{
+		x: (x%g.columns + g.columns) % g.columns,
+		y: int(math.Floor((p.Latitude + 90) / g.cellSize)),
+	}
+}
@@ -0,0 +1,50 @@
+package geo
+
+import "testing"
+
+// TestGrid_Within is a function that checks that a search finds the points of the cells around
+// its center, the longitudes wrapping at the antimeridian, the nearest first
+func TestGrid_Within(t *testing.T) {
+	g := NewGrid(0.1)
+	g.Set(1, Point{Longitude: 179.99, Latitude: 0})
+	g.Set(2, Point{Longitude: -179.99, Latitude: 0})
+	g.Set(3, Point{Longitude: 170, Latitude: 0})
+	g.Set(4, Point{Longitude: -46.63, Latitude: -23.55})
+	g.Set(5, Point{Longitude: -46.64, Latitude: -23.56})
+	g.Set(6, Point{Longitude: 0, Latitude: 89.99})
+	g.Set(7, Point{Longitude: 180, Latitude: 89.99})
+
+	cases := map[string]struct {
+		center Point
+		radius float64
+		want   []int
+	}{
+		"east of the antimeridian": {center: Point{Longitude: 179.995, Latitude: 0}, radius: 5, want: []int{1, 2}},
+		"west of the antimeridian": {center: Point{Longitude: -179.999, Latitude: 0}, radius: 5, want: []int{2, 1}},
+		"on the antimeridian":      {center: Point{Longitude: 180, Latitude: 0}, radius: 1, want: []int{}},
+		"in a city":                {center: Point{Longitude: -46.63, Latitude: -23.55}, radius: 2, want: []int{4, 5}},
+		"around the pole":          {center: Point{Longitude: 90, Latitude: 89.99}, radius: 5, want: []int{6, 7}},
+		"nothing near":             {center: Point{Longitude: 100, Latitude: 10}, radius: 50, want: []int{}},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			n := g.Within(c.center, c.radius)
+
+			if len(n) != len(c.want) {
+				t.Fatalf("got %v, want ids %v", n, c.want)
+			}
+			for i := range n {
+				if n[i].Id != c.want[i] {
+					t.Fatalf("got %v, want ids %v", n, c.want)
+				}
+			}
+		})
+	}
+
+	// a point moved leaves its cell
+	g.Set(2, Point{Longitude: 0, Latitude: 0})
+	if n := g.Within(Point{Longitude: 180, Latitude: 0}, 5); len(n) != 1 || n[0].Id != 1 {
+		t.Errorf("after the move got %v, want id 1", n)
+	}
+}
@@ -0,0 +1,124 @@
+// Package ical writes calendars in the iCalendar format of RFC 5545, enough for the
+// calendar applications to subscribe to the events of the API
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// timeLayout is the layout of the UTC date-times
+const timeLayout = "20060102T150405Z"
+
+// lineOctets is the longest line, folded beyond
+const lineOctets = 75
+
+// Calendar is a struct that represents a calendar of events
+type Calendar struct {
+	// ProdID is the identifier of the product that wrote the calendar
+	ProdID string
+	// Name is the name shown by the calendar applications
+	Name string
+	// Events are the events of the calendar
+	Events []Event
+}
+
+// Event is a struct that represents an event of a calendar
+type Event struct {
+	// UID is the globally unique identifier of the event
+	UID string
+	// Start and End are the interval of the event, End excluded
+	Start time.Time
+	End   time.Time
+	// Stamp is when the event was written
+	Stamp time.Time
+	// Summary and Description are the title and the details of the event
+	Summary     string
+	Description string
+	// Status is TENTATIVE, CONFIRMED or CANCELLED, omitted when empty
+	Status string
+}
+
+// event statuses
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// WriteTo is a method that writes the calendar to w
+func (c Calendar) WriteTo(w io.Writer) (n int64, err error) {
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	line := func(name, value string) {
+		fold(bw, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", e.Stamp.UTC().Format(timeLayout))
+		line("DTSTART", e.Start.UTC().Format(timeLayout))
+		line("DTEND", e.End.UTC().Format(timeLayout))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.Status != "" {
+			line("STATUS", e.Status)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	err = bw.Flush()
+	n = cw.n
+	return
+}
+
+// escape is a function that escapes the text values
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(s)
+}
+
+// fold is a function that writes a content line ended by CRLF, the longer lines folded
+// each lineOctets octets, never inside a UTF-8 sequence
+func fold(w *bufio.Writer, s string) {
+	limit := lineOctets
+	for len(s) > limit {
+		i := limit
+		// back to the start of a rune
+		for i > 0 && s[i]&0xC0 == 0x80 {
+			i--
+		}
+		w.WriteString(s[:i])
+		w.WriteString("\r\n ")
+		s = s[i:]
+		// the leading space of the continuation counts
+		limit = lineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
+
+// countWriter is a struct that counts the bytes written to w
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write is a method that writes p to the underlying writer
+func (c *countWriter) Write(p []byte) (n int, err error) {
+	n, err = c.w.Write(p)
+	c.n += int64(n)
+	return
+}
@@ -0,0 +1,91 @@
+package license
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Category is a category of driver's license, a letter of the Brazilian CNH
+type Category rune
+
+// categories of the Brazilian driver's license
+const (
+	// A is the category of the motorcycles
+	A Category = 'A'
+	// B is the category of the cars, up to 8 passengers and 3500 kg
+	B Category = 'B'
+	// C is the category of the cargo vehicles, up to 8 passengers
+	C Category = 'C'
+	// D is the category of the passenger vehicles, more than 8 passengers
+	D Category = 'D'
+	// E is the category of the articulated vehicles, any vehicle
+	E Category = 'E'
+)
+
+// Limits is a struct that represents the largest vehicle a category may drive
+type Limits struct {
+	// Passengers is the highest capacity of people
+	Passengers int
+	// Weight is the highest weight
+	Weight float64
+}
+
+// limits are the largest vehicle of each category
+var limits = map[Category]Limits{
+	A: {Passengers: 2, Weight: 400},
+	B: {Passengers: 8, Weight: 3500},
+	C: {Passengers: 8, Weight: math.Inf(1)},
+	D: {Passengers: math.MaxInt, Weight: math.Inf(1)},
+	E: {Passengers: math.MaxInt, Weight: math.Inf(1)},
+}
+
+// Categories is a set of categories held by a driver, e.g. AB for motorcycles and cars
+type Categories []Category
+
+// Parse is a function that returns the categories of s, a letter per category, in order
+// and without repetitions: "ba", "A/B" and "ab" are AB
+func Parse(s string) (c Categories, err error) {
+	seen := make(map[Category]bool)
+	for _, r := range strings.ToUpper(s) {
+		if r == ' ' || r == '/' || r == ',' {
+			continue
+		}
+		cat := Category(r)
+		if _, ok := limits[cat]; !ok {
+			err = fmt.Errorf("license: unknown category %q", string(r))
+			return
+		}
+		if !seen[cat] {
+			seen[cat] = true
+			c = append(c, cat)
+		}
+	}
+	if len(c) == 0 {
+		err = fmt.Errorf("license: no category in %q", s)
+		return
+	}
+	sort.Slice(c, func(i, j int) bool { return c[i] < c[j] })
+	return
+}
+
+// String is a method that returns the categories as their letters, e.g. AB
+func (c Categories) String() string {
+	var b strings.Builder
+	for _, cat := range c {
+		b.WriteRune(rune(cat))
+	}
+	return b.String()
+}
+
+// Fits is a method that reports whether one of the categories may drive a vehicle of
+// passengers and weight
+func (c Categories) Fits(passengers int, weight float64) bool {
+	for _, cat := range c {
+		if l := limits[cat]; passengers <= l.Passengers && weight <= l.Weight {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package license
+
+import (
+	"math"
+	"testing"
+)
+
+// TestParse is a function that checks the categories parsed from their letters
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		s       string
+		want    string
+		wantErr bool
+	}{
+		"single":               {s: "B", want: "B"},
+		"lower case":           {s: "ab", want: "AB"},
+		"out of order":         {s: "ba", want: "AB"},
+		"separators":           {s: "A/B, D", want: "ABD"},
+		"repeated":             {s: "BBa", want: "AB"},
+		"all":                  {s: "EDCBA", want: "ABCDE"},
+		"unknown category":     {s: "AX", wantErr: true},
+		"digit":                {s: "B1", wantErr: true},
+		"empty":                {s: "", wantErr: true},
+		"separators only":      {s: " / ", wantErr: true},
+		"accented letter":      {s: "Á", wantErr: true},
+		"dash is no separator": {s: "A-B", wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(c.s)
+			if (err != nil) != c.wantErr {
+				t.Fatalf("got error %v, want error %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if got.String() != c.want {
+				t.Errorf("got %q, want %q", got.String(), c.want)
+			}
+		})
+	}
+}
+
+// TestCategories_Fits is a function that checks the vehicles the categories may drive, at and
+// beyond the limits of each one
+func TestCategories_Fits(t *testing.T) {
+	cases := map[string]struct {
+		c          Categories
+		passengers int
+		weight     float64
+		want       bool
+	}{
+		"motorcycle by A":           {c: Categories{A}, passengers: 2, weight: 400, want: true},
+		"car by A":                  {c: Categories{A}, passengers: 5, weight: 1200, want: false},
+		"car by B":                  {c: Categories{B}, passengers: 5, weight: 1200, want: true},
+		"B at its limits":           {c: Categories{B}, passengers: 8, weight: 3500, want: true},
+		"B over its weight":         {c: Categories{B}, passengers: 2, weight: 3500.5, want: false},
+		"B over its passengers":     {c: Categories{B}, passengers: 9, weight: 3000, want: false},
+		"truck by C":                {c: Categories{C}, passengers: 3, weight: 12000, want: true},
+		"bus by C":                  {c: Categories{C}, passengers: 40, weight: 12000, want: false},
+		"bus by D":                  {c: Categories{D}, passengers: 40, weight: 12000, want: true},
+		"articulated by E":          {c: Categories{E}, passengers: math.MaxInt, weight: 40000, want: true},
+		"bus by AB":                 {c: Categories{A, B}, passengers: 40, weight: 12000, want: false},
+		"car by AB, the B one fits": {c: Categories{A, B}, passengers: 5, weight: 1200, want: true},
+		"no category":               {c: nil, passengers: 1, weight: 100, want: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := c.c.Fits(c.passengers, c.weight); got != c.want {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"app/pkg/tenant"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware stores in the request context a logger tagged with the request id and
+// writes an access log line once the request has been served.
+// It expects middleware.RequestID, tenant.Middleware and, to log the trace id,
+// tracing.Middleware to run before it.
+func Middleware(l *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			requestID := middleware.GetReqID(r.Context())
+			tenantID := tenant.FromContext(r.Context())
+
+			rl := l.With(
+				slog.String("request_id", requestID),
+				slog.String("tenant", tenantID),
+			)
+			if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+				rl = rl.With(slog.String("trace_id", sc.TraceID().String()))
+			}
+			w.Header().Set(middleware.RequestIDHeader, requestID)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(WithContext(r.Context(), rl)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			route := ""
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				route = rctx.RoutePattern()
+			}
+
+			rl.LogAttrs(r.Context(), slog.LevelInfo, "request",
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.String("route", route),
+				slog.Int("status", status),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package plate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Format is a format of license plate
+type Format string
+
+// formats of the Brazilian plates
+const (
+	// Mercosul is the plate of the Mercosul standard, 3 letters, a digit, a letter and 2 digits (ABC1D23)
+	Mercosul Format = "mercosul"
+	// Legacy is the plate before the Mercosul standard, 3 letters and 4 digits (ABC-1234)
+	Legacy Format = "legacy"
+)
+
+// patterns are the normalized plates of each format
+var patterns = map[Format]*regexp.Regexp{
+	Mercosul: regexp.MustCompile(`^[A-Z]{3}[0-9][A-Z][0-9]{2}$`),
+	Legacy:   regexp.MustCompile(`^[A-Z]{3}[0-9]{4}$`),
+}
+
+// Any is the key of the formats of Policy applied to the tenants without their own
+const Any = "*"
+
+// Normalize is a function that returns a plate in upper case without separators,
+// "abc-1234" and "ABC 1234" are ABC1234
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// ParseFormat is a function that returns the format of a name
+func ParseFormat(name string) (f Format, err error) {
+	f = Format(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := patterns[f]; !ok {
+		err = fmt.Errorf("plate: unknown format %q", name)
+	}
+	return
+}
+
+// Match is a method that reports whether a plate, normalized or not, is in the format
+func (f Format) Match(plate string) bool {
+	p, ok := patterns[f]
+	return ok && p.MatchString(Normalize(plate))
+}
+
+// Policy is a map that represents the formats accepted for the plates of each tenant,
+// Any applying to the tenants absent from it. A tenant without formats accepts any plate.
+type Policy map[string][]Format
+
+// ParsePolicy is a function that returns the policy written as tenant=format,format;tenant=format,
+// e.g. "acme=mercosul;*=mercosul,legacy". An empty string accepts any plate.
+func ParsePolicy(s string) (p Policy, err error) {
+	p = make(Policy)
+	for _, entry := range strings.Split(s, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		tenant, names, ok := strings.Cut(entry, "=")
+		tenant = strings.TrimSpace(tenant)
+		if !ok || tenant == "" {
+			err = fmt.Errorf("plate: malformed policy entry %q, want tenant=format,format", entry)
+			return
+		}
+		for _, name := range strings.Split(names, ",") {
+			f, e := ParseFormat(name)
+			if e != nil {
+				err = fmt.Errorf("%w, tenant %q", e, tenant)
+				return
+			}
+			p[tenant] = append(p[tenant], f)
+		}
+	}
+	return
+}
+
+// Validate is a method that returns an error when the plate is in none of the formats of the tenant
+func (p Policy) Validate(tenant, plate string) error {
+	formats, ok := p[tenant]
+	if !ok {
+		formats = p[Any]
+	}
+	if len(formats) == 0 {
+		return nil
+	}
+	for _, f := range formats {
+		if f.Match(plate) {
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(formats))
+	for _, f := range formats {
+		names = append(names, string(f))
+	}
+	sort.Strings(names)
+	return fmt.Errorf("registration %q is not a %s plate", plate, strings.Join(names, " or "))
+}
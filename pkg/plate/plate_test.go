@@ -0,0 +1,114 @@
+package plate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestNormalize is a function that checks that a plate is upper cased without separators
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"abc-1234":   "ABC1234",
+		"ABC 1234":   "ABC1234",
+		" abc1d23 ":  "ABC1D23",
+		"a.b/c-1d23": "ABC1D23",
+		"":           "",
+	}
+
+	for s, want := range cases {
+		if got := Normalize(s); got != want {
+			t.Errorf("Normalize(%q): got %q, want %q", s, got, want)
+		}
+	}
+}
+
+// TestFormat_Match is a function that checks the plates of each format, normalized or not
+func TestFormat_Match(t *testing.T) {
+	cases := map[string]struct {
+		f     Format
+		plate string
+		want  bool
+	}{
+		"mercosul":             {f: Mercosul, plate: "ABC1D23", want: true},
+		"mercosul lower case":  {f: Mercosul, plate: "abc-1d23", want: true},
+		"mercosul of a legacy": {f: Mercosul, plate: "ABC1234", want: false},
+		"legacy":               {f: Legacy, plate: "ABC-1234", want: true},
+		"legacy of a mercosul": {f: Legacy, plate: "ABC1D23", want: false},
+		"too long":             {f: Legacy, plate: "ABC12345", want: false},
+		"digits first":         {f: Mercosul, plate: "1BC1D23", want: false},
+		"unknown format":       {f: Format("eu"), plate: "ABC1234", want: false},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := c.f.Match(c.plate); got != c.want {
+				t.Errorf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestParsePolicy is a function that checks the policies written as tenant=format,format
+func TestParsePolicy(t *testing.T) {
+	cases := map[string]struct {
+		s       string
+		want    Policy
+		wantErr bool
+	}{
+		"empty":            {s: "", want: Policy{}},
+		"tenant and any":   {s: "acme=mercosul; *=Mercosul, legacy", want: Policy{"acme": {Mercosul}, Any: {Mercosul, Legacy}}},
+		"trailing entry":   {s: "acme=legacy;", want: Policy{"acme": {Legacy}}},
+		"unknown format":   {s: "acme=eu", wantErr: true},
+		"no tenant":        {s: "=mercosul", wantErr: true},
+		"no equal sign":    {s: "acme", wantErr: true},
+		"no format listed": {s: "acme=", wantErr: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			p, err := ParsePolicy(c.s)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("got %v and no error, want an error", p)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error %v, want none", err)
+			}
+			if !reflect.DeepEqual(p, c.want) {
+				t.Errorf("got %v, want %v", p, c.want)
+			}
+		})
+	}
+}
+
+// TestPolicy_Validate is a function that checks the plates accepted for a tenant, by its own
+// formats or by the ones of Any, any plate when it has none
+func TestPolicy_Validate(t *testing.T) {
+	p := Policy{"acme": {Mercosul}, "open": {}, Any: {Mercosul, Legacy}}
+
+	cases := map[string]struct {
+		p       Policy
+		tenant  string
+		plate   string
+		wantErr bool
+	}{
+		"own format":               {p: p, tenant: "acme", plate: "ABC1D23"},
+		"not in its own formats":   {p: p, tenant: "acme", plate: "ABC-1234", wantErr: true},
+		"any of the default":       {p: p, tenant: "other", plate: "ABC-1234"},
+		"none of the default":      {p: p, tenant: "other", plate: "AB-12", wantErr: true},
+		"tenant without formats":   {p: p, tenant: "open", plate: "anything"},
+		"empty policy":             {p: Policy{}, tenant: "acme", plate: "anything"},
+		"no default and no tenant": {p: Policy{"acme": {Mercosul}}, tenant: "other", plate: "anything"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.p.Validate(c.tenant, c.plate)
+			if (err != nil) != c.wantErr {
+				t.Errorf("got error %v, want error %v", err, c.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+)
+
+// Header is the name of the HTTP header that identifies the tenant of a request
+const Header = "X-Tenant-ID"
+
+// Default is the tenant used when the request does not identify one
+const Default = "default"
+
+type ctxKey struct{}
+
+// WithContext returns a copy of ctx carrying the tenant id
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the tenant id carried by ctx, or Default
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(ctxKey{}).(string); ok && id != "" {
+		return id
+	}
+	return Default
+}
+
+// Middleware stores the tenant id of the request header in the request context
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if id == "" {
+			id = Default
+		}
+		next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), id)))
+	})
+}
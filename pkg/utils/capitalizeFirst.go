@@ -1,10 +0,0 @@
-package utils
-
-import "strings"
-
-func CapitalizeFirst(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	return strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
-}